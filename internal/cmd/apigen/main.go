@@ -0,0 +1,455 @@
+// apigen writes the per-domain interfaces that moov.Client satisfies and the moovmock fake
+// implementing them, both from the Client's methods so neither drifts from the other.
+//
+// Run it through go generate in pkg/moov.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// domains maps each file of pkg/moov declaring Client methods to the interface they belong to.
+var domains = map[string]string{
+	"account_api.go":                      "AccountsAPI",
+	"avatar_api.go":                       "AccountsAPI",
+	"branding.go":                         "AccountsAPI",
+	"connections_api.go":                  "AccountsAPI",
+	"form_shortening_api.go":              "AccountsAPI",
+	"capabilities_api.go":                 "CapabilitiesAPI",
+	"representative_api.go":               "RepresentativesAPI",
+	"underwriting_api.go":                 "UnderwritingAPI",
+	"application_api.go":                  "ApplicationsAPI",
+	"terminal_application_api.go":         "TerminalApplicationsAPI",
+	"account_terminal_application_api.go": "TerminalApplicationsAPI",
+	"apple_pay.go":                        "ApplePayAPI",
+	"bank_account_api.go":                 "BankAccountsAPI",
+	"institutions.go":                     "BankAccountsAPI",
+	"cards.go":                            "CardsAPI",
+	"card_metadata.go":                    "CardsAPI",
+	"e2ee.go":                             "CardsAPI",
+	"card_issuing_api.go":                 "CardIssuingAPI",
+	"billing.go":                          "BillingAPI",
+	"fee_api.go":                          "BillingAPI",
+	"statement_api.go":                    "BillingAPI",
+	"disputes.go":                         "DisputesAPI",
+	"files.go":                            "FilesAPI",
+	"image_api.go":                        "ImagesAPI",
+	"invoice_api.go":                      "InvoicesAPI",
+	"payment_method_api.go":               "PaymentMethodsAPI",
+	"product_api.go":                      "ProductsAPI",
+	"receipt.go":                          "ReceiptsAPI",
+	"resolutionlinks_api.go":              "ResolutionLinksAPI",
+	"schedules_api.go":                    "SchedulesAPI",
+	"support_api.go":                      "SupportAPI",
+	"sweep_api.go":                        "SweepsAPI",
+	"transfer_api.go":                     "TransfersAPI",
+	"transfer_config_api.go":              "TransfersAPI",
+	"riskoutcomes_api.go":                 "TransfersAPI",
+	"wallet.go":                           "WalletsAPI",
+	"webhook.go":                          "WebhooksAPI",
+	"token.go":                            "AuthAPI",
+	"ping_api.go":                         "AuthAPI",
+}
+
+// skipped are Client methods for configuring or extending the client itself rather than
+// calling Moov.
+var skipped = []string{"CallHttp", "WithBearerToken"}
+
+// unexportedParams are Client methods taking unexported types, which fakes outside of pkg/moov
+// can't implement, so they're left out of the interfaces.
+var unexportedParams = []string{"ListSchedule", "GetScheduleOccurrence"}
+
+const moovImport = "github.com/moovfinancial/moov-go/pkg/moov"
+
+type param struct {
+	name     string
+	typ      ast.Expr
+	variadic bool
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []ast.Expr
+}
+
+type generator struct {
+	imports map[string]string // package name to import path, as used by the parsed files
+	used    map[string]bool   // import paths referenced by the output being written
+	qualify bool              // qualify pkg/moov types with "moov."
+	err     error
+}
+
+func main() {
+	dir := flag.String("dir", ".", "directory of pkg/moov")
+	apiOut := flag.String("api", "api_gen.go", "output file for the interfaces, relative to -dir")
+	mockOut := flag.String("mock", "../moovmock/client_gen.go", "output file for the fake, relative to -dir")
+	flag.Parse()
+
+	g := &generator{imports: map[string]string{}}
+	groups, order, err := g.parse(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	api, err := g.api(groups, order)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *apiOut), api, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	mock, err := g.mock(groups, order)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *mockOut), mock, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse collects the exported Client methods of every file, grouped by interface.
+func (g *generator) parse(dir string) (map[string][]method, []string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+	slices.Sort(files)
+
+	groups := map[string][]method{}
+	order := []string{}
+	fset := token.NewFileSet()
+
+	for _, path := range files {
+		name := filepath.Base(path)
+		if strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_gen.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, spec := range file.Imports {
+			p, _ := strconv.Unquote(spec.Path.Value)
+			pkg := p[strings.LastIndex(p, "/")+1:]
+			if spec.Name != nil {
+				pkg = spec.Name.Name
+			}
+			g.imports[pkg] = p
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !isClientMethod(fn) || !fn.Name.IsExported() || slices.Contains(skipped, fn.Name.Name) || slices.Contains(unexportedParams, fn.Name.Name) {
+				continue
+			}
+
+			domain, ok := domains[name]
+			if !ok {
+				return nil, nil, fmt.Errorf("%s declares Client.%s but isn't mapped to an interface in apigen", name, fn.Name.Name)
+			}
+			if _, seen := groups[domain]; !seen {
+				order = append(order, domain)
+			}
+			groups[domain] = append(groups[domain], newMethod(fn))
+		}
+	}
+
+	slices.Sort(order)
+	return groups, order, nil
+}
+
+func isClientMethod(fn *ast.FuncDecl) bool {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 {
+		return false
+	}
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	ident, ok := typ.(*ast.Ident)
+	return ok && ident.Name == "Client"
+}
+
+func newMethod(fn *ast.FuncDecl) method {
+	m := method{name: fn.Name.Name}
+	for i, field := range fn.Type.Params.List {
+		typ, variadic := field.Type, false
+		if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+			typ, variadic = ellipsis.Elt, true
+		}
+
+		if len(field.Names) == 0 {
+			m.params = append(m.params, param{name: fmt.Sprintf("p%d", i), typ: typ, variadic: variadic})
+		}
+		for _, name := range field.Names {
+			m.params = append(m.params, param{name: name.Name, typ: typ, variadic: variadic})
+		}
+	}
+	if fn.Type.Results != nil {
+		for _, field := range fn.Type.Results.List {
+			for range max(len(field.Names), 1) {
+				m.results = append(m.results, field.Type)
+			}
+		}
+	}
+	return m
+}
+
+func (g *generator) api(groups map[string][]method, order []string) ([]byte, error) {
+	g.used, g.qualify = map[string]bool{}, false
+
+	var body bytes.Buffer
+	body.WriteString("// API is every call the Client makes to Moov. Depend on it, or on one of the smaller\n")
+	body.WriteString("// per-domain interfaces, to substitute a fake such as moovmock.Client in tests.\n")
+	body.WriteString("type API interface {\n")
+	for _, domain := range order {
+		fmt.Fprintf(&body, "\t%s\n", domain)
+	}
+	body.WriteString("}\n\n")
+
+	for _, domain := range order {
+		fmt.Fprintf(&body, "// %s is implemented by *Client.\n", domain)
+		fmt.Fprintf(&body, "type %s interface {\n", domain)
+		for _, m := range groups[domain] {
+			fmt.Fprintf(&body, "\t%s%s\n", m.name, g.signature(m))
+		}
+		body.WriteString("}\n\n")
+	}
+
+	body.WriteString("var (\n\t_ API = (*Client)(nil)\n")
+	for _, domain := range order {
+		fmt.Fprintf(&body, "\t_ %s = (*Client)(nil)\n", domain)
+	}
+	body.WriteString(")\n")
+
+	return g.file("moov", body.Bytes())
+}
+
+func (g *generator) mock(groups map[string][]method, order []string) ([]byte, error) {
+	g.used, g.qualify = map[string]bool{moovImport: true}, true
+
+	var fields, methods bytes.Buffer
+	for _, domain := range order {
+		fmt.Fprintf(&fields, "\n\t// %s\n\n", domain)
+		for _, m := range groups[domain] {
+			fmt.Fprintf(&fields, "\t%sFunc func%s\n", m.name, g.signature(m))
+
+			fmt.Fprintf(&methods, "\n// %s records the call and returns the results of %sFunc.\n", m.name, m.name)
+			fmt.Fprintf(&methods, "func (c *Client) %s%s {\n", m.name, g.signature(m))
+			fmt.Fprintf(&methods, "\tc.record(%q%s)\n", m.name, recordArgs(m, g))
+			fmt.Fprintf(&methods, "\tif c.%sFunc == nil {\n", m.name)
+			fmt.Fprintf(&methods, "\t\terr := notStubbed(%q)\n", m.name)
+			fmt.Fprintf(&methods, "\t\t%s\n", g.zeroReturn(m))
+			methods.WriteString("\t}\n")
+			fmt.Fprintf(&methods, "\treturn c.%sFunc(%s)\n", m.name, forwardArgs(m))
+			methods.WriteString("}\n")
+		}
+	}
+
+	var body bytes.Buffer
+	body.WriteString("// Client is a programmable fake of moov.API. Set the Func field of each method a test relies\n")
+	body.WriteString("// on, methods without one return ErrNotStubbed. Every call is recorded, see Calls.\n")
+	body.WriteString("type Client struct {")
+	body.Write(fields.Bytes())
+	body.WriteString("\n\tmu    sync.Mutex\n\tcalls []Call\n}\n\n")
+	body.WriteString("var _ moov.API = (*Client)(nil)\n")
+	body.Write(methods.Bytes())
+	g.used["sync"] = true
+
+	return g.file("moovmock", body.Bytes())
+}
+
+func (g *generator) file(pkg string, body []byte) ([]byte, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by apigen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\nimport (\n", pkg)
+	std, others := []string{}, []string{}
+	for p := range g.used {
+		if strings.Contains(strings.Split(p, "/")[0], ".") {
+			others = append(others, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(others)
+	for _, p := range std {
+		fmt.Fprintf(&out, "\t%q\n", p)
+	}
+	if len(std) > 0 && len(others) > 0 {
+		out.WriteString("\n")
+	}
+	for _, p := range others {
+		fmt.Fprintf(&out, "\t%q\n", p)
+	}
+	out.WriteString(")\n\n")
+	out.Write(body)
+
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) signature(m method) string {
+	params := []string{}
+	for _, p := range m.params {
+		prefix := ""
+		if p.variadic {
+			prefix = "..."
+		}
+		params = append(params, p.name+" "+prefix+g.typ(p.typ))
+	}
+
+	results := []string{}
+	for _, r := range m.results {
+		results = append(results, g.typ(r))
+	}
+
+	switch len(results) {
+	case 0:
+		return "(" + strings.Join(params, ", ") + ")"
+	case 1:
+		return "(" + strings.Join(params, ", ") + ") " + results[0]
+	default:
+		return "(" + strings.Join(params, ", ") + ") (" + strings.Join(results, ", ") + ")"
+	}
+}
+
+// typ renders a type expression, noting the imports it needs.
+func (g *generator) typ(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if !e.IsExported() {
+			if isPredeclared(e.Name) {
+				return e.Name
+			}
+			g.fail(fmt.Errorf("unexported type %s can't be used outside of pkg/moov", e.Name))
+			return e.Name
+		}
+		if g.qualify {
+			return "moov." + e.Name
+		}
+		return e.Name
+	case *ast.SelectorExpr:
+		pkg := e.X.(*ast.Ident).Name
+		p, ok := g.imports[pkg]
+		if !ok {
+			g.fail(fmt.Errorf("unknown package %s", pkg))
+		}
+		g.used[p] = true
+		return pkg + "." + e.Sel.Name
+	case *ast.StarExpr:
+		return "*" + g.typ(e.X)
+	case *ast.ArrayType:
+		return "[]" + g.typ(e.Elt)
+	case *ast.MapType:
+		return "map[" + g.typ(e.Key) + "]" + g.typ(e.Value)
+	case *ast.IndexExpr:
+		return g.typ(e.X) + "[" + g.typ(e.Index) + "]"
+	case *ast.IndexListExpr:
+		args := []string{}
+		for _, arg := range e.Indices {
+			args = append(args, g.typ(arg))
+		}
+		return g.typ(e.X) + "[" + strings.Join(args, ", ") + "]"
+	case *ast.InterfaceType:
+		return "any"
+	default:
+		g.fail(fmt.Errorf("unsupported type %T", expr))
+		return ""
+	}
+}
+
+// zeroReturn returns the zero value of every result, with err in place of errors.
+func (g *generator) zeroReturn(m method) string {
+	values := []string{}
+	hasErr := false
+	for _, r := range m.results {
+		switch e := r.(type) {
+		case *ast.StarExpr, *ast.ArrayType, *ast.MapType, *ast.InterfaceType:
+			values = append(values, "nil")
+		case *ast.Ident:
+			switch e.Name {
+			case "error":
+				values, hasErr = append(values, "err"), true
+			case "string":
+				values = append(values, `""`)
+			case "bool":
+				values = append(values, "false")
+			case "CreateTransferBuilder":
+				values, hasErr = append(values, "CreateTransferResult(nil, nil, err)"), true
+			default:
+				g.fail(fmt.Errorf("%s: no zero value for %s", m.name, e.Name))
+			}
+		case *ast.IndexListExpr:
+			if g.typ(e.X) != "iter.Seq2" {
+				g.fail(fmt.Errorf("%s: no zero value for %s", m.name, g.typ(e)))
+			}
+			values, hasErr = append(values, "failedSeq["+g.typ(e.Indices[0])+"](err)"), true
+		default:
+			g.fail(fmt.Errorf("%s: no zero value for %T", m.name, r))
+		}
+	}
+	if !hasErr {
+		g.fail(fmt.Errorf("%s: nowhere to return ErrNotStubbed", m.name))
+	}
+	return "return " + strings.Join(values, ", ")
+}
+
+// recordArgs lists the arguments to record, leaving out the context.
+func recordArgs(m method, g *generator) string {
+	out := ""
+	for _, p := range m.params {
+		if g.typ(p.typ) == "context.Context" {
+			continue
+		}
+		out += ", " + p.name
+	}
+	return out
+}
+
+func forwardArgs(m method) string {
+	args := []string{}
+	for _, p := range m.params {
+		if p.variadic {
+			args = append(args, p.name+"...")
+			continue
+		}
+		args = append(args, p.name)
+	}
+	return strings.Join(args, ", ")
+}
+
+func (g *generator) fail(err error) {
+	if g.err == nil {
+		g.err = err
+	}
+}
+
+func isPredeclared(name string) bool {
+	switch name {
+	case "any", "bool", "byte", "error", "string", "rune",
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64":
+		return true
+	}
+	return false
+}
@@ -12,151 +12,255 @@ import (
 // Access the event payload by calling the corresponding getter method.
 // For example, if the event.EventType=account.created, call event.AccountCreated().
 //
-// The webhook signature is verified using the provided signing secret.
-func ParseEvent(r *http.Request, secret string) (*Event, error) {
-	isValid, err := checkSignature(r.Header, secret)
+// The webhook signature is verified using the provided signing secret. Pass WithMaxClockSkew
+// and WithNonceStore to reject replayed webhooks as well, and WithUnknownEventTypes to get events
+// of types this package doesn't know yet rather than an error.
+func ParseEvent(r *http.Request, secret string, opts ...ParseOption) (*Event, error) {
+	event, _, err := parseEvent(r, []string{secret}, opts)
+	return event, err
+}
+
+// ParseEventWithSecrets is ParseEvent accepting webhooks signed with any of the secrets returned
+// by the provider for the webhook, e.g. while a secret is rotated. It returns the index of the
+// secret that matched.
+func ParseEventWithSecrets(r *http.Request, provider SecretProvider, opts ...ParseOption) (*Event, int, error) {
+	secrets, err := provider.Secrets(r.Context(), r.Header.Get("x-webhook-id"))
 	if err != nil {
-		return nil, fmt.Errorf("checking webhook signature: %w", err)
+		return nil, -1, fmt.Errorf("getting webhook secrets: %w", err)
+	}
+	return parseEvent(r, secrets, opts)
+}
+
+// ParseOption configures ParseEvent. Every VerifyOption is a ParseOption too.
+type ParseOption interface {
+	applyParse(p *parser)
+}
+
+type parser struct {
+	verify       []VerifyOption
+	unknownTypes bool
+}
+
+type parseOptionFunc func(p *parser)
+
+func (fn parseOptionFunc) applyParse(p *parser) {
+	fn(p)
+}
+
+// WithUnknownEventTypes returns events of types this package doesn't know yet with only their
+// raw Data instead of failing with an invalid event type error, so new events Moov starts sending
+// can be acknowledged or decoded with DecodeData.
+func WithUnknownEventTypes() ParseOption {
+	return parseOptionFunc(func(p *parser) {
+		p.unknownTypes = true
+	})
+}
+
+func parseEvent(r *http.Request, secrets []string, opts []ParseOption) (*Event, int, error) {
+	p := &parser{}
+	for _, opt := range opts {
+		opt.applyParse(p)
 	}
 
-	if !isValid {
-		return nil, ErrInvalidSignature
+	v := newVerifier(p.verify)
+	matched, err := v.verify(r.Context(), r.Header, secrets)
+	if err != nil {
+		return nil, -1, err
 	}
 
 	var event Event
 	err = json.NewDecoder(r.Body).Decode(&event)
 	if err != nil {
-		return nil, fmt.Errorf("decoding event: %w", err)
+		return nil, -1, fmt.Errorf("decoding event: %w", err)
+	}
+	if !event.known && !p.unknownTypes {
+		return nil, -1, fmt.Errorf("invalid event type: %v", event.EventType)
+	}
+
+	// Recorded last, so Moov's redelivery of a webhook that failed to parse isn't a replay.
+	err = v.addNonce(r.Context(), r.Header)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	return &event, matched, nil
+}
+
+type Event struct {
+	EventID   string          `json:"eventID"`
+	EventType EventType       `json:"type"`
+	CreatedOn time.Time       `json:"createdOn"`
+	Data      json.RawMessage `json:"data"`
+
+	// known is set for event types with a getter.
+	known bool
+
+	accountCreated                 *AccountCreated
+	accountDeleted                 *AccountDisconnected
+	accountUpdated                 *AccountUpdated
+	balanceUpdated                 *BalanceUpdated
+	bankAccountCreated             *BankAccountCreated
+	bankAccountDeleted             *BankAccountDeleted
+	bankAccountUpdated             *BankAccountUpdated
+	billingStatementCreated        *BillingStatementCreated
+	cancellationCreated            *CancellationCreated
+	cancellationUpdated            *CancellationUpdated
+	cardAutoUpdated                *CardAutoUpdated
+	capabilityRequested            *CapabilityRequested
+	capabilityUpdated              *CapabilityUpdated
+	disputeCreated                 *DisputeCreated
+	disputeUpdated                 *DisputeUpdated
+	invoiceCreated                 *InvoiceCreated
+	invoiceUpdated                 *InvoiceUpdated
+	networkIDUpdated               *NetworkIDUpdated
+	paymentMethodDisabled          *PaymentMethodDisabled
+	paymentMethodEnabled           *PaymentMethodEnabled
+	refundCreated                  *RefundCreated
+	refundUpdated                  *RefundUpdated
+	representativeCreated          *RepresentativeCreated
+	representativeDeleted          *RepresentativeDeleted
+	representativeUpdated          *RepresentativeUpdated
+	sweepCreated                   *SweepCreated
+	sweepUpdated                   *SweepUpdated
+	testPing                       *TestPing
+	ticketCreated                  *TicketCreated
+	ticketUpdated                  *TicketUpdated
+	ticketMessageAdded             *TicketMessageAdded
+	transferCreated                *TransferCreated
+	transferUpdated                *TransferUpdated
+	walletCreated                  *WalletCreated
+	walletUpdated                  *WalletUpdated
+	walletTransactionUpdated       *WalletTransactionUpdated
+	issuedCardCreated              *IssuedCardCreated
+	issuedCardUpdated              *IssuedCardUpdated
+	issuedCardAuthorizationCreated *IssuedCardAuthorizationCreated
+	issuedCardAuthorizationUpdated *IssuedCardAuthorizationUpdated
+	issuedCardTransactionCreated   *IssuedCardTransactionCreated
+	resolutionLinkCreated          *ResolutionLinkCreated
+	resolutionLinkUpdated          *ResolutionLinkUpdated
+	scheduleCreated                *ScheduleCreated
+	scheduleUpdated                *ScheduleUpdated
+	scheduleOccurrenceUpdated      *ScheduleOccurrenceUpdated
+	underwritingUpdated            *UnderwritingUpdated
+}
+
+// UnmarshalJSON decodes the event along with the payload returned by its getter, so events
+// stored as JSON can be handled like parsed ones. The payload of an event of a type this
+// package doesn't know is left to DecodeData.
+func (e *Event) UnmarshalJSON(b []byte) error {
+	type event Event // without the UnmarshalJSON method
+	err := json.Unmarshal(b, (*event)(e))
+	if err != nil {
+		return err
 	}
 
 	var eventData any
-	switch event.EventType {
+	switch e.EventType {
 	case EventTypeAccountCreated:
-		eventData = &event.accountCreated
+		eventData = &e.accountCreated
 	case EventTypeAccountDisconnected:
-		eventData = &event.accountDeleted
+		eventData = &e.accountDeleted
 	case EventTypeAccountUpdated:
-		eventData = &event.accountUpdated
+		eventData = &e.accountUpdated
 	case EventTypeBalanceUpdated:
-		eventData = &event.balanceUpdated
+		eventData = &e.balanceUpdated
 	case EventTypeBankAccountCreated:
-		eventData = &event.bankAccountCreated
+		eventData = &e.bankAccountCreated
 	case EventTypeBankAccountDeleted:
-		eventData = &event.bankAccountDeleted
+		eventData = &e.bankAccountDeleted
 	case EventTypeBankAccountUpdated:
-		eventData = &event.bankAccountUpdated
+		eventData = &e.bankAccountUpdated
 	case EventTypeCancellationCreated:
-		eventData = &event.cancellationCreated
+		eventData = &e.cancellationCreated
 	case EventTypeCancellationUpdated:
-		eventData = &event.cancellationUpdated
+		eventData = &e.cancellationUpdated
 	case EventTypeCardAutoUpdated:
-		eventData = &event.cardAutoUpdated
+		eventData = &e.cardAutoUpdated
 	case EventTypeCapabilityRequested:
-		eventData = &event.capabilityRequested
+		eventData = &e.capabilityRequested
 	case EventTypeCapabilityUpdated:
-		eventData = &event.capabilityUpdated
+		eventData = &e.capabilityUpdated
 	case EventTypeDisputeCreated:
-		eventData = &event.disputeCreated
+		eventData = &e.disputeCreated
 	case EventTypeDisputeUpdated:
-		eventData = &event.disputeUpdated
+		eventData = &e.disputeUpdated
 	case EventTypeNetworkIDUpdated:
-		eventData = &event.networkIDUpdated
+		eventData = &e.networkIDUpdated
 	case EventTypePaymentMethodDisabled:
-		eventData = &event.paymentMethodDisabled
+		eventData = &e.paymentMethodDisabled
 	case EventTypePaymentMethodEnabled:
-		eventData = &event.paymentMethodEnabled
+		eventData = &e.paymentMethodEnabled
 	case EventTypeRefundCreated:
-		eventData = &event.refundCreated
+		eventData = &e.refundCreated
 	case EventTypeRefundUpdated:
-		eventData = &event.refundUpdated
+		eventData = &e.refundUpdated
 	case EventTypeRepresentativeCreated:
-		eventData = &event.representativeCreated
+		eventData = &e.representativeCreated
 	case EventTypeRepresentativeDeleted:
-		eventData = &event.representativeDeleted
+		eventData = &e.representativeDeleted
 	case EventTypeRepresentativeUpdated:
-		eventData = &event.representativeUpdated
+		eventData = &e.representativeUpdated
 	case EventTypeSweepCreated:
-		eventData = &event.sweepCreated
+		eventData = &e.sweepCreated
 	case EventTypeSweepUpdated:
-		eventData = &event.sweepUpdated
+		eventData = &e.sweepUpdated
 	case EventTypeTestPing:
-		eventData = &event.testPing
+		eventData = &e.testPing
 	case EventTypeTicketCreated:
-		eventData = &event.ticketCreated
+		eventData = &e.ticketCreated
 	case EventTypeTicketUpdated:
-		eventData = &event.ticketUpdated
+		eventData = &e.ticketUpdated
 	case EventTypeTicketMessageAdded:
-		eventData = &event.ticketMessageAdded
+		eventData = &e.ticketMessageAdded
 	case EventTypeTransferCreated:
-		eventData = &event.transferCreated
+		eventData = &e.transferCreated
 	case EventTypeTransferUpdated:
-		eventData = &event.transferUpdated
+		eventData = &e.transferUpdated
 	case EventTypeWalletCreated:
-		eventData = &event.walletCreated
+		eventData = &e.walletCreated
 	case EventTypeWalletUpdated:
-		eventData = &event.walletUpdated
+		eventData = &e.walletUpdated
 	case EventTypeWalletTransactionUpdated:
-		eventData = &event.walletTransactionUpdated
+		eventData = &e.walletTransactionUpdated
 	case EventTypeBillingStatementCreated:
-		eventData = &event.billingStatementCreated
+		eventData = &e.billingStatementCreated
 	case EventTypeInvoiceCreated:
-		eventData = &event.invoiceCreated
+		eventData = &e.invoiceCreated
 	case EventTypeInvoiceUpdated:
-		eventData = &event.invoiceUpdated
+		eventData = &e.invoiceUpdated
+	case EventTypeIssuedCardCreated:
+		eventData = &e.issuedCardCreated
+	case EventTypeIssuedCardUpdated:
+		eventData = &e.issuedCardUpdated
+	case EventTypeIssuedCardAuthorizationCreated:
+		eventData = &e.issuedCardAuthorizationCreated
+	case EventTypeIssuedCardAuthorizationUpdated:
+		eventData = &e.issuedCardAuthorizationUpdated
+	case EventTypeIssuedCardTransactionCreated:
+		eventData = &e.issuedCardTransactionCreated
+	case EventTypeResolutionLinkCreated:
+		eventData = &e.resolutionLinkCreated
+	case EventTypeResolutionLinkUpdated:
+		eventData = &e.resolutionLinkUpdated
+	case EventTypeScheduleCreated:
+		eventData = &e.scheduleCreated
+	case EventTypeScheduleUpdated:
+		eventData = &e.scheduleUpdated
+	case EventTypeScheduleOccurrenceUpdated:
+		eventData = &e.scheduleOccurrenceUpdated
+	case EventTypeUnderwritingUpdated:
+		eventData = &e.underwritingUpdated
 	default:
-		return nil, fmt.Errorf("invalid event type: %v", event.EventType)
+		return nil
 	}
 
-	err = json.Unmarshal(event.Data, eventData)
+	e.known = true
+
+	err = json.Unmarshal(e.Data, eventData)
 	if err != nil {
-		return nil, fmt.Errorf("unmarshalling event data: %w", err)
+		return fmt.Errorf("unmarshalling event data: %w", err)
 	}
-
-	return &event, nil
-}
-
-type Event struct {
-	EventID   string          `json:"eventID"`
-	EventType EventType       `json:"type"`
-	CreatedOn time.Time       `json:"createdOn"`
-	Data      json.RawMessage `json:"data"`
-
-	accountCreated           *AccountCreated
-	accountDeleted           *AccountDisconnected
-	accountUpdated           *AccountUpdated
-	balanceUpdated           *BalanceUpdated
-	bankAccountCreated       *BankAccountCreated
-	bankAccountDeleted       *BankAccountDeleted
-	bankAccountUpdated       *BankAccountUpdated
-	billingStatementCreated  *BillingStatementCreated
-	cancellationCreated      *CancellationCreated
-	cancellationUpdated      *CancellationUpdated
-	cardAutoUpdated          *CardAutoUpdated
-	capabilityRequested      *CapabilityRequested
-	capabilityUpdated        *CapabilityUpdated
-	disputeCreated           *DisputeCreated
-	disputeUpdated           *DisputeUpdated
-	invoiceCreated           *InvoiceCreated
-	invoiceUpdated           *InvoiceUpdated
-	networkIDUpdated         *NetworkIDUpdated
-	paymentMethodDisabled    *PaymentMethodDisabled
-	paymentMethodEnabled     *PaymentMethodEnabled
-	refundCreated            *RefundCreated
-	refundUpdated            *RefundUpdated
-	representativeCreated    *RepresentativeCreated
-	representativeDeleted    *RepresentativeDeleted
-	representativeUpdated    *RepresentativeUpdated
-	sweepCreated             *SweepCreated
-	sweepUpdated             *SweepUpdated
-	testPing                 *TestPing
-	ticketCreated            *TicketCreated
-	ticketUpdated            *TicketUpdated
-	ticketMessageAdded       *TicketMessageAdded
-	transferCreated          *TransferCreated
-	transferUpdated          *TransferUpdated
-	walletCreated            *WalletCreated
-	walletUpdated            *WalletUpdated
-	walletTransactionUpdated *WalletTransactionUpdated
+	return nil
 }
 
 func (e Event) AccountCreated() (*AccountCreated, error) {
@@ -447,6 +551,104 @@ func (e Event) InvoiceUpdated() (*InvoiceUpdated, error) {
 	return e.invoiceUpdated, nil
 }
 
+func (e Event) IssuedCardCreated() (*IssuedCardCreated, error) {
+	if e.EventType != EventTypeIssuedCardCreated {
+		return nil, newInvalidEventTypeError(EventTypeIssuedCardCreated, e.EventType)
+	}
+
+	return e.issuedCardCreated, nil
+}
+
+func (e Event) IssuedCardUpdated() (*IssuedCardUpdated, error) {
+	if e.EventType != EventTypeIssuedCardUpdated {
+		return nil, newInvalidEventTypeError(EventTypeIssuedCardUpdated, e.EventType)
+	}
+
+	return e.issuedCardUpdated, nil
+}
+
+func (e Event) IssuedCardAuthorizationCreated() (*IssuedCardAuthorizationCreated, error) {
+	if e.EventType != EventTypeIssuedCardAuthorizationCreated {
+		return nil, newInvalidEventTypeError(EventTypeIssuedCardAuthorizationCreated, e.EventType)
+	}
+
+	return e.issuedCardAuthorizationCreated, nil
+}
+
+func (e Event) IssuedCardAuthorizationUpdated() (*IssuedCardAuthorizationUpdated, error) {
+	if e.EventType != EventTypeIssuedCardAuthorizationUpdated {
+		return nil, newInvalidEventTypeError(EventTypeIssuedCardAuthorizationUpdated, e.EventType)
+	}
+
+	return e.issuedCardAuthorizationUpdated, nil
+}
+
+func (e Event) IssuedCardTransactionCreated() (*IssuedCardTransactionCreated, error) {
+	if e.EventType != EventTypeIssuedCardTransactionCreated {
+		return nil, newInvalidEventTypeError(EventTypeIssuedCardTransactionCreated, e.EventType)
+	}
+
+	return e.issuedCardTransactionCreated, nil
+}
+
+func (e Event) ResolutionLinkCreated() (*ResolutionLinkCreated, error) {
+	if e.EventType != EventTypeResolutionLinkCreated {
+		return nil, newInvalidEventTypeError(EventTypeResolutionLinkCreated, e.EventType)
+	}
+
+	return e.resolutionLinkCreated, nil
+}
+
+func (e Event) ResolutionLinkUpdated() (*ResolutionLinkUpdated, error) {
+	if e.EventType != EventTypeResolutionLinkUpdated {
+		return nil, newInvalidEventTypeError(EventTypeResolutionLinkUpdated, e.EventType)
+	}
+
+	return e.resolutionLinkUpdated, nil
+}
+
+func (e Event) ScheduleCreated() (*ScheduleCreated, error) {
+	if e.EventType != EventTypeScheduleCreated {
+		return nil, newInvalidEventTypeError(EventTypeScheduleCreated, e.EventType)
+	}
+
+	return e.scheduleCreated, nil
+}
+
+func (e Event) ScheduleUpdated() (*ScheduleUpdated, error) {
+	if e.EventType != EventTypeScheduleUpdated {
+		return nil, newInvalidEventTypeError(EventTypeScheduleUpdated, e.EventType)
+	}
+
+	return e.scheduleUpdated, nil
+}
+
+func (e Event) ScheduleOccurrenceUpdated() (*ScheduleOccurrenceUpdated, error) {
+	if e.EventType != EventTypeScheduleOccurrenceUpdated {
+		return nil, newInvalidEventTypeError(EventTypeScheduleOccurrenceUpdated, e.EventType)
+	}
+
+	return e.scheduleOccurrenceUpdated, nil
+}
+
+func (e Event) UnderwritingUpdated() (*UnderwritingUpdated, error) {
+	if e.EventType != EventTypeUnderwritingUpdated {
+		return nil, newInvalidEventTypeError(EventTypeUnderwritingUpdated, e.EventType)
+	}
+
+	return e.underwritingUpdated, nil
+}
+
+// DecodeData unmarshals the raw payload of the event into a T, e.g. for events of types this
+// package doesn't know yet returned by WithUnknownEventTypes.
+func DecodeData[T any](event *Event) (*T, error) {
+	var data T
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return nil, fmt.Errorf("unmarshalling %s event data: %w", event.EventType, err)
+	}
+	return &data, nil
+}
+
 func newInvalidEventTypeError(expected, got EventType) error {
 	return fmt.Errorf("invalid event type: expected %v but got %v", expected, got)
 }
@@ -130,3 +130,103 @@ func TestBankAccountCreated(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, ba, *got)
 }
+
+func TestParseEvent_UnknownEventTypes(t *testing.T) {
+	type paymentLinkCreated struct {
+		PaymentLinkCode string `json:"paymentLinkCode"`
+	}
+	data := paymentLinkCreated{PaymentLinkCode: "code"}
+
+	_, err := ParseEvent(signedRequest(t, testSecret, "paymentLink.created", data), testSecret)
+	require.ErrorContains(t, err, "invalid event type")
+
+	event, err := ParseEvent(signedRequest(t, testSecret, "paymentLink.created", data), testSecret, WithUnknownEventTypes())
+	require.NoError(t, err)
+	require.Equal(t, EventType("paymentLink.created"), event.EventType)
+
+	got, err := DecodeData[paymentLinkCreated](event)
+	require.NoError(t, err)
+	require.Equal(t, data, *got)
+
+	_, err = event.TransferCreated()
+	require.Error(t, err)
+
+	// Known event types are still hydrated.
+	transfer := TransferUpdated{AccountID: "account", TransferID: "transfer", Status: TransferUpdatedStatus_Completed}
+	event, err = ParseEvent(signedRequest(t, testSecret, EventTypeTransferUpdated, transfer), testSecret, WithUnknownEventTypes())
+	require.NoError(t, err)
+
+	gotTransfer, err := event.TransferUpdated()
+	require.NoError(t, err)
+	require.Equal(t, transfer, *gotTransfer)
+}
+
+func TestEvent_RESTModelPayloads(t *testing.T) {
+	// The payloads share their fields with the REST models, so they decode from the same JSON.
+	for _, tt := range []struct {
+		eventType EventType
+		data      any
+		get       func(Event) (any, error)
+		want      any
+	}{
+		{
+			eventType: EventTypeIssuedCardTransactionCreated,
+			data: moov.IssuedCardTransaction{
+				CardTransactionID: "transaction",
+				IssuedCardID:      "card",
+				AuthorizationID:   moov.PtrOf("authorization"),
+			},
+			get: func(e Event) (any, error) { return e.IssuedCardTransactionCreated() },
+			want: &IssuedCardTransactionCreated{
+				IssuedCardID:      "card",
+				CardTransactionID: "transaction",
+				AuthorizationID:   moov.PtrOf("authorization"),
+			},
+		},
+		{
+			eventType: EventTypeResolutionLinkUpdated,
+			data: moov.ResolutionLinkResponse{
+				ResolutionLinkCode: "code",
+				AccountID:          "account",
+				Status:             moov.ResolutionLinkStatus_Completed,
+			},
+			get: func(e Event) (any, error) { return e.ResolutionLinkUpdated() },
+			want: &ResolutionLinkUpdated{
+				AccountID:          "account",
+				ResolutionLinkCode: "code",
+				Status:             moov.ResolutionLinkStatus_Completed,
+			},
+		},
+		{
+			eventType: EventTypeScheduleOccurrenceUpdated,
+			data: moov.Occurrence{
+				ScheduleID:    "schedule",
+				OccurrenceID:  "occurrence",
+				RunTransferID: moov.PtrOf("transfer"),
+				Status:        moov.PtrOf("completed"),
+			},
+			get: func(e Event) (any, error) { return e.ScheduleOccurrenceUpdated() },
+			want: &ScheduleOccurrenceUpdated{
+				ScheduleID:    "schedule",
+				OccurrenceID:  "occurrence",
+				Status:        moov.PtrOf("completed"),
+				RunTransferID: moov.PtrOf("transfer"),
+			},
+		},
+		{
+			eventType: EventTypeUnderwritingUpdated,
+			data:      moov.Underwriting{Status: moov.UnderwritingStatusApproved},
+			get:       func(e Event) (any, error) { return e.UnderwritingUpdated() },
+			want:      &UnderwritingUpdated{Status: moov.UnderwritingStatusApproved},
+		},
+	} {
+		t.Run(string(tt.eventType), func(t *testing.T) {
+			event, err := ParseEvent(signedRequest(t, testSecret, tt.eventType, tt.data), testSecret)
+			require.NoError(t, err)
+
+			got, err := tt.get(*event)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -9,42 +9,53 @@ import (
 type EventType = moov.EventType
 
 const (
-	EventTypeAccountCreated           = moov.EventTypeAccountCreated
-	EventTypeAccountDisconnected      = moov.EventTypeAccountDisconnected
-	EventTypeAccountUpdated           = moov.EventTypeAccountUpdated
-	EventTypeBalanceUpdated           = moov.EventTypeBalanceUpdated
-	EventTypeBankAccountCreated       = moov.EventTypeBankAccountCreated
-	EventTypeBankAccountDeleted       = moov.EventTypeBankAccountDeleted
-	EventTypeBankAccountUpdated       = moov.EventTypeBankAccountUpdated
-	EventTypeBillingStatementCreated  = moov.EventTypeBillingStatementCreated
-	EventTypeCancellationCreated      = moov.EventTypeCancellationCreated
-	EventTypeCancellationUpdated      = moov.EventTypeCancellationUpdated
-	EventTypeCardAutoUpdated          = moov.EventTypeCardAutoUpdated
-	EventTypeCapabilityRequested      = moov.EventTypeCapabilityRequested
-	EventTypeCapabilityUpdated        = moov.EventTypeCapabilityUpdated
-	EventTypeDisputeCreated           = moov.EventTypeDisputeCreated
-	EventTypeDisputeUpdated           = moov.EventTypeDisputeUpdated
-	EventTypeInvoiceCreated           = moov.EventTypeInvoiceCreated
-	EventTypeInvoiceUpdated           = moov.EventTypeInvoiceUpdated
-	EventTypeNetworkIDUpdated         = moov.EventTypeNetworkIDUpdated
-	EventTypePaymentMethodDisabled    = moov.EventTypePaymentMethodDisabled
-	EventTypePaymentMethodEnabled     = moov.EventTypePaymentMethodEnabled
-	EventTypeRefundCreated            = moov.EventTypeRefundCreated
-	EventTypeRefundUpdated            = moov.EventTypeRefundUpdated
-	EventTypeRepresentativeCreated    = moov.EventTypeRepresentativeCreated
-	EventTypeRepresentativeDeleted    = moov.EventTypeRepresentativeDeleted
-	EventTypeRepresentativeUpdated    = moov.EventTypeRepresentativeUpdated
-	EventTypeSweepCreated             = moov.EventTypeSweepCreated
-	EventTypeSweepUpdated             = moov.EventTypeSweepUpdated
-	EventTypeTestPing                 = moov.EventTypeTestPing
-	EventTypeTicketCreated            = moov.EventTypeTicketCreated
-	EventTypeTicketUpdated            = moov.EventTypeTicketUpdated
-	EventTypeTicketMessageAdded       = moov.EventTypeTicketMessageAdded
-	EventTypeTransferCreated          = moov.EventTypeTransferCreated
-	EventTypeTransferUpdated          = moov.EventTypeTransferUpdated
-	EventTypeWalletCreated            = moov.EventTypeWalletCreated
-	EventTypeWalletUpdated            = moov.EventTypeWalletUpdated
-	EventTypeWalletTransactionUpdated = moov.EventTypeWalletTransactionUpdated
+	EventTypeAccountCreated                 = moov.EventTypeAccountCreated
+	EventTypeAccountDisconnected            = moov.EventTypeAccountDisconnected
+	EventTypeAccountUpdated                 = moov.EventTypeAccountUpdated
+	EventTypeBalanceUpdated                 = moov.EventTypeBalanceUpdated
+	EventTypeBankAccountCreated             = moov.EventTypeBankAccountCreated
+	EventTypeBankAccountDeleted             = moov.EventTypeBankAccountDeleted
+	EventTypeBankAccountUpdated             = moov.EventTypeBankAccountUpdated
+	EventTypeBillingStatementCreated        = moov.EventTypeBillingStatementCreated
+	EventTypeCancellationCreated            = moov.EventTypeCancellationCreated
+	EventTypeCancellationUpdated            = moov.EventTypeCancellationUpdated
+	EventTypeCardAutoUpdated                = moov.EventTypeCardAutoUpdated
+	EventTypeCapabilityRequested            = moov.EventTypeCapabilityRequested
+	EventTypeCapabilityUpdated              = moov.EventTypeCapabilityUpdated
+	EventTypeDisputeCreated                 = moov.EventTypeDisputeCreated
+	EventTypeDisputeUpdated                 = moov.EventTypeDisputeUpdated
+	EventTypeInvoiceCreated                 = moov.EventTypeInvoiceCreated
+	EventTypeInvoiceUpdated                 = moov.EventTypeInvoiceUpdated
+	EventTypeIssuedCardCreated              = moov.EventTypeIssuedCardCreated
+	EventTypeIssuedCardUpdated              = moov.EventTypeIssuedCardUpdated
+	EventTypeIssuedCardAuthorizationCreated = moov.EventTypeIssuedCardAuthorizationCreated
+	EventTypeIssuedCardAuthorizationUpdated = moov.EventTypeIssuedCardAuthorizationUpdated
+	EventTypeIssuedCardTransactionCreated   = moov.EventTypeIssuedCardTransactionCreated
+	EventTypeNetworkIDUpdated               = moov.EventTypeNetworkIDUpdated
+	EventTypePaymentMethodDisabled          = moov.EventTypePaymentMethodDisabled
+	EventTypePaymentMethodEnabled           = moov.EventTypePaymentMethodEnabled
+	EventTypeRefundCreated                  = moov.EventTypeRefundCreated
+	EventTypeRefundUpdated                  = moov.EventTypeRefundUpdated
+	EventTypeRepresentativeCreated          = moov.EventTypeRepresentativeCreated
+	EventTypeRepresentativeDeleted          = moov.EventTypeRepresentativeDeleted
+	EventTypeRepresentativeUpdated          = moov.EventTypeRepresentativeUpdated
+	EventTypeResolutionLinkCreated          = moov.EventTypeResolutionLinkCreated
+	EventTypeResolutionLinkUpdated          = moov.EventTypeResolutionLinkUpdated
+	EventTypeScheduleCreated                = moov.EventTypeScheduleCreated
+	EventTypeScheduleUpdated                = moov.EventTypeScheduleUpdated
+	EventTypeScheduleOccurrenceUpdated      = moov.EventTypeScheduleOccurrenceUpdated
+	EventTypeSweepCreated                   = moov.EventTypeSweepCreated
+	EventTypeSweepUpdated                   = moov.EventTypeSweepUpdated
+	EventTypeTestPing                       = moov.EventTypeTestPing
+	EventTypeTicketCreated                  = moov.EventTypeTicketCreated
+	EventTypeTicketUpdated                  = moov.EventTypeTicketUpdated
+	EventTypeTicketMessageAdded             = moov.EventTypeTicketMessageAdded
+	EventTypeTransferCreated                = moov.EventTypeTransferCreated
+	EventTypeTransferUpdated                = moov.EventTypeTransferUpdated
+	EventTypeUnderwritingUpdated            = moov.EventTypeUnderwritingUpdated
+	EventTypeWalletCreated                  = moov.EventTypeWalletCreated
+	EventTypeWalletUpdated                  = moov.EventTypeWalletUpdated
+	EventTypeWalletTransactionUpdated       = moov.EventTypeWalletTransactionUpdated
 )
 
 type AccountCreated struct {
@@ -175,6 +186,57 @@ type InvoiceUpdated struct {
 	Status    moov.InvoiceStatus `json:"status"`
 }
 
+type IssuedCardCreated struct {
+	// ID of the account the card was issued for
+	AccountID string `json:"accountID"`
+	// ID of the issued card
+	IssuedCardID string `json:"issuedCardID"`
+	// State of the issued card
+	State moov.IssuedCardState `json:"state"`
+}
+
+type IssuedCardUpdated struct {
+	// ID of the account the card was issued for
+	AccountID string `json:"accountID"`
+	// ID of the issued card
+	IssuedCardID string `json:"issuedCardID"`
+	// State of the issued card
+	State moov.IssuedCardState `json:"state"`
+}
+
+type IssuedCardAuthorizationCreated struct {
+	// ID of the account the card was issued for
+	AccountID string `json:"accountID"`
+	// ID of the issued card
+	IssuedCardID string `json:"issuedCardID"`
+	// ID of the authorization
+	AuthorizationID string `json:"authorizationID"`
+	// Status of the authorization
+	Status moov.IssuedCardAuthorizationStatus `json:"status"`
+}
+
+type IssuedCardAuthorizationUpdated struct {
+	// ID of the account the card was issued for
+	AccountID string `json:"accountID"`
+	// ID of the issued card
+	IssuedCardID string `json:"issuedCardID"`
+	// ID of the authorization
+	AuthorizationID string `json:"authorizationID"`
+	// Status of the authorization
+	Status moov.IssuedCardAuthorizationStatus `json:"status"`
+}
+
+type IssuedCardTransactionCreated struct {
+	// ID of the account the card was issued for
+	AccountID string `json:"accountID"`
+	// ID of the issued card
+	IssuedCardID string `json:"issuedCardID"`
+	// ID of the card transaction
+	CardTransactionID string `json:"cardTransactionID"`
+	// ID of the authorization the transaction cleared, if any
+	AuthorizationID *string `json:"authorizationID,omitempty"`
+}
+
 type NetworkIDUpdated struct {
 	// ID of account.
 	AccountID     string     `json:"accountID"`
@@ -244,6 +306,51 @@ type RepresentativeUpdated struct {
 	AccountID string `json:"accountID"`
 }
 
+type ResolutionLinkCreated struct {
+	// ID of the account
+	AccountID string `json:"accountID"`
+	// Code of the resolution link
+	ResolutionLinkCode string `json:"code"`
+	// Status of the resolution link
+	Status moov.ResolutionLinkStatus `json:"status"`
+}
+
+type ResolutionLinkUpdated struct {
+	// ID of the account
+	AccountID string `json:"accountID"`
+	// Code of the resolution link
+	ResolutionLinkCode string `json:"code"`
+	// Status of the resolution link
+	Status moov.ResolutionLinkStatus `json:"status"`
+}
+
+type ScheduleCreated struct {
+	// ID of the account that owns the schedule
+	AccountID string `json:"accountID"`
+	// ID of the schedule
+	ScheduleID string `json:"scheduleID"`
+}
+
+type ScheduleUpdated struct {
+	// ID of the account that owns the schedule
+	AccountID string `json:"accountID"`
+	// ID of the schedule
+	ScheduleID string `json:"scheduleID"`
+}
+
+type ScheduleOccurrenceUpdated struct {
+	// ID of the account that owns the schedule
+	AccountID string `json:"accountID"`
+	// ID of the schedule
+	ScheduleID string `json:"scheduleID"`
+	// ID of the occurrence
+	OccurrenceID string `json:"occurrenceID"`
+	// Status of the occurrence's run, if it ran
+	Status *string `json:"status,omitempty"`
+	// ID of the transfer the occurrence ran, if any
+	RunTransferID *string `json:"ranTransferID,omitempty"`
+}
+
 type SweepCreated struct {
 	// ID of the sweep
 	SweepID string `json:"sweepID"`
@@ -349,6 +456,13 @@ type PaymentMethodPartial struct {
 	PaymentMethodID string `json:"paymentMethodID"`
 }
 
+type UnderwritingUpdated struct {
+	// ID of the account
+	AccountID string `json:"accountID"`
+	// Status of the account's underwriting
+	Status moov.UnderwritingStatus `json:"status"`
+}
+
 type WalletCreated struct {
 	// ID of the account
 	AccountID string `json:"accountID"`
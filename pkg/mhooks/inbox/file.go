@@ -0,0 +1,100 @@
+package inbox
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileStore keeps the inbox in a directory with a JSON file per event, and an index of it in
+// memory. Files are replaced atomically, so a crash never leaves a partially written event
+// behind. The directory must only be used by one FileStore at a time.
+type FileStore struct {
+	MemoryStore
+	dir string
+}
+
+// NewFileStore opens the inbox stored in dir, creating the directory if it doesn't exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating inbox directory: %w", err)
+	}
+
+	s := &FileStore{MemoryStore: MemoryStore{records: map[string]*Record{}}, dir: dir}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading inbox directory: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			// Leftover from a write interrupted by a crash.
+			os.Remove(filepath.Join(dir, name))
+			continue
+		}
+		if filepath.Ext(name) != ".json" {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("reading inbox event: %w", err)
+		}
+		var rec Record
+		if err := json.Unmarshal(b, &rec); err != nil {
+			return nil, fmt.Errorf("decoding inbox event %s: %w", name, err)
+		}
+		s.records[rec.Event.EventID] = &rec
+	}
+
+	s.save = s.write
+	s.remove = func(eventID string) error {
+		err := os.Remove(s.path(eventID))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("removing inbox event: %w", err)
+		}
+		return nil
+	}
+	return s, nil
+}
+
+// write replaces the file of the record through a temporary file.
+func (s *FileStore) write(rec *Record) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encoding inbox event: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".event-*")
+	if err != nil {
+		return fmt.Errorf("writing inbox event: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(b)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path(rec.Event.EventID))
+	}
+	if err != nil {
+		return fmt.Errorf("writing inbox event: %w", err)
+	}
+	return nil
+}
+
+// path names files after a hash of the event ID, so any ID makes a valid file name.
+func (s *FileStore) path(eventID string) string {
+	sum := sha256.Sum256([]byte(eventID))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:16])+".json")
+}
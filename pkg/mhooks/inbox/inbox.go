@@ -0,0 +1,335 @@
+// Package inbox stores verified Moov webhook events durably before they're acknowledged and
+// handles them asynchronously with a pool of workers, retrying failed events until they succeed
+// or run out of attempts.
+//
+// Moov delivers webhooks at least once and in no particular order. The inbox keeps one record
+// per Event.EventID, so redelivered events are acknowledged without being handled again, and
+// hands the oldest due events to the workers first.
+//
+// Each event is handled exactly once by inboxes made with NewTx on a SQLStore: the handler runs
+// in the transaction marking the event done, so its changes are committed once along with the
+// outcome, and rolled back if the process stops first or the event was claimed again meanwhile.
+//
+//	in := inbox.NewTx(store, func(ctx context.Context, tx *sql.Tx, event *mhooks.Event) error {
+//		// Make the changes for the event through tx.
+//	})
+//
+// Inboxes made with New handle events with a Handler outside of the store. Its worker renews
+// the lease of the event until the handler returns, so no other worker handles it meanwhile. A
+// handler that succeeded is only called again for the event if its process stopped before the
+// outcome was saved.
+//
+//	in := inbox.New(store, handle)
+//	router := mhooks.NewRouter(secret)
+//	router.Fallback(in.Accept)
+//	go in.Run(ctx)
+package inbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+const (
+	DefaultWorkers      = 4
+	DefaultMaxAttempts  = 10
+	DefaultLease        = 5 * time.Minute
+	DefaultPollInterval = time.Second
+)
+
+// DefaultBackoff waits between attempts of an event from 10 seconds up to an hour.
+var DefaultBackoff = moov.ExponentialBackoff(10*time.Second, time.Hour)
+
+// ErrHandlerPanicked is returned for handlers that panicked, wrapped with the panic value.
+var ErrHandlerPanicked = errors.New("inbox handler panicked")
+
+// Handler handles an event taken from the inbox. Events whose handler returns an error are
+// retried later, and dead-lettered once they ran out of attempts.
+type Handler func(ctx context.Context, event *mhooks.Event) error
+
+// Inbox accepts events into a Store and handles them with a pool of workers.
+type Inbox struct {
+	store  Store
+	handle Handler
+
+	// complete is set by NewTx to handle a claimed record and mark it done in one transaction.
+	complete func(ctx context.Context, rec Record) error
+
+	workers      int
+	maxAttempts  int
+	backoff      moov.Backoff
+	lease        time.Duration
+	pollInterval time.Duration
+	onError      func(rec Record, err error)
+	now          func() time.Time
+
+	wake chan struct{}
+}
+
+type Option func(in *Inbox)
+
+// WithWorkers sets how many events are handled at the same time. Defaults to DefaultWorkers.
+func WithWorkers(n int) Option {
+	return func(in *Inbox) {
+		in.workers = n
+	}
+}
+
+// WithMaxAttempts sets how often an event is attempted before it's dead-lettered. Defaults to
+// DefaultMaxAttempts.
+func WithMaxAttempts(n int) Option {
+	return func(in *Inbox) {
+		in.maxAttempts = n
+	}
+}
+
+// WithBackoff sets how long to wait before retrying an event after the given failed attempt.
+// Defaults to DefaultBackoff.
+func WithBackoff(backoff moov.Backoff) Option {
+	return func(in *Inbox) {
+		in.backoff = backoff
+	}
+}
+
+// WithLease sets how long a handler may run, its context is canceled once the lease runs out.
+// Inboxes made with New renew the lease until the handler returns, so the event is only claimed
+// again by another worker once the process handling it stopped. With NewTx the transaction of
+// the handler is rolled back when the lease runs out instead. Defaults to DefaultLease.
+func WithLease(d time.Duration) Option {
+	return func(in *Inbox) {
+		in.lease = d
+	}
+}
+
+// WithPollInterval sets how often idle workers look for events that became due, e.g. retries or
+// events accepted by other processes sharing the store. Defaults to DefaultPollInterval.
+func WithPollInterval(d time.Duration) Option {
+	return func(in *Inbox) {
+		in.pollInterval = d
+	}
+}
+
+// WithErrorHandler calls fn for every failed attempt of an event and for errors of the store,
+// with a zero Record in that case, e.g. to log them. rec.State tells if the event will be
+// retried or was dead-lettered.
+func WithErrorHandler(fn func(rec Record, err error)) Option {
+	return func(in *Inbox) {
+		in.onError = fn
+	}
+}
+
+// New returns an inbox storing events in the store and handling them with handle once Run is called.
+func New(store Store, handle Handler, opts ...Option) *Inbox {
+	in := &Inbox{
+		store:        store,
+		handle:       handle,
+		workers:      DefaultWorkers,
+		maxAttempts:  DefaultMaxAttempts,
+		backoff:      DefaultBackoff,
+		lease:        DefaultLease,
+		pollInterval: DefaultPollInterval,
+		now:          time.Now,
+		wake:         make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(in)
+	}
+	return in
+}
+
+// Accept stores the event to be handled by the workers, and returns nil once it's stored or if it
+// was accepted before. Its signature matches mhooks.Router.Fallback and Handle, so the router
+// acknowledges events as soon as they're stored.
+func (in *Inbox) Accept(ctx context.Context, event *mhooks.Event) error {
+	now := in.now()
+	added, err := in.store.Add(ctx, Record{
+		Event:       *event,
+		State:       StatePending,
+		NextAttempt: now,
+		ReceivedOn:  now,
+		UpdatedOn:   now,
+	})
+	if err != nil {
+		return fmt.Errorf("accepting %s event %s: %w", event.EventType, event.EventID, err)
+	}
+
+	if added {
+		select {
+		case in.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Run handles events with the pool of workers until ctx is canceled, then waits for the
+// handlers still running to return.
+func (in *Inbox) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for range max(in.workers, 1) {
+		wg.Go(func() {
+			in.work(ctx)
+		})
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// work handles events one at a time, waiting for new ones once none are due.
+func (in *Inbox) work(ctx context.Context) {
+	ticker := time.NewTicker(in.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		claimed, err := in.store.Claim(ctx, in.now(), in.lease, 1)
+		if err != nil && ctx.Err() == nil {
+			in.fail(Record{}, fmt.Errorf("claiming events: %w", err))
+		}
+		for _, rec := range claimed {
+			in.process(ctx, rec)
+		}
+		if len(claimed) > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-in.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// process runs the handler and saves its outcome. Shutting down doesn't cancel handlers that
+// are running, only their lease does.
+func (in *Inbox) process(ctx context.Context, rec Record) {
+	ctx = context.WithoutCancel(ctx)
+	handleCtx, cancel := context.WithDeadline(ctx, rec.LeasedUntil)
+	defer cancel()
+
+	var err error
+	if in.complete != nil {
+		// The transaction ends with the lease, so the lease isn't renewed.
+		err = in.complete(handleCtx, rec)
+	} else {
+		stopRenewing := in.renew(ctx, rec)
+		err = in.run(func() error { return in.handle(handleCtx, &rec.Event) })
+		stopRenewing()
+	}
+
+	if errors.Is(err, ErrClaimLost) {
+		in.fail(rec, fmt.Errorf("handling %s event %s: %w", rec.Event.EventType, rec.Event.EventID, err))
+		return
+	}
+
+	now := in.now()
+	rec.UpdatedOn = now
+	switch {
+	case err == nil:
+		rec.State = StateDone
+		rec.LastError = ""
+		if in.complete != nil {
+			// Saved along with the changes of the handler.
+			return
+		}
+	case rec.Attempts >= in.maxAttempts:
+		rec.State = StateDead
+		rec.LastError = err.Error()
+	default:
+		rec.State = StatePending
+		rec.NextAttempt = now.Add(in.backoff(rec.Attempts))
+		rec.LastError = err.Error()
+	}
+
+	if releaseErr := in.store.Release(ctx, rec); releaseErr != nil {
+		in.fail(rec, fmt.Errorf("saving %s event %s: %w", rec.Event.EventType, rec.Event.EventID, releaseErr))
+	}
+	if err != nil {
+		in.fail(rec, fmt.Errorf("handling %s event %s: %w", rec.Event.EventType, rec.Event.EventID, err))
+	}
+}
+
+// renew keeps the record claimed while its handler runs, renewing its lease every third of it,
+// until the returned function is called.
+func (in *Inbox) renew(ctx context.Context, rec Record) (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		ticker := time.NewTicker(max(in.lease/3, time.Millisecond))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			err := in.store.Renew(ctx, rec, in.now().Add(in.lease))
+			if err != nil {
+				in.fail(rec, fmt.Errorf("renewing lease of %s event %s: %w", rec.Event.EventType, rec.Event.EventID, err))
+			}
+			if errors.Is(err, ErrClaimLost) {
+				return
+			}
+		}
+	})
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// run calls the handler, recovering from panics.
+func (in *Inbox) run(handle func() error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%w: %v\n%s", ErrHandlerPanicked, p, debug.Stack())
+		}
+	}()
+	return handle()
+}
+
+func (in *Inbox) fail(rec Record, err error) {
+	if in.onError != nil {
+		in.onError(rec, err)
+	}
+}
+
+// Get returns the record of the event, or ErrNotFound.
+func (in *Inbox) Get(ctx context.Context, eventID string) (*Record, error) {
+	return in.store.Get(ctx, eventID)
+}
+
+// DeadLetters returns the events that ran out of attempts, oldest first.
+func (in *Inbox) DeadLetters(ctx context.Context) ([]Record, error) {
+	return in.store.List(ctx, StateDead)
+}
+
+// Retry makes a dead-lettered event due again with a fresh set of attempts.
+func (in *Inbox) Retry(ctx context.Context, eventID string) error {
+	if err := in.store.Requeue(ctx, eventID, in.now()); err != nil {
+		return err
+	}
+
+	select {
+	case in.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Prune forgets the events handled more than d ago. Redeliveries of pruned events are handled
+// again, so d should exceed the time Moov keeps retrying webhooks.
+func (in *Inbox) Prune(ctx context.Context, d time.Duration) (int, error) {
+	return in.store.Prune(ctx, in.now().Add(-d))
+}
@@ -0,0 +1,177 @@
+package inbox_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+	"github.com/moovfinancial/moov-go/pkg/mhooks/inbox"
+	"github.com/moovfinancial/moov-go/pkg/mhooks/inbox/inboxtest"
+)
+
+func TestInbox(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls = map[string]int{}
+		errs  []error
+	)
+	handle := func(_ context.Context, event *mhooks.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls[event.EventID]++
+		switch event.EventID {
+		case "flaky":
+			if calls[event.EventID] < 3 {
+				return errors.New("database is down")
+			}
+		case "broken":
+			return errors.New("can't handle this event")
+		case "panics":
+			panic("boom")
+		}
+		return nil
+	}
+	called := func(eventID string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls[eventID]
+	}
+
+	store := inbox.NewMemoryStore()
+	in := inbox.New(store, handle,
+		inbox.WithWorkers(3),
+		inbox.WithMaxAttempts(3),
+		inbox.WithBackoff(func(int) time.Duration { return time.Millisecond }),
+		inbox.WithPollInterval(time.Millisecond),
+		inbox.WithErrorHandler(func(_ inbox.Record, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	go func() {
+		stopped <- in.Run(ctx)
+	}()
+
+	now := time.Now()
+	for _, eventID := range []string{"ok", "flaky", "broken", "panics"} {
+		event := inboxtest.Event(t, eventID, now)
+		require.NoError(t, in.Accept(ctx, &event))
+	}
+
+	done := func(eventID string, state inbox.State) func() bool {
+		return func() bool {
+			rec, err := in.Get(ctx, eventID)
+			require.NoError(t, err)
+			return rec.State == state
+		}
+	}
+	require.Eventually(t, done("ok", inbox.StateDone), time.Second, time.Millisecond)
+	require.Eventually(t, done("flaky", inbox.StateDone), time.Second, time.Millisecond)
+	require.Eventually(t, done("broken", inbox.StateDead), time.Second, time.Millisecond)
+	require.Eventually(t, done("panics", inbox.StateDead), time.Second, time.Millisecond)
+
+	require.Equal(t, 1, called("ok"))
+	require.Equal(t, 3, called("flaky"))
+	require.Equal(t, 3, called("broken"))
+
+	mu.Lock()
+	require.True(t, slices.ContainsFunc(errs, func(err error) bool { return errors.Is(err, inbox.ErrHandlerPanicked) }))
+	mu.Unlock()
+
+	// Redelivered events aren't handled again.
+	event := inboxtest.Event(t, "ok", now)
+	require.NoError(t, in.Accept(ctx, &event))
+
+	deadLetters, err := in.DeadLetters(ctx)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 2)
+	require.Equal(t, "can't handle this event", deadLetters[0].LastError)
+
+	// Dead-lettered events get a fresh set of attempts when retried.
+	require.NoError(t, in.Retry(ctx, "broken"))
+	require.Eventually(t, func() bool { return called("broken") == 6 }, time.Second, time.Millisecond)
+	require.Eventually(t, done("broken", inbox.StateDead), time.Second, time.Millisecond)
+	require.ErrorIs(t, in.Retry(ctx, "ok"), inbox.ErrNotFound)
+
+	cancel()
+	require.ErrorIs(t, <-stopped, context.Canceled)
+	require.Equal(t, 1, called("ok"))
+
+	pruned, err := in.Prune(context.Background(), -time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+}
+
+func TestInbox_Shutdown(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	in := inbox.New(inbox.NewMemoryStore(), func(ctx context.Context, _ *mhooks.Event) error {
+		close(started)
+		<-release
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	go func() {
+		stopped <- in.Run(ctx)
+	}()
+
+	event := inboxtest.Event(t, "event", time.Now())
+	require.NoError(t, in.Accept(ctx, &event))
+	<-started
+
+	// Stopping waits for the running handler, which isn't canceled.
+	cancel()
+	select {
+	case <-stopped:
+		require.FailNow(t, "stopped before the handler returned")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	require.ErrorIs(t, <-stopped, context.Canceled)
+
+	rec, err := in.Get(context.Background(), "event")
+	require.NoError(t, err)
+	require.Equal(t, inbox.StateDone, rec.State)
+}
+
+func TestInbox_LeaseRenewal(t *testing.T) {
+	var calls atomic.Int32
+	in := inbox.New(inbox.NewMemoryStore(), func(context.Context, *mhooks.Event) error {
+		calls.Add(1)
+		// Runs past its lease, ignoring its context.
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	}, inbox.WithWorkers(2), inbox.WithLease(20*time.Millisecond), inbox.WithPollInterval(time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	go func() {
+		stopped <- in.Run(ctx)
+	}()
+
+	event := inboxtest.Event(t, "event", time.Now())
+	require.NoError(t, in.Accept(ctx, &event))
+	require.Eventually(t, func() bool {
+		rec, err := in.Get(ctx, "event")
+		require.NoError(t, err)
+		return rec.State == inbox.StateDone
+	}, time.Second, time.Millisecond)
+
+	// The other worker never claimed the event while the handler ran.
+	require.Equal(t, int32(1), calls.Load())
+	cancel()
+	<-stopped
+}
@@ -0,0 +1,172 @@
+// Package inboxtest checks implementations of inbox.Store, e.g. a store for another database:
+//
+//	func TestStore(t *testing.T) {
+//		inboxtest.TestStore(t, newStore(t))
+//	}
+package inboxtest
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+	"github.com/moovfinancial/moov-go/pkg/mhooks/inbox"
+)
+
+// Event returns a transfer.updated event for the transfer eventID, with its payload hydrated
+// like events parsed by mhooks.ParseEvent.
+func Event(t *testing.T, eventID string, createdOn time.Time) mhooks.Event {
+	t.Helper()
+
+	data, err := json.Marshal(mhooks.TransferUpdated{AccountID: "account", TransferID: eventID})
+	require.NoError(t, err)
+
+	b, err := json.Marshal(mhooks.Event{EventID: eventID, EventType: mhooks.EventTypeTransferUpdated, CreatedOn: createdOn, Data: data})
+	require.NoError(t, err)
+
+	var event mhooks.Event
+	require.NoError(t, json.Unmarshal(b, &event))
+	return event
+}
+
+// TestStore checks that an empty store adds, claims, renews, releases, requeues and prunes
+// records as documented by inbox.Store.
+func TestStore(t *testing.T, store inbox.Store) {
+
+	ctx := context.Background()
+	now := time.Now()
+
+	add := func(eventID string, createdOn time.Time) bool {
+		added, err := store.Add(ctx, inbox.Record{
+			Event:       Event(t, eventID, createdOn),
+			State:       inbox.StatePending,
+			NextAttempt: now,
+			ReceivedOn:  now,
+			UpdatedOn:   now,
+		})
+		require.NoError(t, err)
+		return added
+	}
+	require.True(t, add("second", now.Add(-time.Minute)))
+	require.True(t, add("first", now.Add(-time.Hour)))
+	require.False(t, add("first", now.Add(-time.Hour)))
+
+	// Events are claimed oldest first, and only once.
+	claimed, err := store.Claim(ctx, now, time.Minute, 1)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, "first", claimed[0].Event.EventID)
+	require.Equal(t, inbox.StateProcessing, claimed[0].State)
+	require.Equal(t, 1, claimed[0].Attempts)
+
+	data, err := claimed[0].Event.TransferUpdated()
+	require.NoError(t, err)
+	require.Equal(t, "first", data.TransferID)
+
+	second, err := store.Claim(ctx, now, 2*time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	require.Equal(t, "second", second[0].Event.EventID)
+
+	// The first event is retried later.
+	first := claimed[0]
+	first.State = inbox.StatePending
+	first.NextAttempt = now.Add(time.Minute)
+	first.LastError = "database is down"
+	require.NoError(t, store.Release(ctx, first))
+
+	claimed, err = store.Claim(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	claimed, err = store.Claim(ctx, now.Add(time.Minute), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, 2, claimed[0].Attempts)
+	require.Equal(t, "database is down", claimed[0].LastError)
+
+	// The lease of the second event ran out, so it's claimed again and its first worker loses it.
+	claimed, err = store.Claim(ctx, now.Add(2*time.Minute), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+
+	second[0].State = inbox.StateDone
+	require.ErrorIs(t, store.Release(ctx, second[0]), inbox.ErrClaimLost)
+
+	done, dead := claimed[0], claimed[1]
+	done.State = inbox.StateDone
+	done.UpdatedOn = now
+	require.NoError(t, store.Release(ctx, done))
+	dead.State = inbox.StateDead
+	require.NoError(t, store.Release(ctx, dead))
+
+	list, err := store.List(ctx, inbox.StateDead)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, dead.Event.EventID, list[0].Event.EventID)
+
+	require.NoError(t, store.Requeue(ctx, dead.Event.EventID, now))
+	require.ErrorIs(t, store.Requeue(ctx, done.Event.EventID, now), inbox.ErrNotFound)
+
+	rec, err := store.Get(ctx, dead.Event.EventID)
+	require.NoError(t, err)
+	require.Equal(t, inbox.StatePending, rec.State)
+	require.Zero(t, rec.Attempts)
+
+	pruned, err := store.Prune(ctx, now.Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, 1, pruned)
+
+	_, err = store.Get(ctx, done.Event.EventID)
+	require.ErrorIs(t, err, inbox.ErrNotFound)
+
+	// Workers claiming at the same time never claim an event twice.
+	for _, eventID := range []string{"a", "b", "c", "d"} {
+		require.True(t, add(eventID, now))
+	}
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		ids []string
+	)
+	for range 2 {
+		wg.Go(func() {
+			for {
+				recs, err := store.Claim(ctx, now, time.Minute, 1)
+				require.NoError(t, err)
+				if len(recs) == 0 {
+					return
+				}
+
+				mu.Lock()
+				ids = append(ids, recs[0].Event.EventID)
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	require.ElementsMatch(t, []string{dead.Event.EventID, "a", "b", "c", "d"}, ids)
+
+	// A renewed lease keeps the event claimed, and only the attempt holding it can renew it.
+	require.True(t, add("renewed", now))
+	claimed, err = store.Claim(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.NoError(t, store.Renew(ctx, claimed[0], now.Add(time.Hour)))
+
+	expired, err := store.Claim(ctx, now.Add(time.Minute), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 5)
+	for _, rec := range expired {
+		require.NotEqual(t, "renewed", rec.Event.EventID)
+	}
+
+	stale := claimed[0]
+	stale.Attempts--
+	require.ErrorIs(t, store.Renew(ctx, stale, now.Add(time.Hour)), inbox.ErrClaimLost)
+}
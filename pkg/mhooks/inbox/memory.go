@@ -0,0 +1,187 @@
+package inbox
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the inbox in memory. Events are lost when the process exits, so it's meant
+// for tests and for services that can afford that.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]*Record
+
+	// save persists a record before it's changed in memory, and remove before it's deleted. They
+	// are set by FileStore.
+	save   func(rec *Record) error
+	remove func(eventID string) error
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: map[string]*Record{}}
+}
+
+func (s *MemoryStore) Add(_ context.Context, rec Record) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.records[rec.Event.EventID]; ok {
+		return false, nil
+	}
+	if err := s.persist(&rec); err != nil {
+		return false, err
+	}
+	s.records[rec.Event.EventID] = &rec
+	return true, nil
+}
+
+func (s *MemoryStore) Claim(_ context.Context, now time.Time, lease time.Duration, limit int) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*Record
+	for _, rec := range s.records {
+		if rec.claimable(now) {
+			due = append(due, rec)
+		}
+	}
+	slices.SortFunc(due, older)
+
+	claimed := make([]Record, 0, min(limit, len(due)))
+	for _, rec := range due[:min(limit, len(due))] {
+		next := *rec
+		next.claim(now, lease)
+		if err := s.persist(&next); err != nil {
+			return claimed, err
+		}
+		*rec = next
+		claimed = append(claimed, next)
+	}
+	return claimed, nil
+}
+
+func (s *MemoryStore) Renew(_ context.Context, rec Record, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.records[rec.Event.EventID]
+	if !ok {
+		return ErrNotFound
+	}
+	if stored.State != StateProcessing || stored.Attempts != rec.Attempts {
+		return ErrClaimLost
+	}
+
+	next := *stored
+	next.LeasedUntil = until
+	if err := s.persist(&next); err != nil {
+		return err
+	}
+	*stored = next
+	return nil
+}
+
+func (s *MemoryStore) Release(_ context.Context, rec Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.records[rec.Event.EventID]
+	if !ok {
+		return ErrNotFound
+	}
+	if stored.State != StateProcessing || stored.Attempts != rec.Attempts {
+		return ErrClaimLost
+	}
+
+	next := *stored
+	next.State = rec.State
+	next.NextAttempt = rec.NextAttempt
+	next.LastError = rec.LastError
+	next.UpdatedOn = rec.UpdatedOn
+	if err := s.persist(&next); err != nil {
+		return err
+	}
+	*stored = next
+	return nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, eventID string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.records[eventID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	found := *rec
+	return &found, nil
+}
+
+func (s *MemoryStore) List(_ context.Context, state State) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matching []*Record
+	for _, rec := range s.records {
+		if rec.State == state {
+			matching = append(matching, rec)
+		}
+	}
+	slices.SortFunc(matching, older)
+
+	list := make([]Record, len(matching))
+	for i, rec := range matching {
+		list[i] = *rec
+	}
+	return list, nil
+}
+
+func (s *MemoryStore) Requeue(_ context.Context, eventID string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.records[eventID]
+	if !ok || stored.State != StateDead {
+		return ErrNotFound
+	}
+
+	next := *stored
+	next.State = StatePending
+	next.Attempts = 0
+	next.NextAttempt = now
+	next.UpdatedOn = now
+	if err := s.persist(&next); err != nil {
+		return err
+	}
+	*stored = next
+	return nil
+}
+
+func (s *MemoryStore) Prune(_ context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pruned := 0
+	for id, rec := range s.records {
+		if rec.State != StateDone || !rec.UpdatedOn.Before(before) {
+			continue
+		}
+		if s.remove != nil {
+			if err := s.remove(id); err != nil {
+				return pruned, err
+			}
+		}
+		delete(s.records, id)
+		pruned++
+	}
+	return pruned, nil
+}
+
+func (s *MemoryStore) persist(rec *Record) error {
+	if s.save == nil {
+		return nil
+	}
+	return s.save(rec)
+}
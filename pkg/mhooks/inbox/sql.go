@@ -0,0 +1,328 @@
+package inbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+)
+
+// SQLStore keeps the inbox in a table of a SQLite database, opened by the caller with the driver
+// of their choice, e.g.
+//
+//	db, err := sql.Open("sqlite", "inbox.db")
+//	store, err := inbox.NewSQLStore(ctx, db, "moov_inbox")
+//
+// Several processes may share the database. Records are only claimed when they're still in the
+// state they were read in, so two processes never claim a record for the same attempt. Use
+// NewTx to handle events in the transaction marking them done.
+type SQLStore struct {
+	db    *sql.DB
+	table string
+}
+
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+const sqlColumns = `event_id, event_type, created_on, data, state, attempts, next_attempt, leased_until, last_error, received_on, updated_on`
+
+// NewSQLStore returns a store keeping the inbox in the table, creating it if it doesn't exist.
+// Times are stored as Unix nanoseconds so they compare the same with any driver.
+func NewSQLStore(ctx context.Context, db *sql.DB, table string) (*SQLStore, error) {
+	if !tableName.MatchString(table) {
+		return nil, fmt.Errorf("invalid inbox table name: %q", table)
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+	event_id TEXT PRIMARY KEY,
+	event_type TEXT NOT NULL,
+	created_on INTEGER NOT NULL,
+	data BLOB NOT NULL,
+	state TEXT NOT NULL,
+	attempts INTEGER NOT NULL,
+	next_attempt INTEGER NOT NULL,
+	leased_until INTEGER NOT NULL,
+	last_error TEXT NOT NULL,
+	received_on INTEGER NOT NULL,
+	updated_on INTEGER NOT NULL
+)`, table))
+	if err != nil {
+		return nil, fmt.Errorf("creating inbox table: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_state ON %[1]s (state, created_on)`, table))
+	if err != nil {
+		return nil, fmt.Errorf("creating inbox index: %w", err)
+	}
+
+	return &SQLStore{db: db, table: table}, nil
+}
+
+// TxHandler handles an event inside the transaction of a SQLStore that marks it done, see NewTx.
+type TxHandler func(ctx context.Context, tx *sql.Tx, event *mhooks.Event) error
+
+// NewTx returns an inbox handling events with handle inside the transaction marking them done in
+// the store, once Run is called. Changes the handler makes through tx, in the database of the
+// store, are committed along with the outcome of the event: exactly once per EventID, even when
+// the process stops at any point or the event was claimed again meanwhile. Failed attempts are
+// rolled back before they're retried.
+func NewTx(store *SQLStore, handle TxHandler, opts ...Option) *Inbox {
+	in := New(store, nil, opts...)
+	in.complete = func(ctx context.Context, rec Record) error {
+		return store.complete(ctx, rec, in.now, func(tx *sql.Tx) error {
+			return in.run(func() error { return handle(ctx, tx, &rec.Event) })
+		})
+	}
+	return in
+}
+
+// complete runs handle in a transaction marking the claimed record done, which is only committed
+// if handle succeeded and the record is still claimed for the same attempt.
+func (s *SQLStore) complete(ctx context.Context, rec Record, now func() time.Time, handle func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning inbox transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := handle(tx); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET state = ?, last_error = '', updated_on = ?
+WHERE event_id = ? AND state = ? AND attempts = ?`, s.table),
+		string(StateDone), nanos(now()), rec.Event.EventID, string(StateProcessing), rec.Attempts)
+	if err != nil {
+		return fmt.Errorf("completing inbox event: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("completing inbox event: %w", err)
+	}
+	if n == 0 {
+		return ErrClaimLost
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("completing inbox event: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) Add(ctx context.Context, rec Record) (bool, error) {
+	data := []byte(rec.Event.Data)
+	if data == nil {
+		data = []byte("null")
+	}
+
+	res, err := s.db.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (%s) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (event_id) DO NOTHING`, s.table, sqlColumns),
+		rec.Event.EventID, string(rec.Event.EventType), nanos(rec.Event.CreatedOn), data, string(rec.State),
+		rec.Attempts, nanos(rec.NextAttempt), nanos(rec.LeasedUntil), rec.LastError, nanos(rec.ReceivedOn), nanos(rec.UpdatedOn))
+	if err != nil {
+		return false, fmt.Errorf("adding inbox event: %w", err)
+	}
+
+	added, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("adding inbox event: %w", err)
+	}
+	return added == 1, nil
+}
+
+func (s *SQLStore) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Record, error) {
+	due, err := s.query(ctx, fmt.Sprintf(`SELECT %s FROM %s
+WHERE (state = ? AND next_attempt <= ?) OR (state = ? AND leased_until <= ?)
+ORDER BY created_on, received_on LIMIT ?`, sqlColumns, s.table),
+		string(StatePending), nanos(now), string(StateProcessing), nanos(now), limit)
+	if err != nil {
+		return nil, fmt.Errorf("claiming inbox events: %w", err)
+	}
+
+	claimed := make([]Record, 0, len(due))
+	for _, rec := range due {
+		state, attempts := rec.State, rec.Attempts
+		rec.claim(now, lease)
+
+		res, err := s.db.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET state = ?, attempts = ?, leased_until = ?, updated_on = ?
+WHERE event_id = ? AND state = ? AND attempts = ?`, s.table),
+			string(rec.State), rec.Attempts, nanos(rec.LeasedUntil), nanos(rec.UpdatedOn),
+			rec.Event.EventID, string(state), attempts)
+		if err != nil {
+			return claimed, fmt.Errorf("claiming inbox event: %w", err)
+		}
+
+		// Another process claimed the record first.
+		if n, err := res.RowsAffected(); err != nil {
+			return claimed, fmt.Errorf("claiming inbox event: %w", err)
+		} else if n == 0 {
+			continue
+		}
+		claimed = append(claimed, rec)
+	}
+	return claimed, nil
+}
+
+func (s *SQLStore) Renew(ctx context.Context, rec Record, until time.Time) error {
+	res, err := s.db.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET leased_until = ?
+WHERE event_id = ? AND state = ? AND attempts = ?`, s.table),
+		nanos(until), rec.Event.EventID, string(StateProcessing), rec.Attempts)
+	if err != nil {
+		return fmt.Errorf("renewing inbox event lease: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("renewing inbox event lease: %w", err)
+	}
+	if n == 0 {
+		return ErrClaimLost
+	}
+	return nil
+}
+
+func (s *SQLStore) Release(ctx context.Context, rec Record) error {
+	res, err := s.db.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET state = ?, next_attempt = ?, last_error = ?, updated_on = ?
+WHERE event_id = ? AND state = ? AND attempts = ?`, s.table),
+		string(rec.State), nanos(rec.NextAttempt), rec.LastError, nanos(rec.UpdatedOn),
+		rec.Event.EventID, string(StateProcessing), rec.Attempts)
+	if err != nil {
+		return fmt.Errorf("releasing inbox event: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("releasing inbox event: %w", err)
+	}
+	if n == 0 {
+		return ErrClaimLost
+	}
+	return nil
+}
+
+func (s *SQLStore) Get(ctx context.Context, eventID string) (*Record, error) {
+	found, err := s.query(ctx, fmt.Sprintf(`SELECT %s FROM %s WHERE event_id = ?`, sqlColumns, s.table), eventID)
+	if err != nil {
+		return nil, fmt.Errorf("getting inbox event: %w", err)
+	}
+	if len(found) == 0 {
+		return nil, ErrNotFound
+	}
+	return &found[0], nil
+}
+
+func (s *SQLStore) List(ctx context.Context, state State) ([]Record, error) {
+	list, err := s.query(ctx, fmt.Sprintf(`SELECT %s FROM %s WHERE state = ? ORDER BY created_on, received_on`, sqlColumns, s.table), string(state))
+	if err != nil {
+		return nil, fmt.Errorf("listing inbox events: %w", err)
+	}
+	return list, nil
+}
+
+func (s *SQLStore) Requeue(ctx context.Context, eventID string, now time.Time) error {
+	res, err := s.db.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET state = ?, attempts = 0, next_attempt = ?, updated_on = ?
+WHERE event_id = ? AND state = ?`, s.table),
+		string(StatePending), nanos(now), nanos(now), eventID, string(StateDead))
+	if err != nil {
+		return fmt.Errorf("requeueing inbox event: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("requeueing inbox event: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLStore) Prune(ctx context.Context, before time.Time) (int, error) {
+	res, err := s.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE state = ? AND updated_on < ?`, s.table),
+		string(StateDone), nanos(before))
+	if err != nil {
+		return 0, fmt.Errorf("pruning inbox events: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("pruning inbox events: %w", err)
+	}
+	return int(n), nil
+}
+
+func (s *SQLStore) query(ctx context.Context, query string, args ...any) ([]Record, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var (
+			eventID, eventType, state, lastError                       string
+			data                                                       []byte
+			attempts                                                   int
+			createdOn, nextAttempt, leasedUntil, receivedOn, updatedOn int64
+		)
+		err := rows.Scan(&eventID, &eventType, &createdOn, &data, &state, &attempts, &nextAttempt, &leasedUntil, &lastError, &receivedOn, &updatedOn)
+		if err != nil {
+			return nil, err
+		}
+
+		event, err := decodeEvent(mhooks.Event{
+			EventID:   eventID,
+			EventType: mhooks.EventType(eventType),
+			CreatedOn: fromNanos(createdOn),
+			Data:      data,
+		})
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, Record{
+			Event:       *event,
+			State:       State(state),
+			Attempts:    attempts,
+			NextAttempt: fromNanos(nextAttempt),
+			LeasedUntil: fromNanos(leasedUntil),
+			LastError:   lastError,
+			ReceivedOn:  fromNanos(receivedOn),
+			UpdatedOn:   fromNanos(updatedOn),
+		})
+	}
+	return records, rows.Err()
+}
+
+// decodeEvent hydrates the payload of an event read from its columns.
+func decodeEvent(stored mhooks.Event) (*mhooks.Event, error) {
+	b, err := json.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	var event mhooks.Event
+	if err := json.Unmarshal(b, &event); err != nil {
+		return nil, fmt.Errorf("decoding inbox event %s: %w", stored.EventID, err)
+	}
+	return &event, nil
+}
+
+// nanos stores the zero time as 0, so it's due before any other time.
+func nanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromNanos(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
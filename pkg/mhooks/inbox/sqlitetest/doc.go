@@ -0,0 +1,6 @@
+// Package sqlitetest tests inbox.SQLStore and inboxes made with inbox.NewTx against SQLite with
+// github.com/mattn/go-sqlite3. It's a module of its own so moov-go doesn't depend on the driver
+// or on cgo.
+//
+//	cd pkg/mhooks/inbox/sqlitetest && go test ./...
+package sqlitetest
@@ -0,0 +1,18 @@
+module github.com/moovfinancial/moov-go/pkg/mhooks/inbox/sqlitetest
+
+go 1.26.0
+
+require (
+	github.com/mattn/go-sqlite3 v1.14.33
+	github.com/moovfinancial/moov-go v0.0.0
+	github.com/stretchr/testify v1.12.1
+)
+
+require (
+	github.com/go-jose/go-jose/v4 v4.1.4 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+	golang.org/x/time v0.15.0 // indirect
+)
+
+replace github.com/moovfinancial/moov-go => ../../../..
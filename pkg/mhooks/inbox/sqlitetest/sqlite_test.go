@@ -0,0 +1,220 @@
+package sqlitetest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+	"github.com/moovfinancial/moov-go/pkg/mhooks/inbox"
+	"github.com/moovfinancial/moov-go/pkg/mhooks/inbox/inboxtest"
+)
+
+// beforeClaim, if set, is called by connections of the sqlite3_hooked driver when they prepare
+// the UPDATE claiming an event, after the event was read.
+var (
+	beforeClaimMu sync.Mutex
+	beforeClaim   func()
+)
+
+func init() {
+	sql.Register("sqlite3_hooked", &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			conn.RegisterAuthorizer(func(op int, _, column, _ string) int {
+				if op == sqlite3.SQLITE_UPDATE && column == "leased_until" {
+					beforeClaimMu.Lock()
+					hook := beforeClaim
+					beforeClaim = nil
+					beforeClaimMu.Unlock()
+
+					if hook != nil {
+						hook()
+					}
+				}
+				return sqlite3.SQLITE_OK
+			})
+			return nil
+		},
+	})
+}
+
+func openStore(t *testing.T, driver, path string) *inbox.SQLStore {
+	t.Helper()
+
+	db, err := sql.Open(driver, path+"?_busy_timeout=10000")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	store, err := inbox.NewSQLStore(context.Background(), db, "moov_inbox")
+	require.NoError(t, err)
+	return store
+}
+
+func TestSQLStore(t *testing.T) {
+	inboxtest.TestStore(t, openStore(t, "sqlite3", filepath.Join(t.TempDir(), "inbox.db")))
+}
+
+func TestSQLStore_ClaimRace(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "inbox.db")
+
+	store := openStore(t, "sqlite3_hooked", path)
+	other := openStore(t, "sqlite3", path)
+
+	now := time.Now()
+	added, err := store.Add(ctx, inbox.Record{Event: inboxtest.Event(t, "event", now), State: inbox.StatePending, NextAttempt: now, ReceivedOn: now})
+	require.NoError(t, err)
+	require.True(t, added)
+
+	// The other process claims the event after this one read it, but before it claims it.
+	var otherClaimed []inbox.Record
+	beforeClaimMu.Lock()
+	beforeClaim = func() {
+		var claimErr error
+		otherClaimed, claimErr = other.Claim(ctx, now, time.Minute, 10)
+		require.NoError(t, claimErr)
+	}
+	beforeClaimMu.Unlock()
+
+	claimed, err := store.Claim(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+	require.Len(t, otherClaimed, 1)
+
+	rec, err := other.Get(ctx, "event")
+	require.NoError(t, err)
+	require.Equal(t, inbox.StateProcessing, rec.State)
+	require.Equal(t, 1, rec.Attempts)
+
+	// Processes claiming at the same time never claim an event twice.
+	eventIDs := make([]string, 20)
+	for i := range eventIDs {
+		eventIDs[i] = fmt.Sprintf("event-%d", i)
+		_, err := store.Add(ctx, inbox.Record{Event: inboxtest.Event(t, eventIDs[i], now), State: inbox.StatePending, NextAttempt: now, ReceivedOn: now})
+		require.NoError(t, err)
+	}
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		ids []string
+	)
+	for _, s := range []*inbox.SQLStore{store, other, openStore(t, "sqlite3", path)} {
+		wg.Go(func() {
+			for {
+				recs, err := s.Claim(ctx, now, time.Minute, 1)
+				require.NoError(t, err)
+				if len(recs) == 0 {
+					return
+				}
+
+				mu.Lock()
+				ids = append(ids, recs[0].Event.EventID)
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	require.ElementsMatch(t, eventIDs, ids)
+}
+
+func TestNewTx(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "inbox.db")
+	store := openStore(t, "sqlite3", path)
+	other := openStore(t, "sqlite3", path)
+
+	db, err := sql.Open("sqlite3", path+"?_busy_timeout=10000")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.ExecContext(ctx, `CREATE TABLE payouts (event_id TEXT NOT NULL)`)
+	require.NoError(t, err)
+
+	payouts := func(eventID string) int {
+		var n int
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM payouts WHERE event_id = ?`, eventID).Scan(&n))
+		return n
+	}
+
+	var (
+		mu    sync.Mutex
+		calls = map[string]int{}
+		errs  []error
+	)
+	in := inbox.NewTx(store, func(ctx context.Context, tx *sql.Tx, event *mhooks.Event) error {
+		mu.Lock()
+		calls[event.EventID]++
+		call := calls[event.EventID]
+		mu.Unlock()
+
+		if event.EventID == "claimed-again" {
+			// Another worker claims the event while this one handles it.
+			claimed, err := other.Claim(ctx, time.Now().Add(time.Hour), time.Hour, 10)
+			require.NoError(t, err)
+			require.Len(t, claimed, 1)
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO payouts (event_id) VALUES (?)`, event.EventID)
+		require.NoError(t, err)
+
+		if event.EventID == "flaky" && call == 1 {
+			return errors.New("payout provider is down")
+		}
+		return nil
+	},
+		inbox.WithBackoff(func(int) time.Duration { return time.Millisecond }),
+		inbox.WithPollInterval(time.Millisecond),
+		inbox.WithErrorHandler(func(_ inbox.Record, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		}),
+	)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	stopped := make(chan error)
+	go func() {
+		stopped <- in.Run(runCtx)
+	}()
+	state := func(eventID string) inbox.State {
+		rec, err := in.Get(ctx, eventID)
+		require.NoError(t, err)
+		return rec.State
+	}
+
+	for _, eventID := range []string{"ok", "flaky"} {
+		event := inboxtest.Event(t, eventID, time.Now())
+		require.NoError(t, in.Accept(ctx, &event))
+		require.Eventually(t, func() bool { return state(eventID) == inbox.StateDone }, time.Second, time.Millisecond)
+	}
+	require.Equal(t, 1, payouts("ok"))
+
+	// The changes of the failed attempt were rolled back.
+	mu.Lock()
+	require.Equal(t, 2, calls["flaky"])
+	mu.Unlock()
+	require.Equal(t, 1, payouts("flaky"))
+
+	// The changes of an attempt that lost its claim are rolled back too.
+	event := inboxtest.Event(t, "claimed-again", time.Now())
+	require.NoError(t, in.Accept(ctx, &event))
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.ContainsFunc(errs, func(err error) bool { return errors.Is(err, inbox.ErrClaimLost) })
+	}, time.Second, time.Millisecond)
+	require.Equal(t, 0, payouts("claimed-again"))
+	require.Equal(t, inbox.StateProcessing, state("claimed-again"))
+
+	cancel()
+	require.ErrorIs(t, <-stopped, context.Canceled)
+}
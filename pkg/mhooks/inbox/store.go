@@ -0,0 +1,105 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+)
+
+var (
+	ErrNotFound = errors.New("event not found in inbox")
+
+	// ErrClaimLost is returned when renewing the lease or saving the outcome of an event that was
+	// claimed again since, e.g. by another worker after its lease ran out.
+	ErrClaimLost = errors.New("event was claimed again")
+)
+
+// State is where an event is in its processing.
+type State string
+
+const (
+	// StatePending events are waiting to be handled, for the first time or after a failed attempt.
+	StatePending State = "pending"
+	// StateProcessing events are being handled by a worker.
+	StateProcessing State = "processing"
+	// StateDone events were handled successfully and are never handled again.
+	StateDone State = "done"
+	// StateDead events failed every attempt and wait to be retried by hand.
+	StateDead State = "dead"
+)
+
+// Record is an event stored in the inbox along with its processing state.
+type Record struct {
+	Event mhooks.Event `json:"event"`
+	State State        `json:"state"`
+	// Attempts counts how often the event was claimed by a worker.
+	Attempts int `json:"attempts"`
+	// NextAttempt is when a pending event is due to be handled.
+	NextAttempt time.Time `json:"nextAttempt"`
+	// LeasedUntil is when a processing event may be claimed again if its worker didn't finish it.
+	LeasedUntil time.Time `json:"leasedUntil"`
+	// LastError is the error of the latest failed attempt.
+	LastError  string    `json:"lastError,omitempty"`
+	ReceivedOn time.Time `json:"receivedOn"`
+	UpdatedOn  time.Time `json:"updatedOn"`
+}
+
+// Store persists the events of an inbox. Implementations must be safe for concurrent use.
+type Store interface {
+	// Add stores a pending record unless one with the same Event.EventID already exists, and
+	// reports if it was added.
+	Add(ctx context.Context, rec Record) (bool, error)
+
+	// Claim marks up to limit records as processing, leased until now+lease, increments their
+	// Attempts and returns them oldest event first. Pending records are claimed once their
+	// NextAttempt is due, and processing records once their lease ran out.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Record, error)
+
+	// Renew extends the lease of a claimed record until the time. It returns ErrClaimLost if the
+	// record isn't processing with the same Attempts anymore.
+	Renew(ctx context.Context, rec Record, until time.Time) error
+
+	// Release saves the State, NextAttempt, LastError and UpdatedOn of a claimed record. It returns
+	// ErrClaimLost if the record isn't processing with the same Attempts anymore.
+	Release(ctx context.Context, rec Record) error
+
+	// Get returns the record of the event, or ErrNotFound.
+	Get(ctx context.Context, eventID string) (*Record, error)
+
+	// List returns the records in the state, oldest event first.
+	List(ctx context.Context, state State) ([]Record, error)
+
+	// Requeue makes a dead record pending again with its attempts reset, or returns ErrNotFound.
+	Requeue(ctx context.Context, eventID string, now time.Time) error
+
+	// Prune deletes done records last updated before the time and returns how many it deleted.
+	Prune(ctx context.Context, before time.Time) (int, error)
+}
+
+// claimable reports if the record can be claimed at now.
+func (rec *Record) claimable(now time.Time) bool {
+	switch rec.State {
+	case StatePending:
+		return !rec.NextAttempt.After(now)
+	case StateProcessing:
+		return !rec.LeasedUntil.After(now)
+	}
+	return false
+}
+
+func (rec *Record) claim(now time.Time, lease time.Duration) {
+	rec.State = StateProcessing
+	rec.Attempts++
+	rec.LeasedUntil = now.Add(lease)
+	rec.UpdatedOn = now
+}
+
+// older orders records by the creation of their events, as Moov may deliver them out of order.
+func older(a, b *Record) int {
+	if c := a.Event.CreatedOn.Compare(b.Event.CreatedOn); c != 0 {
+		return c
+	}
+	return a.ReceivedOn.Compare(b.ReceivedOn)
+}
@@ -0,0 +1,64 @@
+package inbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks/inbox"
+	"github.com/moovfinancial/moov-go/pkg/mhooks/inbox/inboxtest"
+)
+
+// SQLStore is tested against SQLite in the sqlitetest module, which keeps the driver out of
+// this one.
+func TestStores(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		inboxtest.TestStore(t, inbox.NewMemoryStore())
+	})
+
+	t.Run("file", func(t *testing.T) {
+		store, err := inbox.NewFileStore(t.TempDir())
+		require.NoError(t, err)
+		inboxtest.TestStore(t, store)
+	})
+}
+
+func TestFileStore_Reopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	store, err := inbox.NewFileStore(dir)
+	require.NoError(t, err)
+
+	now := time.Now()
+	added, err := store.Add(ctx, inbox.Record{Event: inboxtest.Event(t, "event", now), State: inbox.StatePending, ReceivedOn: now})
+	require.NoError(t, err)
+	require.True(t, added)
+
+	claimed, err := store.Claim(ctx, now, time.Minute, 1)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	// The process crashed while handling the event, which is claimed again after its lease.
+	store, err = inbox.NewFileStore(dir)
+	require.NoError(t, err)
+
+	added, err = store.Add(ctx, inbox.Record{Event: inboxtest.Event(t, "event", now), State: inbox.StatePending, ReceivedOn: now})
+	require.NoError(t, err)
+	require.False(t, added)
+
+	claimed, err = store.Claim(ctx, now, time.Minute, 1)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	claimed, err = store.Claim(ctx, now.Add(time.Minute), time.Minute, 1)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, 2, claimed[0].Attempts)
+
+	data, err := claimed[0].Event.TransferUpdated()
+	require.NoError(t, err)
+	require.Equal(t, "event", data.TransferID)
+}
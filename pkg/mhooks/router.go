@@ -0,0 +1,461 @@
+package mhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+// Router is a ready-to-mount http.Handler for Moov webhooks. It verifies and parses every
+// request with ParseEvent and calls the handler registered for the event type with its typed
+// payload, e.g.
+//
+//	router := mhooks.NewRouter(secret)
+//	router.OnTransferUpdated(func(ctx context.Context, event *mhooks.Event, data *mhooks.TransferUpdated) error {
+//		...
+//	})
+//	http.Handle("/webhooks/moov", router)
+//
+// Webhooks are verified with WithMaxClockSkew(DefaultMaxClockSkew) and an in-memory nonce
+// store by default, so replayed requests are rejected with a 401. Use WithVerifyOptions to
+// share the nonce store between instances. A nonce is only kept once its event is
+// acknowledged, so Moov's redelivery of an event that failed isn't taken for a replay.
+//
+// Events are acknowledged with a 200 once their handler returns nil, and events without a
+// handler are acknowledged right away. Any other response makes Moov retry the event later:
+// handler errors are answered with a 500, or the status of a StatusError, handlers running
+// past their timeout with a 503 and panicking handlers with a 500.
+//
+// Events of types this package doesn't know yet are passed to the Fallback with their raw Data
+// rather than rejected, see DecodeData.
+type Router struct {
+	secrets        SecretProvider
+	verify         []VerifyOption
+	defaultTimeout time.Duration
+	onError        func(r *http.Request, event *Event, err error)
+
+	routes   map[EventType]route
+	fallback route
+}
+
+type route struct {
+	handle  func(ctx context.Context, event *Event) error
+	timeout time.Duration
+}
+
+type RouterOption func(r *Router)
+
+// WithSecrets verifies webhooks with any of the secrets returned by the provider instead of the
+// secret given to NewRouter, e.g. a moov.WebhookSecretRotation.
+func WithSecrets(provider SecretProvider) RouterOption {
+	return func(r *Router) {
+		r.secrets = provider
+	}
+}
+
+// WithVerifyOptions replaces the checks webhooks are verified with beyond their signature.
+func WithVerifyOptions(opts ...VerifyOption) RouterOption {
+	return func(r *Router) {
+		r.verify = opts
+	}
+}
+
+// WithDefaultTimeout sets how long handlers registered without a timeout of their own may run.
+// Defaults to no timeout.
+func WithDefaultTimeout(d time.Duration) RouterOption {
+	return func(r *Router) {
+		r.defaultTimeout = d
+	}
+}
+
+// WithErrorHandler calls fn for every request the router doesn't acknowledge, e.g. to log it.
+// event is nil when the request couldn't be parsed.
+func WithErrorHandler(fn func(r *http.Request, event *Event, err error)) RouterOption {
+	return func(r *Router) {
+		r.onError = fn
+	}
+}
+
+// NewRouter returns a router verifying webhooks with the signing secret.
+func NewRouter(secret string, opts ...RouterOption) *Router {
+	r := &Router{
+		secrets: StaticSecrets{secret},
+		verify:  []VerifyOption{WithMaxClockSkew(DefaultMaxClockSkew), WithNonceStore(NewMemoryNonceStore())},
+		routes:  map[EventType]route{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Handler handles the payload of an event of a given type.
+type Handler[T any] func(ctx context.Context, event *Event, data *T) error
+
+type HandlerOption func(rt *route)
+
+// WithTimeout limits how long the handler may run. The handler's context is canceled once it
+// runs out, and the event is answered with a 503 so Moov retries it. A handler ignoring its
+// context keeps running though, and a redelivery arriving meanwhile is rejected as a replay
+// with a 401. The event's nonce is only forgotten once the handler returns an error, so Moov's
+// next redelivery runs it again, or kept if it returns nil.
+func WithTimeout(d time.Duration) HandlerOption {
+	return func(rt *route) {
+		rt.timeout = d
+	}
+}
+
+// StatusError makes the router answer a failed event with Code instead of a 500, e.g. a 200 to
+// acknowledge an event that can't ever be handled so Moov stops retrying it.
+type StatusError struct {
+	Code int
+	Err  error
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%d: %v", e.Code, e.Err)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// WithStatus returns err answered with the HTTP status code by the Router.
+func WithStatus(code int, err error) error {
+	return &StatusError{Code: code, Err: err}
+}
+
+// ErrHandlerPanicked is returned for handlers that panicked, wrapped with the panic value.
+var ErrHandlerPanicked = errors.New("webhook handler panicked")
+
+// Fallback handles the events without a handler of their own. Without a fallback they're acknowledged.
+func (r *Router) Fallback(handle func(ctx context.Context, event *Event) error, opts ...HandlerOption) {
+	r.fallback = r.newRoute(handle, opts)
+}
+
+// Handle registers an untyped handler for the event type, replacing any previous one.
+func (r *Router) Handle(eventType EventType, handle func(ctx context.Context, event *Event) error, opts ...HandlerOption) {
+	r.routes[eventType] = r.newRoute(handle, opts)
+}
+
+func (r *Router) newRoute(handle func(ctx context.Context, event *Event) error, opts []HandlerOption) route {
+	rt := route{handle: handle, timeout: r.defaultTimeout}
+	for _, opt := range opts {
+		opt(&rt)
+	}
+	return rt
+}
+
+// on registers a handler receiving the payload returned by the event's getter.
+func on[T any](r *Router, eventType EventType, get func(Event) (*T, error), handle Handler[T], opts []HandlerOption) {
+	r.Handle(eventType, func(ctx context.Context, event *Event) error {
+		data, err := get(*event)
+		if err != nil {
+			return err
+		}
+		return handle(ctx, event, data)
+	}, opts...)
+}
+
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	opts := make([]ParseOption, 0, len(r.verify)+1)
+	for _, opt := range r.verify {
+		opts = append(opts, opt)
+	}
+	opts = append(opts, WithUnknownEventTypes())
+	event, _, err := ParseEventWithSecrets(req, r.secrets, opts...)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, ErrInvalidSignature) || errors.Is(err, ErrTimestampExpired) || errors.Is(err, ErrNonceReused) {
+			code = http.StatusUnauthorized
+		}
+		r.fail(w, req, nil, code, err)
+		return
+	}
+
+	rt, ok := r.routes[event.EventType]
+	if !ok {
+		rt = r.fallback
+	}
+	if rt.handle == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	running, err := rt.run(req.Context(), event)
+	if err != nil {
+		code := statusCode(err)
+
+		// Moov redelivers events that weren't acknowledged with the same nonce. The nonce of a
+		// handler still running past its timeout is only forgotten once it failed, so the
+		// redelivery doesn't run alongside it.
+		if nonces := newVerifier(r.verify).nonces; !acknowledged(code) && nonces != nil {
+			ctx, nonce := context.WithoutCancel(req.Context()), req.Header.Get("x-nonce")
+			forget := func() error {
+				if err := nonces.Remove(ctx, nonce); err != nil {
+					return fmt.Errorf("forgetting webhook nonce: %w", err)
+				}
+				return nil
+			}
+
+			if running == nil {
+				err = errors.Join(err, forget())
+			} else {
+				go func() {
+					if handleErr := <-running; handleErr != nil && !acknowledged(statusCode(handleErr)) {
+						if err := forget(); err != nil && r.onError != nil {
+							r.onError(req, event, err)
+						}
+					}
+				}()
+			}
+		}
+		r.fail(w, req, event, code, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// statusCode returns the status a failed event is answered with. Codes of a StatusError that
+// aren't valid HTTP status codes are answered with a 500.
+func statusCode(err error) int {
+	var statusErr *StatusError
+	switch {
+	case errors.As(err, &statusErr) && statusErr.Code >= 100 && statusErr.Code <= 999:
+		return statusErr.Code
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func acknowledged(code int) bool {
+	return code >= 200 && code <= 299
+}
+
+// run calls the handler, recovering from panics and returning once its timeout runs out even if
+// the handler ignores its context. The handler's error is then sent on running once it returns.
+func (rt route) run(ctx context.Context, event *Event) (running <-chan error, err error) {
+	if rt.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rt.timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("%w: %v\n%s", ErrHandlerPanicked, p, debug.Stack())
+			}
+		}()
+		done <- rt.handle(ctx, event)
+	}()
+
+	select {
+	case err := <-done:
+		return nil, err
+	case <-ctx.Done():
+		return done, fmt.Errorf("handling %s event %s: %w", event.EventType, event.EventID, ctx.Err())
+	}
+}
+
+func (r *Router) fail(w http.ResponseWriter, req *http.Request, event *Event, code int, err error) {
+	if r.onError != nil {
+		r.onError(req, event, err)
+	}
+	http.Error(w, http.StatusText(code), code)
+}
+
+func (r *Router) OnAccountCreated(handle Handler[AccountCreated], opts ...HandlerOption) {
+	on(r, EventTypeAccountCreated, Event.AccountCreated, handle, opts)
+}
+
+func (r *Router) OnAccountDisconnected(handle Handler[AccountDisconnected], opts ...HandlerOption) {
+	on(r, EventTypeAccountDisconnected, Event.AccountDisconnected, handle, opts)
+}
+
+func (r *Router) OnAccountUpdated(handle Handler[AccountUpdated], opts ...HandlerOption) {
+	on(r, EventTypeAccountUpdated, Event.AccountUpdated, handle, opts)
+}
+
+func (r *Router) OnBalanceUpdated(handle Handler[BalanceUpdated], opts ...HandlerOption) {
+	on(r, EventTypeBalanceUpdated, Event.BalanceUpdated, handle, opts)
+}
+
+func (r *Router) OnBankAccountCreated(handle Handler[BankAccountCreated], opts ...HandlerOption) {
+	on(r, EventTypeBankAccountCreated, Event.BankAccountCreated, handle, opts)
+}
+
+func (r *Router) OnBankAccountDeleted(handle Handler[BankAccountDeleted], opts ...HandlerOption) {
+	on(r, EventTypeBankAccountDeleted, Event.BankAccountDeleted, handle, opts)
+}
+
+func (r *Router) OnBankAccountUpdated(handle Handler[BankAccountUpdated], opts ...HandlerOption) {
+	on(r, EventTypeBankAccountUpdated, Event.BankAccountUpdated, handle, opts)
+}
+
+func (r *Router) OnCancellationCreated(handle Handler[CancellationCreated], opts ...HandlerOption) {
+	on(r, EventTypeCancellationCreated, Event.CancellationCreated, handle, opts)
+}
+
+func (r *Router) OnCancellationUpdated(handle Handler[CancellationUpdated], opts ...HandlerOption) {
+	on(r, EventTypeCancellationUpdated, Event.CancellationUpdated, handle, opts)
+}
+
+func (r *Router) OnCardAutoUpdated(handle Handler[CardAutoUpdated], opts ...HandlerOption) {
+	on(r, EventTypeCardAutoUpdated, Event.CardAutoUpdated, handle, opts)
+}
+
+func (r *Router) OnCapabilityRequested(handle Handler[CapabilityRequested], opts ...HandlerOption) {
+	on(r, EventTypeCapabilityRequested, Event.CapabilityRequested, handle, opts)
+}
+
+func (r *Router) OnCapabilityUpdated(handle Handler[CapabilityUpdated], opts ...HandlerOption) {
+	on(r, EventTypeCapabilityUpdated, Event.CapabilityUpdated, handle, opts)
+}
+
+func (r *Router) OnDisputeCreated(handle Handler[DisputeCreated], opts ...HandlerOption) {
+	on(r, EventTypeDisputeCreated, Event.DisputeCreated, handle, opts)
+}
+
+func (r *Router) OnDisputeUpdated(handle Handler[DisputeUpdated], opts ...HandlerOption) {
+	on(r, EventTypeDisputeUpdated, Event.DisputeUpdated, handle, opts)
+}
+
+func (r *Router) OnNetworkIDUpdated(handle Handler[NetworkIDUpdated], opts ...HandlerOption) {
+	on(r, EventTypeNetworkIDUpdated, Event.NetworkIDUpdated, handle, opts)
+}
+
+func (r *Router) OnPaymentMethodDisabled(handle Handler[PaymentMethodDisabled], opts ...HandlerOption) {
+	on(r, EventTypePaymentMethodDisabled, Event.PaymentMethodDisabled, handle, opts)
+}
+
+func (r *Router) OnPaymentMethodEnabled(handle Handler[PaymentMethodEnabled], opts ...HandlerOption) {
+	on(r, EventTypePaymentMethodEnabled, Event.PaymentMethodEnabled, handle, opts)
+}
+
+func (r *Router) OnRefundCreated(handle Handler[RefundCreated], opts ...HandlerOption) {
+	on(r, EventTypeRefundCreated, Event.RefundCreated, handle, opts)
+}
+
+func (r *Router) OnRefundUpdated(handle Handler[RefundUpdated], opts ...HandlerOption) {
+	on(r, EventTypeRefundUpdated, Event.RefundUpdated, handle, opts)
+}
+
+func (r *Router) OnRepresentativeCreated(handle Handler[RepresentativeCreated], opts ...HandlerOption) {
+	on(r, EventTypeRepresentativeCreated, Event.RepresentativeCreated, handle, opts)
+}
+
+func (r *Router) OnRepresentativeDeleted(handle Handler[RepresentativeDeleted], opts ...HandlerOption) {
+	on(r, EventTypeRepresentativeDeleted, Event.RepresentativeDeleted, handle, opts)
+}
+
+func (r *Router) OnRepresentativeUpdated(handle Handler[RepresentativeUpdated], opts ...HandlerOption) {
+	on(r, EventTypeRepresentativeUpdated, Event.RepresentativeUpdated, handle, opts)
+}
+
+func (r *Router) OnSweepCreated(handle Handler[SweepCreated], opts ...HandlerOption) {
+	on(r, EventTypeSweepCreated, Event.SweepCreated, handle, opts)
+}
+
+func (r *Router) OnSweepUpdated(handle Handler[SweepUpdated], opts ...HandlerOption) {
+	on(r, EventTypeSweepUpdated, Event.SweepUpdated, handle, opts)
+}
+
+func (r *Router) OnTestPing(handle Handler[TestPing], opts ...HandlerOption) {
+	on(r, EventTypeTestPing, Event.TestPing, handle, opts)
+}
+
+func (r *Router) OnTicketCreated(handle Handler[TicketCreated], opts ...HandlerOption) {
+	on(r, EventTypeTicketCreated, Event.TicketCreated, handle, opts)
+}
+
+func (r *Router) OnTicketUpdated(handle Handler[TicketUpdated], opts ...HandlerOption) {
+	on(r, EventTypeTicketUpdated, Event.TicketUpdated, handle, opts)
+}
+
+func (r *Router) OnTicketMessageAdded(handle Handler[TicketMessageAdded], opts ...HandlerOption) {
+	on(r, EventTypeTicketMessageAdded, Event.TicketMessageAdded, handle, opts)
+}
+
+func (r *Router) OnTransferCreated(handle Handler[TransferCreated], opts ...HandlerOption) {
+	on(r, EventTypeTransferCreated, Event.TransferCreated, handle, opts)
+}
+
+func (r *Router) OnTransferUpdated(handle Handler[TransferUpdated], opts ...HandlerOption) {
+	on(r, EventTypeTransferUpdated, Event.TransferUpdated, handle, opts)
+}
+
+func (r *Router) OnWalletCreated(handle Handler[WalletCreated], opts ...HandlerOption) {
+	on(r, EventTypeWalletCreated, Event.WalletCreated, handle, opts)
+}
+
+func (r *Router) OnWalletUpdated(handle Handler[WalletUpdated], opts ...HandlerOption) {
+	on(r, EventTypeWalletUpdated, Event.WalletUpdated, handle, opts)
+}
+
+func (r *Router) OnWalletTransactionUpdated(handle Handler[WalletTransactionUpdated], opts ...HandlerOption) {
+	on(r, EventTypeWalletTransactionUpdated, Event.WalletTransactionUpdated, handle, opts)
+}
+
+func (r *Router) OnBillingStatementCreated(handle Handler[BillingStatementCreated], opts ...HandlerOption) {
+	on(r, EventTypeBillingStatementCreated, Event.BillingStatementCreated, handle, opts)
+}
+
+func (r *Router) OnInvoiceCreated(handle Handler[InvoiceCreated], opts ...HandlerOption) {
+	on(r, EventTypeInvoiceCreated, Event.InvoiceCreated, handle, opts)
+}
+
+func (r *Router) OnInvoiceUpdated(handle Handler[InvoiceUpdated], opts ...HandlerOption) {
+	on(r, EventTypeInvoiceUpdated, Event.InvoiceUpdated, handle, opts)
+}
+
+func (r *Router) OnIssuedCardCreated(handle Handler[IssuedCardCreated], opts ...HandlerOption) {
+	on(r, EventTypeIssuedCardCreated, Event.IssuedCardCreated, handle, opts)
+}
+
+func (r *Router) OnIssuedCardUpdated(handle Handler[IssuedCardUpdated], opts ...HandlerOption) {
+	on(r, EventTypeIssuedCardUpdated, Event.IssuedCardUpdated, handle, opts)
+}
+
+func (r *Router) OnIssuedCardAuthorizationCreated(handle Handler[IssuedCardAuthorizationCreated], opts ...HandlerOption) {
+	on(r, EventTypeIssuedCardAuthorizationCreated, Event.IssuedCardAuthorizationCreated, handle, opts)
+}
+
+func (r *Router) OnIssuedCardAuthorizationUpdated(handle Handler[IssuedCardAuthorizationUpdated], opts ...HandlerOption) {
+	on(r, EventTypeIssuedCardAuthorizationUpdated, Event.IssuedCardAuthorizationUpdated, handle, opts)
+}
+
+func (r *Router) OnIssuedCardTransactionCreated(handle Handler[IssuedCardTransactionCreated], opts ...HandlerOption) {
+	on(r, EventTypeIssuedCardTransactionCreated, Event.IssuedCardTransactionCreated, handle, opts)
+}
+
+func (r *Router) OnResolutionLinkCreated(handle Handler[ResolutionLinkCreated], opts ...HandlerOption) {
+	on(r, EventTypeResolutionLinkCreated, Event.ResolutionLinkCreated, handle, opts)
+}
+
+func (r *Router) OnResolutionLinkUpdated(handle Handler[ResolutionLinkUpdated], opts ...HandlerOption) {
+	on(r, EventTypeResolutionLinkUpdated, Event.ResolutionLinkUpdated, handle, opts)
+}
+
+func (r *Router) OnScheduleCreated(handle Handler[ScheduleCreated], opts ...HandlerOption) {
+	on(r, EventTypeScheduleCreated, Event.ScheduleCreated, handle, opts)
+}
+
+func (r *Router) OnScheduleUpdated(handle Handler[ScheduleUpdated], opts ...HandlerOption) {
+	on(r, EventTypeScheduleUpdated, Event.ScheduleUpdated, handle, opts)
+}
+
+func (r *Router) OnScheduleOccurrenceUpdated(handle Handler[ScheduleOccurrenceUpdated], opts ...HandlerOption) {
+	on(r, EventTypeScheduleOccurrenceUpdated, Event.ScheduleOccurrenceUpdated, handle, opts)
+}
+
+func (r *Router) OnUnderwritingUpdated(handle Handler[UnderwritingUpdated], opts ...HandlerOption) {
+	on(r, EventTypeUnderwritingUpdated, Event.UnderwritingUpdated, handle, opts)
+}
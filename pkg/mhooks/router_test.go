@@ -0,0 +1,193 @@
+package mhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+const testSecret = "my-webhook-signing-secret"
+
+// signedRequest returns a webhook request for the event signed with secret like Moov does.
+func signedRequest(t *testing.T, secret string, eventType EventType, data any) *http.Request {
+	t.Helper()
+
+	dataBytes, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	var body bytes.Buffer
+	require.NoError(t, json.NewEncoder(&body).Encode(Event{
+		EventID:   uuid.NewString(),
+		EventType: eventType,
+		Data:      dataBytes,
+		CreatedOn: time.Now(),
+	}))
+
+	var (
+		timestamp = time.Now().UTC().Format(time.RFC3339)
+		nonce     = uuid.NewString()
+		webhookID = uuid.NewString()
+	)
+	signature, err := hash([]byte(timestamp+"|"+nonce+"|"+webhookID), []byte(secret))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", &body)
+	req.Header.Set("x-timestamp", timestamp)
+	req.Header.Set("x-nonce", nonce)
+	req.Header.Set("x-webhook-id", webhookID)
+	req.Header.Set("x-signature", *signature)
+	return req
+}
+
+func serve(h http.Handler, req *http.Request) int {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestRouter(t *testing.T) {
+	transfer := TransferUpdated{AccountID: "account", TransferID: "transfer", Status: TransferUpdatedStatus_Completed}
+
+	var errs []error
+	router := NewRouter(testSecret, WithErrorHandler(func(_ *http.Request, _ *Event, err error) {
+		errs = append(errs, err)
+	}))
+
+	var got *TransferUpdated
+	router.OnTransferUpdated(func(_ context.Context, event *Event, data *TransferUpdated) error {
+		require.Equal(t, EventTypeTransferUpdated, event.EventType)
+		got = data
+		return nil
+	})
+	router.OnDisputeCreated(func(context.Context, *Event, *DisputeCreated) error {
+		return errors.New("database is down")
+	})
+	router.OnDisputeUpdated(func(context.Context, *Event, *DisputeUpdated) error {
+		return WithStatus(http.StatusOK, errors.New("dispute no longer exists"))
+	})
+	router.OnWalletCreated(func(context.Context, *Event, *WalletCreated) error {
+		panic("boom")
+	})
+	router.OnWalletUpdated(func(ctx context.Context, _ *Event, _ *WalletUpdated) error {
+		time.Sleep(time.Second)
+		return nil
+	}, WithTimeout(10*time.Millisecond))
+
+	require.Equal(t, http.StatusOK, serve(router, signedRequest(t, testSecret, EventTypeTransferUpdated, transfer)))
+	require.Equal(t, transfer, *got)
+
+	require.Equal(t, http.StatusInternalServerError, serve(router, signedRequest(t, testSecret, EventTypeDisputeCreated, DisputeCreated{})))
+	require.Equal(t, http.StatusOK, serve(router, signedRequest(t, testSecret, EventTypeDisputeUpdated, DisputeUpdated{})))
+	require.Equal(t, http.StatusInternalServerError, serve(router, signedRequest(t, testSecret, EventTypeWalletCreated, WalletCreated{})))
+	require.ErrorIs(t, errs[len(errs)-1], ErrHandlerPanicked)
+	require.Equal(t, http.StatusServiceUnavailable, serve(router, signedRequest(t, testSecret, EventTypeWalletUpdated, WalletUpdated{})))
+	require.ErrorIs(t, errs[len(errs)-1], context.DeadlineExceeded)
+
+	require.Equal(t, http.StatusUnauthorized, serve(router, signedRequest(t, "wrong-secret", EventTypeTransferUpdated, transfer)))
+	require.ErrorIs(t, errs[len(errs)-1], ErrInvalidSignature)
+
+	req := signedRequest(t, testSecret, EventTypeTransferUpdated, transfer)
+	replayed := signedRequest(t, testSecret, EventTypeTransferUpdated, transfer)
+	replayed.Header = req.Header.Clone()
+	require.Equal(t, http.StatusOK, serve(router, req))
+	require.Equal(t, http.StatusUnauthorized, serve(router, replayed))
+	require.ErrorIs(t, errs[len(errs)-1], ErrNonceReused)
+
+	// Failed events are redelivered with the same nonce.
+	failed := signedRequest(t, testSecret, EventTypeDisputeCreated, DisputeCreated{})
+	redelivered := signedRequest(t, testSecret, EventTypeDisputeCreated, DisputeCreated{})
+	redelivered.Header = failed.Header.Clone()
+	require.Equal(t, http.StatusInternalServerError, serve(router, failed))
+	require.Equal(t, http.StatusInternalServerError, serve(router, redelivered))
+	require.NotErrorIs(t, errs[len(errs)-1], ErrNonceReused)
+
+	acknowledged := signedRequest(t, testSecret, EventTypeDisputeUpdated, DisputeUpdated{})
+	replayed = signedRequest(t, testSecret, EventTypeDisputeUpdated, DisputeUpdated{})
+	replayed.Header = acknowledged.Header.Clone()
+	require.Equal(t, http.StatusOK, serve(router, acknowledged))
+	require.Equal(t, http.StatusUnauthorized, serve(router, replayed))
+
+	t.Run("fallback", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, serve(router, signedRequest(t, testSecret, EventTypeTestPing, TestPing{Ping: true})))
+
+		var fallback []EventType
+		router.Fallback(func(_ context.Context, event *Event) error {
+			fallback = append(fallback, event.EventType)
+			return nil
+		})
+		require.Equal(t, http.StatusOK, serve(router, signedRequest(t, testSecret, EventTypeTestPing, TestPing{Ping: true})))
+		require.Equal(t, []EventType{EventTypeTestPing}, fallback)
+
+		require.Equal(t, http.StatusOK, serve(router, signedRequest(t, testSecret, "paymentLink.created", map[string]string{})))
+		require.Equal(t, []EventType{EventTypeTestPing, "paymentLink.created"}, fallback)
+	})
+
+	t.Run("issuing", func(t *testing.T) {
+		authorization := IssuedCardAuthorizationUpdated{
+			AccountID:       "account",
+			IssuedCardID:    "card",
+			AuthorizationID: "authorization",
+			Status:          moov.IssuedCardAuthorizationStatus_Cleared,
+		}
+
+		var got *IssuedCardAuthorizationUpdated
+		router.OnIssuedCardAuthorizationUpdated(func(_ context.Context, _ *Event, data *IssuedCardAuthorizationUpdated) error {
+			got = data
+			return nil
+		})
+		require.Equal(t, http.StatusOK, serve(router, signedRequest(t, testSecret, EventTypeIssuedCardAuthorizationUpdated, authorization)))
+		require.Equal(t, authorization, *got)
+	})
+
+	t.Run("invalid status codes", func(t *testing.T) {
+		router := NewRouter(testSecret)
+		router.Fallback(func(context.Context, *Event) error {
+			return WithStatus(42, errors.New("not a status code"))
+		})
+		require.Equal(t, http.StatusInternalServerError, serve(router, signedRequest(t, testSecret, EventTypeTestPing, TestPing{})))
+	})
+
+	t.Run("handlers running past their timeout", func(t *testing.T) {
+		var (
+			release = make(chan error)
+			calls   atomic.Int32
+		)
+		router := NewRouter(testSecret)
+		router.Fallback(func(context.Context, *Event) error {
+			if calls.Add(1) > 1 {
+				return nil
+			}
+			return <-release
+		}, WithTimeout(10*time.Millisecond))
+
+		redeliver := func(req *http.Request) *http.Request {
+			redelivered := signedRequest(t, testSecret, EventTypeTestPing, TestPing{})
+			redelivered.Header = req.Header.Clone()
+			return redelivered
+		}
+		timedOut := signedRequest(t, testSecret, EventTypeTestPing, TestPing{})
+		require.Equal(t, http.StatusServiceUnavailable, serve(router, timedOut))
+
+		// The redelivery doesn't run alongside the handler still running.
+		require.Equal(t, http.StatusUnauthorized, serve(router, redeliver(timedOut)))
+		require.Equal(t, int32(1), calls.Load())
+
+		// It runs again once the handler failed.
+		release <- errors.New("database is down")
+		require.Eventually(t, func() bool {
+			return serve(router, redeliver(timedOut)) == http.StatusOK
+		}, time.Second, time.Millisecond)
+		require.Equal(t, int32(2), calls.Load())
+	})
+}
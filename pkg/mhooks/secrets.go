@@ -0,0 +1,19 @@
+package mhooks
+
+import (
+	"context"
+)
+
+// SecretProvider returns the signing secrets webhooks are currently accepted with, e.g. the new
+// and the previous secret while a secret is rotated. moov.WebhookSecretRotation implements it.
+type SecretProvider interface {
+	// Secrets returns the active secrets of the webhook, newest first.
+	Secrets(ctx context.Context, webhookID string) ([]string, error)
+}
+
+// StaticSecrets is a SecretProvider accepting the same secrets for every webhook.
+type StaticSecrets []string
+
+func (s StaticSecrets) Secrets(context.Context, string) ([]string, error) {
+	return s, nil
+}
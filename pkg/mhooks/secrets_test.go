@@ -0,0 +1,82 @@
+package mhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+var _ SecretProvider = (*moov.WebhookSecretRotation)(nil)
+
+func TestParseEventWithSecrets(t *testing.T) {
+	req := signedRequest(t, "new-secret", EventTypeTestPing, TestPing{Ping: true})
+	event, matched, err := ParseEventWithSecrets(req, StaticSecrets{"old-secret", "new-secret"})
+	require.NoError(t, err)
+	require.Equal(t, EventTypeTestPing, event.EventType)
+	require.Equal(t, 1, matched)
+
+	req = signedRequest(t, "other-secret", EventTypeTestPing, TestPing{Ping: true})
+	_, _, err = ParseEventWithSecrets(req, StaticSecrets{"old-secret", "new-secret"})
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestWebhookSecretRotation(t *testing.T) {
+	secret := "old-secret"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/webhooks/webhook/secret", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(moov.WebhookSecret{Secret: secret})
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := moov.NewClient(
+		moov.WithCredentials(moov.Credentials{PublicKey: "pk", SecretKey: "sk", Host: strings.TrimPrefix(srv.URL, "http://")}),
+		moov.WithMoovURLScheme("http"),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	rotation, err := client.NewWebhookSecretRotation(ctx, "webhook", 50*time.Millisecond)
+	require.NoError(t, err)
+
+	parse := func(signedWith string) (int, error) {
+		_, matched, err := ParseEventWithSecrets(signedRequest(t, signedWith, EventTypeTestPing, TestPing{Ping: true}), rotation)
+		return matched, err
+	}
+
+	rotated, err := rotation.Rotate(ctx)
+	require.NoError(t, err)
+	require.False(t, rotated)
+
+	secret = "new-secret"
+	rotated, err = rotation.Rotate(ctx)
+	require.NoError(t, err)
+	require.True(t, rotated)
+
+	matched, err := parse("new-secret")
+	require.NoError(t, err)
+	require.Equal(t, 0, matched)
+	matched, err = parse("old-secret")
+	require.NoError(t, err)
+	require.Equal(t, 1, matched)
+
+	require.Eventually(t, func() bool {
+		_, err := parse("old-secret")
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	secret = "newest-secret"
+	_, err = rotation.Rotate(ctx)
+	require.NoError(t, err)
+	rotation.Retire()
+	_, err = parse("new-secret")
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
@@ -1,14 +1,133 @@
 package mhooks
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha512"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"net/http"
+	"sync"
+	"time"
 )
 
-var ErrInvalidSignature = errors.New("calculated signature does not match X-Signature header")
+var (
+	ErrInvalidSignature = errors.New("calculated signature does not match X-Signature header")
+	ErrTimestampExpired = errors.New("X-Timestamp header is outside of the allowed clock skew")
+	ErrNonceReused      = errors.New("X-Nonce header was already used")
+)
+
+const (
+	// DefaultMaxClockSkew is how far the X-Timestamp of a webhook may be from the current time in
+	// a Router verifying webhooks with the default options.
+	DefaultMaxClockSkew = 5 * time.Minute
+
+	// defaultNonceTTL is how long nonces are remembered when timestamps aren't checked.
+	defaultNonceTTL = 24 * time.Hour
+)
+
+// VerifyOption adds checks to the verification of webhooks by ParseEvent.
+type VerifyOption func(v *verifier)
+
+func (opt VerifyOption) applyParse(p *parser) {
+	p.verify = append(p.verify, opt)
+}
+
+// WithMaxClockSkew rejects webhooks whose X-Timestamp is further than d from the current time
+// with ErrTimestampExpired, so captured requests can't be replayed later on.
+func WithMaxClockSkew(d time.Duration) VerifyOption {
+	return func(v *verifier) {
+		v.maxSkew = d
+	}
+}
+
+// WithNonceStore rejects webhooks whose X-Nonce was seen before with ErrNonceReused. Nonces are
+// remembered for twice the max clock skew, or a day without one.
+func WithNonceStore(store NonceStore) VerifyOption {
+	return func(v *verifier) {
+		v.nonces = store
+	}
+}
+
+// NonceStore remembers the nonces of verified webhooks.
+type NonceStore interface {
+	// Add records the nonce for ttl and reports if it was new. A nonce that's already recorded
+	// returns false.
+	Add(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+
+	// Remove forgets the nonce, so the Router accepts Moov's redelivery of a webhook it failed
+	// to handle.
+	Remove(ctx context.Context, nonce string) error
+}
+
+type verifier struct {
+	maxSkew time.Duration
+	nonces  NonceStore
+	now     func() time.Time
+}
+
+func newVerifier(opts []VerifyOption) *verifier {
+	v := &verifier{now: time.Now}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// verify checks the signature of the webhook first, so only requests signed by Moov get to the
+// timestamp check. It returns the index of the secret the webhook was signed with. The nonce is
+// left to addNonce, called once the event is parsed so webhooks that fail to parse can be
+// redelivered.
+func (v *verifier) verify(ctx context.Context, headers http.Header, secrets []string) (int, error) {
+	matched := -1
+	for i, secret := range secrets {
+		isValid, err := checkSignature(headers, secret)
+		if err != nil {
+			return -1, fmt.Errorf("checking webhook signature: %w", err)
+		}
+		if isValid {
+			matched = i
+			break
+		}
+	}
+	if matched < 0 {
+		return -1, ErrInvalidSignature
+	}
+
+	if v.maxSkew > 0 {
+		timestamp, err := time.Parse(time.RFC3339, headers.Get("x-timestamp"))
+		if err != nil {
+			return -1, fmt.Errorf("%w: %w", ErrTimestampExpired, err)
+		}
+		if skew := v.now().Sub(timestamp).Abs(); skew > v.maxSkew {
+			return -1, fmt.Errorf("%w: %s is %s away", ErrTimestampExpired, timestamp.Format(time.RFC3339), skew.Round(time.Second))
+		}
+	}
+
+	return matched, nil
+}
+
+// addNonce records the nonce of the webhook, failing with ErrNonceReused if it was seen before.
+func (v *verifier) addNonce(ctx context.Context, headers http.Header) error {
+	if v.nonces == nil {
+		return nil
+	}
+
+	ttl := defaultNonceTTL
+	if v.maxSkew > 0 {
+		ttl = 2 * v.maxSkew
+	}
+
+	added, err := v.nonces.Add(ctx, headers.Get("x-nonce"), ttl)
+	if err != nil {
+		return fmt.Errorf("checking webhook nonce: %w", err)
+	}
+	if !added {
+		return ErrNonceReused
+	}
+	return nil
+}
 
 func checkSignature(headers http.Header, secret string) (bool, error) {
 	var (
@@ -24,11 +143,7 @@ func checkSignature(headers http.Header, secret string) (bool, error) {
 		return false, err
 	}
 
-	if *wantHash == gotHash {
-		return true, nil
-	} else {
-		return false, nil
-	}
+	return hmac.Equal([]byte(*wantHash), []byte(gotHash)), nil
 }
 
 // hash generates a SHA512 HMAC hash of p using the secret provided.
@@ -41,3 +156,49 @@ func hash(p []byte, secret []byte) (*string, error) {
 	hash := hex.EncodeToString(h.Sum(nil))
 	return &hash, nil
 }
+
+// MemoryNonceStore is a NonceStore kept in memory. Use a shared store, e.g. backed by Redis,
+// when webhooks are received by several instances.
+type MemoryNonceStore struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	nonces    map[string]time.Time
+	nextSweep time.Time
+}
+
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{
+		now:    time.Now,
+		nonces: map[string]time.Time{},
+	}
+}
+
+func (s *MemoryNonceStore) Add(_ context.Context, nonce string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.After(s.nextSweep) {
+		for n, expires := range s.nonces {
+			if now.After(expires) {
+				delete(s.nonces, n)
+			}
+		}
+		s.nextSweep = now.Add(time.Minute)
+	}
+
+	if expires, ok := s.nonces[nonce]; ok && !now.After(expires) {
+		return false, nil
+	}
+	s.nonces[nonce] = now.Add(ttl)
+	return true, nil
+}
+
+func (s *MemoryNonceStore) Remove(_ context.Context, nonce string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.nonces, nonce)
+	return nil
+}
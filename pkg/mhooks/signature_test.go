@@ -0,0 +1,83 @@
+package mhooks
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayProtection(t *testing.T) {
+	// parse parses a copy of req so the same request can be sent again.
+	parse := func(req *http.Request, opts ...ParseOption) error {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		replay := req.Clone(context.Background())
+		replay.Body = io.NopCloser(bytes.NewReader(body))
+		_, err = ParseEvent(replay, testSecret, opts...)
+		return err
+	}
+
+	t.Run("timestamp", func(t *testing.T) {
+		req := signedRequest(t, testSecret, EventTypeTestPing, TestPing{Ping: true})
+		require.NoError(t, parse(req, WithMaxClockSkew(time.Minute)))
+
+		v := &verifier{maxSkew: time.Minute, now: func() time.Time { return time.Now().Add(2 * time.Minute) }}
+		_, err := v.verify(context.Background(), req.Header, []string{testSecret})
+		require.ErrorIs(t, err, ErrTimestampExpired)
+
+		// The timestamp is signed, so it can't be changed to pass the check.
+		req.Header.Set("x-timestamp", time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+		require.ErrorIs(t, parse(req, WithMaxClockSkew(time.Minute)), ErrInvalidSignature)
+	})
+
+	t.Run("nonce", func(t *testing.T) {
+		store := NewMemoryNonceStore()
+		req := signedRequest(t, testSecret, EventTypeTestPing, TestPing{Ping: true})
+
+		require.NoError(t, parse(req, WithNonceStore(store)))
+		require.ErrorIs(t, parse(req, WithNonceStore(store)), ErrNonceReused)
+
+		// Without the store a replay isn't noticed, as before.
+		require.NoError(t, parse(req))
+	})
+
+	t.Run("nonce of webhooks failing to parse", func(t *testing.T) {
+		store := NewMemoryNonceStore()
+		req := signedRequest(t, testSecret, EventType("unknown.created"), TestPing{Ping: true})
+
+		// The redelivered webhook can still be parsed once the event type is known.
+		require.ErrorContains(t, parse(req, WithNonceStore(store)), "invalid event type")
+		require.NoError(t, parse(req, WithNonceStore(store), WithUnknownEventTypes()))
+		require.ErrorIs(t, parse(req, WithNonceStore(store), WithUnknownEventTypes()), ErrNonceReused)
+	})
+
+	t.Run("nonces expire", func(t *testing.T) {
+		now := time.Now()
+		store := NewMemoryNonceStore()
+		store.now = func() time.Time { return now }
+
+		added, err := store.Add(context.Background(), "nonce", time.Minute)
+		require.NoError(t, err)
+		require.True(t, added)
+
+		added, err = store.Add(context.Background(), "nonce", time.Minute)
+		require.NoError(t, err)
+		require.False(t, added)
+
+		now = now.Add(2 * time.Minute)
+		added, err = store.Add(context.Background(), "nonce", time.Minute)
+		require.NoError(t, err)
+		require.True(t, added)
+		require.Len(t, store.nonces, 1)
+
+		require.NoError(t, store.Remove(context.Background(), "nonce"))
+		require.Empty(t, store.nonces)
+	})
+}
@@ -1,7 +1,9 @@
 package moov
 
 import (
+	"cmp"
 	"context"
+	"iter"
 	"net/http"
 	"strconv"
 )
@@ -141,6 +143,23 @@ func (c *Client) CreateAccount(ctx context.Context, account CreateAccount) (crea
 	}
 }
 
+// CreateAccountOperation creates the account like CreateAccount and returns it as an Operation.
+// When Moov only started creating it, the operation is done once GetAccount returns it.
+func (c *Client) CreateAccountOperation(ctx context.Context, account CreateAccount) (*Operation[Account], error) {
+	created, started, err := c.CreateAccount(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	fetched := created != nil
+	result := cmp.Or(created, started)
+	return NewOperation(result, func(*Account) bool { return fetched }, func(ctx context.Context) (*Account, error) {
+		a, err := c.GetAccount(ctx, result.AccountID)
+		fetched = err == nil
+		return a, err
+	}), nil
+}
+
 // Use only for Preversioned API calls. Use mvxxxx.Accounts.Get(...) instead.
 // GetAccount returns an account based on accountID.
 func (c Client) GetAccount(ctx context.Context, accountID string) (*Account, error) {
@@ -262,6 +281,14 @@ func (c Client) ListAccounts(ctx context.Context, opts ...ListAccountFilter) ([]
 	return CompletedListOrError[Account](resp)
 }
 
+// AllAccounts iterates over all accounts matching the filters, fetching pages of
+// WithAccountCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllAccounts(ctx context.Context, opts ...ListAccountFilter) iter.Seq2[Account, error] {
+	return paginate(ctx, opts, func(skip, count int) ([]Account, error) {
+		return c.ListAccounts(ctx, append(opts[:len(opts):len(opts)], WithAccountSkip(skip), WithAccountCount(count))...)
+	})
+}
+
 // Only use for Preversioned API calls. Use mvxxxx.Accounts.Disconnect(...) instead.
 func (c Client) DisconnectAccount(ctx context.Context, accountID string) error {
 	resp, err := c.CallHttp(ctx,
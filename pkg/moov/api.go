@@ -0,0 +1,5 @@
+package moov
+
+// The interfaces in api_gen.go and the moovmock fake are generated from the Client's methods.
+// Map new files declaring Client methods to an interface in internal/cmd/apigen.
+//go:generate go run ../../internal/cmd/apigen
@@ -0,0 +1,375 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package moov
+
+import (
+	"context"
+	"io"
+	"iter"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+// API is every call the Client makes to Moov. Depend on it, or on one of the smaller
+// per-domain interfaces, to substitute a fake such as moovmock.Client in tests.
+type API interface {
+	AccountsAPI
+	ApplePayAPI
+	ApplicationsAPI
+	AuthAPI
+	BankAccountsAPI
+	BillingAPI
+	CapabilitiesAPI
+	CardIssuingAPI
+	CardsAPI
+	DisputesAPI
+	FilesAPI
+	ImagesAPI
+	InvoicesAPI
+	PaymentMethodsAPI
+	ProductsAPI
+	ReceiptsAPI
+	RepresentativesAPI
+	ResolutionLinksAPI
+	SchedulesAPI
+	SupportAPI
+	SweepsAPI
+	TerminalApplicationsAPI
+	TransfersAPI
+	UnderwritingAPI
+	WalletsAPI
+	WebhooksAPI
+}
+
+// AccountsAPI is implemented by *Client.
+type AccountsAPI interface {
+	CreateAccount(ctx context.Context, account CreateAccount) (*Account, *Account, error)
+	CreateAccountOperation(ctx context.Context, account CreateAccount) (*Operation[Account], error)
+	GetAccount(ctx context.Context, accountID string) (*Account, error)
+	UpdateAccount(ctx context.Context, account Account) (*Account, error)
+	PatchAccount(ctx context.Context, accountID string, account PatchAccount) (*Account, error)
+	ListAccounts(ctx context.Context, opts ...ListAccountFilter) ([]Account, error)
+	AllAccounts(ctx context.Context, opts ...ListAccountFilter) iter.Seq2[Account, error]
+	DisconnectAccount(ctx context.Context, accountID string) error
+	UploadAvatar(ctx context.Context, accountID string, file io.Reader) error
+	DeleteAvatar(ctx context.Context, accountID string) error
+	CreateAccountBranding(ctx context.Context, accountID string, colorBrand Brand) (*Brand, error)
+	GetAccountBranding(ctx context.Context, accountID string) (*Brand, error)
+	UpsertAccountBranding(ctx context.Context, accountID string, colorBrand Brand) (*Brand, error)
+	ShareConnection(ctx context.Context, subjectAccountID string, connection ShareConnectionRequest) (*ShareConnectionResponse, error)
+	ListIndustries(ctx context.Context) (*Industries, error)
+	EnrichBusinessProfile(ctx context.Context, email string) (*EnrichedBusinessProfile, error)
+}
+
+// ApplePayAPI is implemented by *Client.
+type ApplePayAPI interface {
+	CreateApplePayDomain(ctx context.Context, accountID string, domain ApplePayDomains) (*ApplePayDomainsResponse, error)
+	UpdateApplePayDomain(ctx context.Context, accountID string, patch PatchApplyPayDomains) error
+	GetApplePayDomain(ctx context.Context, accountID string) (*ApplePayDomainsResponse, error)
+	StartApplePaySession(ctx context.Context, accountID string, req StartApplePaySession) (*string, error)
+	LinkApplePayToken(ctx context.Context, accountID string, req LinkApplePay) (*LinkedApplePayPaymentMethod, error)
+	LinkApplePayTokenWithDetails(ctx context.Context, accountID string, req LinkApplePay) (*LinkApplePayTokenResponse, error)
+}
+
+// ApplicationsAPI is implemented by *Client.
+type ApplicationsAPI interface {
+	ListApplications(ctx context.Context) ([]Application, error)
+	CreateApplicationKeys(ctx context.Context, applicationID string, key CreateApplicationKey) (*ApplicationKeyWithSecret, error)
+}
+
+// AuthAPI is implemented by *Client.
+type AuthAPI interface {
+	Ping(ctx context.Context) error
+	RefreshAccessToken(ctx context.Context, refreshToken string) (*AccessTokenResponse, error)
+	RevokeAccessToken(ctx context.Context, token string) error
+	PingAccessToken(ctx context.Context) (*AccessTokenResponse, error)
+	AccountCreationToken(ctx context.Context) (*AccessTokenResponse, error)
+	AccessToken(ctx context.Context, scopes ...ScopeBuilder) (*AccessTokenResponse, error)
+}
+
+// BankAccountsAPI is implemented by *Client.
+type BankAccountsAPI interface {
+	CreateBankAccount(ctx context.Context, accountID string, opts ...CreateBankAccountType) (*BankAccount, error)
+	GetBankAccount(ctx context.Context, accountID string, bankAccountID string) (*BankAccount, error)
+	DeleteBankAccount(ctx context.Context, accountID string, bankAccountID string) error
+	ListBankAccounts(ctx context.Context, accountID string) ([]BankAccount, error)
+	MicroDepositInitiate(ctx context.Context, accountID string, bankAccountID string) error
+	MicroDepositConfirm(ctx context.Context, accountID string, bankAccountID string, amounts []int) error
+	InstantVerificationInitiate(ctx context.Context, accountID string, bankAccountID string) error
+	GetInstantBankAccountVerification(ctx context.Context, accountID string, bankAccountID string) (*BankAccountVerification, error)
+	InstantVerificationComplete(ctx context.Context, accountID string, bankAccountID string, code string) error
+	SearchInstitutions(ctx context.Context, opts ...ListInstitutionsFilter) (*InstitutionsSearchResponse, error)
+}
+
+// BillingAPI is implemented by *Client.
+type BillingAPI interface {
+	ListFeePlanAgreements(ctx context.Context, accountID string, filters ...FeePlanAgreementListFilter) ([]FeePlanAgreement, error)
+	AllFeePlanAgreements(ctx context.Context, accountID string, filters ...FeePlanAgreementListFilter) iter.Seq2[FeePlanAgreement, error]
+	ListFeePlans(ctx context.Context, accountID string, filters ...FeePlanListFilter) ([]FeePlan, error)
+	CreateFeePlanAgreement(ctx context.Context, accountID string, request FeePlanAgreementRequest) (*FeePlanAgreement, error)
+	ListResiduals(ctx context.Context, accountID string, filters ...ResidualListFilter) ([]Residual, error)
+	AllResiduals(ctx context.Context, accountID string, filters ...ResidualListFilter) iter.Seq2[Residual, error]
+	GetResidual(ctx context.Context, accountID string, residualID string) (*Residual, error)
+	ListResidualFees(ctx context.Context, accountID string, residualID string, filters ...ResidualFeeListFilter) ([]IncurredFee, error)
+	AllResidualFees(ctx context.Context, accountID string, residualID string, filters ...ResidualFeeListFilter) iter.Seq2[IncurredFee, error]
+	ListPartnerPricingAgreements(ctx context.Context, accountID string, filters ...PartnerPricingAgreementListFilter) ([]PartnerPricingAgreement, error)
+	AllPartnerPricingAgreements(ctx context.Context, accountID string, filters ...PartnerPricingAgreementListFilter) iter.Seq2[PartnerPricingAgreement, error]
+	GetFees(ctx context.Context, accountID string, filters ...FeeGetFilter) ([]IncurredFee, error)
+	ListFees(ctx context.Context, accountID string, request FeeListRequest) ([]IncurredFee, error)
+	ListFeeRevenue(ctx context.Context, accountID string, filters ...FeeRevenueFilter) ([]IncurredFee, error)
+	AllFeeRevenue(ctx context.Context, accountID string, filters ...FeeRevenueFilter) iter.Seq2[IncurredFee, error]
+	GetStatement(ctx context.Context, accountID string, statementID string) (*Statement, error)
+	GetStatementPDF(ctx context.Context, accountID string, statementID string) ([]byte, error)
+	GetStatementPDFTo(ctx context.Context, accountID string, statementID string, w io.Writer) error
+	ListStatements(ctx context.Context, accountID string, filters ...ListStatementFilter) ([]Statement, error)
+	AllStatements(ctx context.Context, accountID string, filters ...ListStatementFilter) iter.Seq2[Statement, error]
+}
+
+// CapabilitiesAPI is implemented by *Client.
+type CapabilitiesAPI interface {
+	RequestCapabilities(ctx context.Context, accountID string, capabilities []CapabilityName) ([]Capability, error)
+	ListCapabilities(ctx context.Context, accountID string) ([]Capability, error)
+	GetCapability(ctx context.Context, accountID string, capability CapabilityName) (*Capability, error)
+	DisableCapability(ctx context.Context, accountID string, capability CapabilityName) error
+}
+
+// CardIssuingAPI is implemented by *Client.
+type CardIssuingAPI interface {
+	CreateIssuedCard(ctx context.Context, accountID string, card CreateIssuedCard) (*IssuedCard, error)
+	ListIssuedCards(ctx context.Context, accountID string, filters ...ListIssuedCardsFilter) ([]IssuedCard, error)
+	AllIssuedCards(ctx context.Context, accountID string, filters ...ListIssuedCardsFilter) iter.Seq2[IssuedCard, error]
+	GetIssuedCard(ctx context.Context, accountID string, cardID string) (*IssuedCard, error)
+	UpdateIssuedCard(ctx context.Context, accountID string, cardID string, update UpdateIssuedCard) (*IssuedCard, error)
+	ListIssuedCardAuthorizations(ctx context.Context, accountID string, filters ...ListIssuedCardAuthorizationsFilter) ([]IssuedCardAuthorization, error)
+	AllIssuedCardAuthorizations(ctx context.Context, accountID string, filters ...ListIssuedCardAuthorizationsFilter) iter.Seq2[IssuedCardAuthorization, error]
+	GetIssuedCardAuthorization(ctx context.Context, accountID string, authorizationID string) (*IssuedCardAuthorization, error)
+	ListIssuedCardAuthorizationEvents(ctx context.Context, accountID string, authorizationID string, filters ...ListIssuedCardAuthorizationEventsFilter) ([]IssuedCardAuthorizationEvent, error)
+	AllIssuedCardAuthorizationEvents(ctx context.Context, accountID string, authorizationID string, filters ...ListIssuedCardAuthorizationEventsFilter) iter.Seq2[IssuedCardAuthorizationEvent, error]
+	ListIssuedCardTransactions(ctx context.Context, accountID string, filters ...ListIssuedCardTransactionsFilter) ([]IssuedCardTransaction, error)
+	AllIssuedCardTransactions(ctx context.Context, accountID string, filters ...ListIssuedCardTransactionsFilter) iter.Seq2[IssuedCardTransaction, error]
+	GetIssuedCardTransaction(ctx context.Context, accountID string, cardTransactionID string) (*IssuedCardTransaction, error)
+}
+
+// CardsAPI is implemented by *Client.
+type CardsAPI interface {
+	GetCardMetadata(ctx context.Context, request CardMetadataRequest) (*CardMetadata, error)
+	CreateCard(ctx context.Context, accountID string, card CreateCard) (*Card, error)
+	ListCards(ctx context.Context, accountID string) ([]Card, error)
+	GetCard(ctx context.Context, accountID string, cardID string) (*Card, error)
+	UpdateCard(ctx context.Context, accountID string, cardID string, opt1 CardUpdateFilter, opts ...CardUpdateFilter) (*Card, error)
+	DisableCard(ctx context.Context, accountID string, cardID string) error
+	GenerateEndToEndPublicKey(ctx context.Context) (*jose.JSONWebKey, error)
+	TestEndToEndToken(ctx context.Context, token string) error
+}
+
+// DisputesAPI is implemented by *Client.
+type DisputesAPI interface {
+	ListDisputes(ctx context.Context, accountID string, filters ...DisputeListFilter) ([]Dispute, error)
+	AllDisputes(ctx context.Context, accountID string, filters ...DisputeListFilter) iter.Seq2[Dispute, error]
+	GetDispute(ctx context.Context, accountID string, disputeID string) (*Dispute, error)
+	AcceptDispute(ctx context.Context, accountID string, disputeID string) (*Dispute, error)
+	UploadDisputeEvidence(ctx context.Context, accountID string, disputeID string, evidenceText DisputesEvidenceText) (*DisputeEvidence, error)
+	DeleteDisputeEvidence(ctx context.Context, accountID string, disputeID string, evidenceID string) error
+	UploadEvidenceFile(ctx context.Context, accountID string, disputeID string, evidenceType EvidenceType, filename string, file io.Reader, mimeType string) (*DisputeEvidenceUpload, error)
+	ListDisputeEvidence(ctx context.Context, accountID string, disputeID string) ([]DisputeEvidence, error)
+	SubmitDisputeEvidence(ctx context.Context, accountID string, disputeID string) (*Dispute, error)
+	UpdateDisputeEvidence(ctx context.Context, accountID string, disputeID string, evidenceID string, evidenceUpdate DisputesEvidenceUpdate) (*DisputeEvidence, error)
+	GetDisputeEvidence(ctx context.Context, accountID string, disputeID string, evidenceID string) (*DisputeEvidence, error)
+}
+
+// FilesAPI is implemented by *Client.
+type FilesAPI interface {
+	UploadFile(ctx context.Context, accountID string, upload UploadFile) (*File, error)
+	ListFiles(ctx context.Context, accountID string) ([]File, error)
+	GetFile(ctx context.Context, accountID string, fileID string) (*File, error)
+}
+
+// ImagesAPI is implemented by *Client.
+type ImagesAPI interface {
+	UploadImage(ctx context.Context, accountID string, file io.Reader, metadata *ImageMetadataRequest) (*ImageMetadata, error)
+	ListImageMetadata(ctx context.Context, accountID string, filters ...ImageListFilter) ([]ImageMetadata, error)
+	AllImageMetadata(ctx context.Context, accountID string, filters ...ImageListFilter) iter.Seq2[ImageMetadata, error]
+	GetImageMetadata(ctx context.Context, accountID string, imageID string) (*ImageMetadata, error)
+	UpdateImage(ctx context.Context, accountID string, imageID string, file io.Reader, metadata *ImageMetadataRequest) (*ImageMetadata, error)
+	UpdateImageMetadata(ctx context.Context, accountID string, imageID string, metadata ImageMetadataRequest) (*ImageMetadata, error)
+	DeleteImage(ctx context.Context, accountID string, imageID string) error
+}
+
+// InvoicesAPI is implemented by *Client.
+type InvoicesAPI interface {
+	CreateInvoice(ctx context.Context, accountID string, invoice CreateInvoice) (*Invoice, error)
+	GetInvoice(ctx context.Context, accountID string, invoiceID string) (*Invoice, error)
+	UpdateInvoice(ctx context.Context, accountID string, invoiceID string, invoice UpdateInvoice) (*Invoice, error)
+	CreateInvoicePayment(ctx context.Context, accountID string, invoiceID string, payment CreateInvoicePayment) (*InvoicePayment, error)
+	ListInvoices(ctx context.Context, accountID string, filters ...ListInvoiceFilter) ([]Invoice, error)
+	AllInvoices(ctx context.Context, accountID string, filters ...ListInvoiceFilter) iter.Seq2[Invoice, error]
+	DeleteInvoice(ctx context.Context, accountID string, invoiceID string) error
+	ListInvoicePayments(ctx context.Context, accountID string, invoiceID string) ([]InvoicePayment, error)
+}
+
+// PaymentMethodsAPI is implemented by *Client.
+type PaymentMethodsAPI interface {
+	ListPaymentMethods(ctx context.Context, accountID string, opts ...PaymentMethodListFilter) ([]PaymentMethod, error)
+	GetPaymentMethod(ctx context.Context, accountID string, paymentMethodID string) (*PaymentMethod, error)
+}
+
+// ProductsAPI is implemented by *Client.
+type ProductsAPI interface {
+	CreateProduct(ctx context.Context, accountID string, product ProductRequest) (*Product, error)
+	ListProducts(ctx context.Context, accountID string, filters ...ProductListFilter) ([]Product, error)
+	AllProducts(ctx context.Context, accountID string, filters ...ProductListFilter) iter.Seq2[Product, error]
+	GetProduct(ctx context.Context, accountID string, productID string) (*Product, error)
+	UpdateProduct(ctx context.Context, accountID string, productID string, product ProductRequest) (*Product, error)
+	DisableProduct(ctx context.Context, accountID string, productID string) error
+}
+
+// ReceiptsAPI is implemented by *Client.
+type ReceiptsAPI interface {
+	CreateReceipt(ctx context.Context, receipts ...CreateReceipt) ([]Receipt, error)
+	CreateReceipts(ctx context.Context, receipts []CreateReceipt, options ...CreateReceiptArgs) ([]Receipt, error)
+	ListReceipts(ctx context.Context, filters ...ListReceiptsFilter) ([]Receipt, error)
+	DeleteReceipt(ctx context.Context, receiptID string) error
+}
+
+// RepresentativesAPI is implemented by *Client.
+type RepresentativesAPI interface {
+	CreateRepresentative(ctx context.Context, accountID string, representative CreateRepresentative) (*Representative, error)
+	ListRepresentatives(ctx context.Context, accountID string) ([]Representative, error)
+	GetRepresentative(ctx context.Context, accountID string, representativeID string) (*Representative, error)
+	UpdateRepresentative(ctx context.Context, representativeAccountID string, representativeID string, representative UpdateRepresentative) (*Representative, error)
+	DeleteRepresentative(ctx context.Context, accountID string, representativeAccountID string) error
+}
+
+// ResolutionLinksAPI is implemented by *Client.
+type ResolutionLinksAPI interface {
+	CreateResolutionLink(ctx context.Context, accountID string, resolutionLink CreateResolutionLinkRequest) (*ResolutionLinkResponse, error)
+	GetResolutionLink(ctx context.Context, accountID string, resolutionLinkCode string) (*ResolutionLinkResponse, error)
+	ListResolutionLinks(ctx context.Context, accountID string) ([]ResolutionLinkResponse, error)
+	DeleteResolutionLink(ctx context.Context, accountID string, resolutionLinkCode string) error
+}
+
+// SchedulesAPI is implemented by *Client.
+type SchedulesAPI interface {
+	CreateSchedule(ctx context.Context, accountID string, schedule CreateSchedule) (*Schedule, error)
+	GetSchedule(ctx context.Context, accountID string, scheduleID string) (*Schedule, error)
+	UpdateSchedule(ctx context.Context, accountID string, scheduleID string, schedule UpdateSchedule) (*Schedule, error)
+	CancelSchedule(ctx context.Context, accountID string, scheduleID string) error
+}
+
+// SupportAPI is implemented by *Client.
+type SupportAPI interface {
+	CreateTicket(ctx context.Context, accountID string, create CreateTicket) (*Ticket, error)
+	ListTickets(ctx context.Context, accountID string, filters ...ListTicketFilter) (*ListTicket, error)
+	AllTickets(ctx context.Context, accountID string, filters ...ListTicketFilter) iter.Seq2[Ticket, error]
+	ListTicketMessages(ctx context.Context, accountID string, ticketID string) ([]TicketMessage, error)
+	GetTicket(ctx context.Context, accountID string, ticketID string) (*Ticket, error)
+	UpdateTicket(ctx context.Context, accountID string, ticketID string, update UpdateTicket) (*Ticket, error)
+}
+
+// SweepsAPI is implemented by *Client.
+type SweepsAPI interface {
+	ListSweepConfigs(ctx context.Context, accountID string) ([]SweepConfig, error)
+	GetSweepConfig(ctx context.Context, accountID string, sweepConfigID string) (*SweepConfig, error)
+	CreateSweepConfig(ctx context.Context, create CreateSweepConfig) (*SweepConfig, error)
+	UpdateSweepConfig(ctx context.Context, update UpdateSweepConfig) (*SweepConfig, error)
+	ListSweeps(ctx context.Context, accountID string, walletID string, filters ...ListSweepsFilter) ([]Sweep, error)
+	AllSweeps(ctx context.Context, accountID string, walletID string, filters ...ListSweepsFilter) iter.Seq2[Sweep, error]
+	GetSweep(ctx context.Context, accountID string, walletID string, sweepID string) (*Sweep, error)
+}
+
+// TerminalApplicationsAPI is implemented by *Client.
+type TerminalApplicationsAPI interface {
+	LinkAccountTerminalApplication(ctx context.Context, accountID string, terminalApplicationID string) (*AccountTerminalApplication, error)
+	GetAccountTerminalApplication(ctx context.Context, accountID string, terminalApplicationID string) (*AccountTerminalApplication, error)
+	ListAccountTerminalApplications(ctx context.Context, accountID string) ([]AccountTerminalApplication, error)
+	GetAccountTerminalApplicationConfiguration(ctx context.Context, accountID string, terminalApplicationID string) (*AccountTerminalApplicationConfiguration, error)
+	CreateTerminalApplication(ctx context.Context, terminalApplication TerminalApplicationRequest) (*TerminalApplication, error)
+	GetTerminalApplication(ctx context.Context, terminalApplicationID string) (*TerminalApplication, error)
+	ListTerminalApplications(ctx context.Context) ([]TerminalApplication, error)
+	DeleteTerminalApplication(ctx context.Context, terminalApplicationID string) error
+	CreateTerminalApplicationVersion(ctx context.Context, terminalApplicationID string, version string) (*TerminalApplicationVersion, error)
+}
+
+// TransfersAPI is implemented by *Client.
+type TransfersAPI interface {
+	GetTransferRiskOutcomes(ctx context.Context, transferID string) (*TransferRiskOutcomes, error)
+	CreateTransfer(ctx context.Context, partnerAccountID string, transfer CreateTransfer, options ...CreateTransferArgs) CreateTransferBuilder
+	ListTransfers(ctx context.Context, accountID string, filters ...ListTransferFilter) ([]Transfer, error)
+	AllTransfers(ctx context.Context, accountID string, filters ...ListTransferFilter) iter.Seq2[Transfer, error]
+	GetTransfer(ctx context.Context, accountID string, transferID string) (*Transfer, error)
+	PatchTransfer(ctx context.Context, accountID string, transferID string, patches ...TransferPatcher) (*Transfer, error)
+	RefundTransfer(ctx context.Context, partnerAccountID string, transferID string, refund CreateRefund, options ...CreateRefundArgs) (*Refund, *RefundStarted, error)
+	RefundTransferOperation(ctx context.Context, partnerAccountID string, transferID string, refund CreateRefund, options ...CreateRefundArgs) (*Operation[Refund], error)
+	ListRefunds(ctx context.Context, accountID string, transferID string) ([]Refund, error)
+	GetRefund(ctx context.Context, accountID string, transferID string, refundID string) (*Refund, error)
+	ReverseTransfer(ctx context.Context, partnerAccountID string, transferID string, refund CreateReversal, options ...CreateReversalArgs) (*CreatedReversal, error)
+	CancelTransfer(ctx context.Context, accountID string, transferID string) (*Cancellation, error)
+	GetCancellation(ctx context.Context, accountID string, transferID string, cancellationID string) (*Cancellation, error)
+	ListCancellations(ctx context.Context, accountID string, transferID string) ([]Cancellation, error)
+	TransferOptions(ctx context.Context, partnerAccountID string, payload CreateTransferOptions) (*TransferOptions, error)
+	CreateTransferConfig(ctx context.Context, accountID string, config UpsertTransferConfig) (*TransferConfig, error)
+	GetTransferConfig(ctx context.Context, accountID string) (*TransferConfig, error)
+	UpdateTransferConfig(ctx context.Context, accountID string, config UpsertTransferConfig) (*TransferConfig, error)
+}
+
+// UnderwritingAPI is implemented by *Client.
+type UnderwritingAPI interface {
+	UpsertUnderwriting(ctx context.Context, accountID string, underwriting UpdateUnderwriting) (*Underwriting, error)
+	GetUnderwriting(ctx context.Context, accountID string) (*Underwriting, error)
+}
+
+// WalletsAPI is implemented by *Client.
+type WalletsAPI interface {
+	ListWallets(ctx context.Context, accountID string, filters ...ListWalletFilter) ([]Wallet, error)
+	AllWallets(ctx context.Context, accountID string, filters ...ListWalletFilter) iter.Seq2[Wallet, error]
+	GetWallet(ctx context.Context, accountID string, walletID string) (*Wallet, error)
+	CreateWallet(ctx context.Context, accountID string, create CreateWallet) (*Wallet, error)
+	UpdateWallet(ctx context.Context, accountID string, walletID string, update UpdateWallet) (*Wallet, error)
+	ListWalletTransactions(ctx context.Context, accountID string, walletID string, opts ...ListTransactionFilter) ([]WalletTransaction, error)
+	AllWalletTransactions(ctx context.Context, accountID string, walletID string, opts ...ListTransactionFilter) iter.Seq2[WalletTransaction, error]
+	GetWalletTransaction(ctx context.Context, accountID string, walletID string, transactionID string) (*WalletTransaction, error)
+}
+
+// WebhooksAPI is implemented by *Client.
+type WebhooksAPI interface {
+	CreateWebhook(ctx context.Context, webhook CreateWebhook) (*Webhook, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	GetWebhook(ctx context.Context, webhookID string) (*Webhook, error)
+	UpdateWebhook(ctx context.Context, webhookID string, webhook UpdateWebhook) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	PingWebhook(ctx context.Context, webhookID string) (*WebhookPing, error)
+	GetWebhookSecret(ctx context.Context, webhookID string) (*WebhookSecret, error)
+	ListWebhookEventTypes(ctx context.Context) ([]WebhookEventType, error)
+	NewWebhookSecretRotation(ctx context.Context, webhookID string, grace time.Duration) (*WebhookSecretRotation, error)
+}
+
+var (
+	_ API                     = (*Client)(nil)
+	_ AccountsAPI             = (*Client)(nil)
+	_ ApplePayAPI             = (*Client)(nil)
+	_ ApplicationsAPI         = (*Client)(nil)
+	_ AuthAPI                 = (*Client)(nil)
+	_ BankAccountsAPI         = (*Client)(nil)
+	_ BillingAPI              = (*Client)(nil)
+	_ CapabilitiesAPI         = (*Client)(nil)
+	_ CardIssuingAPI          = (*Client)(nil)
+	_ CardsAPI                = (*Client)(nil)
+	_ DisputesAPI             = (*Client)(nil)
+	_ FilesAPI                = (*Client)(nil)
+	_ ImagesAPI               = (*Client)(nil)
+	_ InvoicesAPI             = (*Client)(nil)
+	_ PaymentMethodsAPI       = (*Client)(nil)
+	_ ProductsAPI             = (*Client)(nil)
+	_ ReceiptsAPI             = (*Client)(nil)
+	_ RepresentativesAPI      = (*Client)(nil)
+	_ ResolutionLinksAPI      = (*Client)(nil)
+	_ SchedulesAPI            = (*Client)(nil)
+	_ SupportAPI              = (*Client)(nil)
+	_ SweepsAPI               = (*Client)(nil)
+	_ TerminalApplicationsAPI = (*Client)(nil)
+	_ TransfersAPI            = (*Client)(nil)
+	_ UnderwritingAPI         = (*Client)(nil)
+	_ WalletsAPI              = (*Client)(nil)
+	_ WebhooksAPI             = (*Client)(nil)
+)
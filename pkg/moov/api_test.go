@@ -0,0 +1,27 @@
+package moov
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_CoversClient(t *testing.T) {
+	api := reflect.TypeFor[API]()
+	client := reflect.TypeFor[*Client]()
+
+	for i := range client.NumMethod() {
+		name := client.Method(i).Name
+		switch name {
+		case "CallHttp", "WithBearerToken":
+			continue
+		case "ListSchedule", "GetScheduleOccurrence":
+			// take unexported types, which fakes can't implement
+			continue
+		}
+
+		_, ok := api.MethodByName(name)
+		require.True(t, ok, "Client.%s is missing from API, run go generate in pkg/moov", name)
+	}
+}
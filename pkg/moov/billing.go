@@ -3,6 +3,7 @@ package moov
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 	"time"
@@ -295,6 +296,14 @@ func (c Client) ListFeePlanAgreements(ctx context.Context, accountID string, fil
 	return CompletedListOrError[FeePlanAgreement](resp)
 }
 
+// AllFeePlanAgreements iterates over all fee plan agreements matching the filters, fetching pages of
+// WithFeePlanAgreementCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllFeePlanAgreements(ctx context.Context, accountID string, filters ...FeePlanAgreementListFilter) iter.Seq2[FeePlanAgreement, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]FeePlanAgreement, error) {
+		return c.ListFeePlanAgreements(ctx, accountID, append(filters[:len(filters):len(filters)], WithFeePlanAgreementSkip(skip), WithFeePlanAgreementCount(count))...)
+	})
+}
+
 // ListFeePlans lists available FeePlans for a Moov account
 // https://docs.moov.io/api/moov-accounts/billing/list-plans/
 func (c Client) ListFeePlans(ctx context.Context, accountID string, filters ...FeePlanListFilter) ([]FeePlan, error) {
@@ -338,6 +347,14 @@ func (c Client) ListResiduals(ctx context.Context, accountID string, filters ...
 	return CompletedListOrError[Residual](resp)
 }
 
+// AllResiduals iterates over all residuals matching the filters, fetching pages of
+// WithResidualCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllResiduals(ctx context.Context, accountID string, filters ...ResidualListFilter) iter.Seq2[Residual, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]Residual, error) {
+		return c.ListResiduals(ctx, accountID, append(filters[:len(filters):len(filters)], WithResidualSkip(skip), WithResidualCount(count))...)
+	})
+}
+
 // GetResidual retrieves a specific residual by ID
 // https://docs.moov.io/api/moov-accounts/partner-billing/get-residuals/
 func (c Client) GetResidual(ctx context.Context, accountID, residualID string) (*Residual, error) {
@@ -364,6 +381,14 @@ func (c Client) ListResidualFees(ctx context.Context, accountID, residualID stri
 	return CompletedListOrError[IncurredFee](resp)
 }
 
+// AllResidualFees iterates over all fees of the residual matching the filters, fetching pages of
+// WithResidualFeeCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllResidualFees(ctx context.Context, accountID, residualID string, filters ...ResidualFeeListFilter) iter.Seq2[IncurredFee, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]IncurredFee, error) {
+		return c.ListResidualFees(ctx, accountID, residualID, append(filters[:len(filters):len(filters)], WithResidualFeeSkip(skip), WithResidualFeeCount(count))...)
+	})
+}
+
 // ListPartnerPricingAgreements lists all partner pricing agreements associated with an account
 // https://docs.moov.io/api/moov-accounts/partner-billing/list-partner-agreements/
 func (c Client) ListPartnerPricingAgreements(ctx context.Context, accountID string, filters ...PartnerPricingAgreementListFilter) ([]PartnerPricingAgreement, error) {
@@ -375,3 +400,11 @@ func (c Client) ListPartnerPricingAgreements(ctx context.Context, accountID stri
 
 	return CompletedListOrError[PartnerPricingAgreement](resp)
 }
+
+// AllPartnerPricingAgreements iterates over all partner pricing agreements matching the filters, fetching pages of
+// WithPartnerPricingAgreementCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllPartnerPricingAgreements(ctx context.Context, accountID string, filters ...PartnerPricingAgreementListFilter) iter.Seq2[PartnerPricingAgreement, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]PartnerPricingAgreement, error) {
+		return c.ListPartnerPricingAgreements(ctx, accountID, append(filters[:len(filters):len(filters)], WithPartnerPricingAgreementSkip(skip), WithPartnerPricingAgreementCount(count))...)
+	})
+}
@@ -0,0 +1,374 @@
+package moov
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultResponseCacheSize = 1000
+
+// ResponseCache stores the responses kept by WithResponseCache. Keys start with a hash of the
+// client's credentials and a space, then the path of the request followed by a "?", so a
+// resource's entries can be deleted by prefix.
+type ResponseCache interface {
+	// Get returns the response stored under key, or nil if there is none.
+	Get(ctx context.Context, key string) (*CachedResponse, error)
+
+	// Set stores the response under key, replacing any previous one.
+	Set(ctx context.Context, key string, resp CachedResponse) error
+
+	// DeletePrefix deletes every response whose key starts with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// CachedResponse is a successful response stored in a ResponseCache.
+type CachedResponse struct {
+	StatusCode  int    `json:"statusCode"`
+	ContentType string `json:"contentType,omitempty"`
+	ETag        string `json:"etag,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+
+	// Expires is when the response has to be revalidated, or fetched again if it has no ETag.
+	Expires time.Time `json:"expires"`
+}
+
+// CacheRule selects the calls whose responses are cached and for how long.
+type CacheRule struct {
+	// Match selects the calls cached by the rule. Calls other than GETs are only cached when a
+	// rule matches them, e.g. GetCardMetadata, and their body is part of the cache key.
+	Match func(req *CallRequest) bool
+
+	// TTL is how long a response is used before it's revalidated.
+	TTL time.Duration
+}
+
+// DefaultCacheRules cache the read-mostly endpoints: institutions, card metadata, industries,
+// webhook event types, branding and capabilities.
+var DefaultCacheRules = []CacheRule{
+	{Match: matchEndpoint(http.MethodGet, pathInstitutions), TTL: time.Hour},
+	{Match: matchEndpoint(http.MethodPost, pathCardMetadata), TTL: time.Hour},
+	{Match: matchEndpoint(http.MethodGet, pathIndustries), TTL: 24 * time.Hour},
+	{Match: matchEndpoint(http.MethodGet, pathEventTypes), TTL: 24 * time.Hour},
+	{Match: matchEndpoint(http.MethodGet, pathBrandings), TTL: 5 * time.Minute},
+	{Match: matchEndpoint(http.MethodGet, pathCapabilities, pathCapability), TTL: time.Minute},
+}
+
+// matchEndpoint matches calls made with the method to any of the path formats.
+func matchEndpoint(method string, pathFmts ...string) func(req *CallRequest) bool {
+	return func(req *CallRequest) bool {
+		return req.Method == method && slices.ContainsFunc(pathFmts, func(p string) bool {
+			return req.PathTemplate == strings.ReplaceAll(p, "%s", "{id}")
+		})
+	}
+}
+
+type CacheResult int
+
+const (
+	// The response was served from the cache.
+	CacheHit CacheResult = iota
+	// The response was fetched from Moov.
+	CacheMiss
+	// Moov confirmed the cached response with a 304 Not Modified.
+	CacheRevalidated
+)
+
+func (r CacheResult) String() string {
+	switch r {
+	case CacheHit:
+		return "hit"
+	case CacheMiss:
+		return "miss"
+	case CacheRevalidated:
+		return "revalidated"
+	default:
+		return fmt.Sprintf("CacheResult(%d)", int(r))
+	}
+}
+
+// CacheConfig configures WithResponseCache.
+type CacheConfig struct {
+	// Store keeps the responses. Defaults to an LRUResponseCache of 1000 responses.
+	Store ResponseCache
+
+	// Rules select the cached calls. Defaults to DefaultCacheRules. The first matching rule applies.
+	Rules []CacheRule
+
+	// OnLookup is called for every cached call with how it was answered, e.g. to count hits and misses.
+	OnLookup func(req *CallRequest, result CacheResult)
+}
+
+// WithResponseCache caches the responses of the calls selected by the config's rules. Once a
+// response expires it's revalidated with If-None-Match when Moov returned an ETag for it.
+// Calls other than GETs made by the client invalidate the responses cached for the same
+// resource, the resources under it and the ones above it, e.g. updating a capability
+// invalidates the capabilities of the account.
+//
+// Responses are cached per credentials, and per scopes for clients using WithTokenSource, so
+// clients with different credentials, e.g. the tenants of a ClientPool, may share a Store.
+// Calls made with a bearer token from WithBearerToken are never cached, since the token may
+// see different data than the client's credentials, but its updates invalidate the responses
+// cached for the client.
+func WithResponseCache(config CacheConfig) ClientConfigurable {
+	return func(c *Client) error {
+		if config.Store == nil {
+			config.Store = NewLRUResponseCache(defaultResponseCacheSize)
+		}
+		if config.Rules == nil {
+			config.Rules = DefaultCacheRules
+		}
+		for i, rule := range config.Rules {
+			if rule.Match == nil || rule.TTL <= 0 {
+				return fmt.Errorf("cache rule %d needs a Match and a positive TTL", i)
+			}
+		}
+
+		c.responseCache = &responseCache{config: config, now: time.Now}
+		return nil
+	}
+}
+
+type responseCache struct {
+	config CacheConfig
+	now    func() time.Time
+}
+
+func (rc *responseCache) rule(req *CallRequest) *CacheRule {
+	for i, rule := range rc.config.Rules {
+		if rule.Match(req) {
+			return &rc.config.Rules[i]
+		}
+	}
+	return nil
+}
+
+func (rc *responseCache) report(req *CallRequest, result CacheResult) {
+	if rc.config.OnLookup != nil {
+		rc.config.OnLookup(req, result)
+	}
+}
+
+// cacheMiddleware answers cached calls from the store and invalidates it on mutating calls.
+func (c *Client) cacheMiddleware(next CallHandler) CallHandler {
+	rc := c.responseCache
+	namespace := c.cacheNamespace()
+	return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+		rule := rc.rule(req)
+		if rule == nil {
+			resp, err := next(ctx, req)
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				// Invalidate even when the call failed, it may have changed the resource anyway.
+				_ = rc.invalidate(ctx, namespace, req.Path)
+			}
+			return resp, err
+		}
+		if req.Stream || req.token != nil || c.bearerToken != "" {
+			return next(ctx, req)
+		}
+
+		key := c.responseKey(namespace, req)
+		cached, err := rc.config.Store.Get(ctx, key)
+		if err != nil {
+			cached = nil
+		}
+
+		if cached != nil && rc.now().Before(cached.Expires) {
+			rc.report(req, CacheHit)
+			return cached.response(), nil
+		}
+
+		sent := req
+		if cached != nil && cached.ETag != "" {
+			conditional := *req
+			conditional.Headers = maps.Clone(req.Headers)
+			conditional.Headers["If-None-Match"] = cached.ETag
+			sent = &conditional
+		}
+
+		resp, err := next(ctx, sent)
+		req.Attempts = sent.Attempts
+		if err != nil {
+			return nil, err
+		}
+
+		if sent != req && resp.StatusCode() == http.StatusNotModified {
+			cached.Expires = rc.now().Add(rule.TTL)
+			_ = rc.config.Store.Set(ctx, key, *cached)
+			rc.report(req, CacheRevalidated)
+			return cached.response(), nil
+		}
+
+		rc.report(req, CacheMiss)
+		if r, ok := resp.(*httpCallResponse); ok && r.stream == nil && r.StatusCode() == http.StatusOK {
+			_ = rc.config.Store.Set(ctx, key, CachedResponse{
+				StatusCode:  r.StatusCode(),
+				ContentType: r.Header().Get("Content-Type"),
+				ETag:        r.Header().Get("ETag"),
+				Body:        r.body,
+				Expires:     rc.now().Add(rule.TTL),
+			})
+		}
+		return resp, nil
+	}
+}
+
+// invalidate deletes the responses cached in the namespace for the path, the paths under it and
+// the paths above it.
+func (rc *responseCache) invalidate(ctx context.Context, namespace, p string) error {
+	p = strings.TrimSuffix(p, "/")
+	prefixes := []string{namespace + " " + p + "?", namespace + " " + p + "/"}
+	for parent := path.Dir(p); parent != "/" && parent != "."; parent = path.Dir(parent) {
+		prefixes = append(prefixes, namespace+" "+parent+"?")
+	}
+
+	var errs []error
+	for _, prefix := range prefixes {
+		if err := rc.config.Store.DeletePrefix(ctx, prefix); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cacheKey identifies the response to a call by its path, query, method and version, and by a
+// hash of the body for calls other than GETs.
+func cacheKey(req *CallRequest) string {
+	params := url.Values{}
+	for k, v := range req.Params {
+		params.Set(k, v)
+	}
+
+	key := req.Path + "?" + params.Encode() + " " + req.Method + " " + req.Headers[VersionHeader]
+	if len(req.Body) > 0 {
+		sum := sha256.Sum256(req.Body)
+		key += " " + hex.EncodeToString(sum[:])
+	}
+	return key
+}
+
+// cacheNamespace identifies the credentials responses are cached for by a hash of them. Clones
+// made by WithBearerToken keep the credentials of their client, and with them its namespace.
+func (c *Client) cacheNamespace() string {
+	sum := sha256.Sum256([]byte(c.Credentials.Host + "\n" + c.Credentials.PublicKey + "\n" + c.Credentials.SecretKey))
+	return hex.EncodeToString(sum[:16])
+}
+
+// responseKey identifies the response to a call made with the credentials of the namespace, and
+// with the scopes of the client when it uses WithTokenSource since tokens for fewer scopes may
+// not see every response the credentials can.
+func (c *Client) responseKey(namespace string, req *CallRequest) string {
+	key := namespace + " " + cacheKey(req)
+	if c.tokenSource != nil {
+		scopes, _ := scopeKey(c.tokenScopes...)
+		sum := sha256.Sum256([]byte(scopes))
+		key += " " + hex.EncodeToString(sum[:8])
+	}
+	return key
+}
+
+func (cr *CachedResponse) response() *httpCallResponse {
+	header := http.Header{"Content-Type": []string{cr.ContentType}}
+	if cr.ETag != "" {
+		header.Set("ETag", cr.ETag)
+	}
+	return &httpCallResponse{
+		resp: &http.Response{
+			StatusCode: cr.StatusCode,
+			Header:     header,
+			Body:       io.NopCloser(bytes.NewReader(cr.Body)),
+		},
+		body: cr.Body,
+	}
+}
+
+// LRUResponseCache is a ResponseCache kept in memory, evicting the least recently used
+// responses once it holds its maximum number of responses.
+type LRUResponseCache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key  string
+	resp CachedResponse
+}
+
+// NewLRUResponseCache returns a cache holding up to size responses.
+func NewLRUResponseCache(size int) *LRUResponseCache {
+	return &LRUResponseCache{
+		size:    max(size, 1),
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (c *LRUResponseCache) Get(_ context.Context, key string) (*CachedResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	c.order.MoveToFront(el)
+
+	resp := el.Value.(*lruEntry).resp
+	return &resp, nil
+}
+
+func (c *LRUResponseCache) Set(_ context.Context, key string, resp CachedResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*lruEntry).resp = resp
+		c.order.MoveToFront(el)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, resp: resp})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+func (c *LRUResponseCache) DeletePrefix(_ context.Context, prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, el := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.order.Remove(el)
+			delete(c.entries, key)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of responses in the cache.
+func (c *LRUResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,113 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+		color    = "#000000"
+	)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("If-None-Match"))
+
+		if r.Method == http.MethodPut {
+			color = "#ffffff"
+		}
+
+		etag := `"` + color + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", etag)
+		json.NewEncoder(w).Encode(Brand{Colors: BrandColors{Dark: BrandColor{Accent: color}}})
+	}
+
+	var results []CacheResult
+	c := newLocalTestClient(t, handler, WithResponseCache(CacheConfig{
+		OnLookup: func(_ *CallRequest, result CacheResult) { results = append(results, result) },
+	}))
+	now := time.Now()
+	c.responseCache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	getAccent := func() string {
+		t.Helper()
+		brand, err := c.GetAccountBranding(ctx, "account")
+		require.NoError(t, err)
+		return brand.Colors.Dark.Accent
+	}
+
+	require.Equal(t, "#000000", getAccent())
+	require.Equal(t, "#000000", getAccent())
+	require.Equal(t, []CacheResult{CacheMiss, CacheHit}, results)
+	require.Len(t, requests, 1)
+
+	t.Run("revalidates with the etag once expired", func(t *testing.T) {
+		now = now.Add(10 * time.Minute)
+		require.Equal(t, "#000000", getAccent())
+		require.Equal(t, `GET /accounts/account/branding "#000000"`, requests[len(requests)-1])
+		require.Equal(t, CacheRevalidated, results[len(results)-1])
+
+		require.Equal(t, "#000000", getAccent())
+		require.Equal(t, CacheHit, results[len(results)-1])
+	})
+
+	t.Run("invalidated by updates", func(t *testing.T) {
+		_, err := c.UpsertAccountBranding(ctx, "account", Brand{})
+		require.NoError(t, err)
+
+		require.Equal(t, "#ffffff", getAccent())
+		require.Equal(t, CacheMiss, results[len(results)-1])
+		require.Equal(t, "GET /accounts/account/branding ", requests[len(requests)-1])
+	})
+
+	t.Run("bearer tokens bypass the cache", func(t *testing.T) {
+		before := len(requests)
+		_, err := c.WithBearerToken("token").GetAccountBranding(ctx, "account")
+		require.NoError(t, err)
+		require.Len(t, requests, before+1)
+	})
+
+	t.Run("updates made with a bearer token invalidate the client's responses", func(t *testing.T) {
+		require.Equal(t, "#ffffff", getAccent())
+		require.Equal(t, CacheHit, results[len(results)-1])
+
+		_, err := c.WithBearerToken("token").UpsertAccountBranding(ctx, "account", Brand{})
+		require.NoError(t, err)
+		getAccent()
+		require.Equal(t, CacheMiss, results[len(results)-1])
+	})
+}
+
+func TestLRUResponseCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUResponseCache(2)
+
+	require.NoError(t, cache.Set(ctx, "/a?", CachedResponse{StatusCode: 200}))
+	require.NoError(t, cache.Set(ctx, "/b?", CachedResponse{StatusCode: 200}))
+	_, err := cache.Get(ctx, "/a?")
+	require.NoError(t, err)
+	require.NoError(t, cache.Set(ctx, "/c?", CachedResponse{StatusCode: 200}))
+
+	evicted, err := cache.Get(ctx, "/b?")
+	require.NoError(t, err)
+	require.Nil(t, evicted)
+	require.Equal(t, 2, cache.Len())
+
+	require.NoError(t, cache.DeletePrefix(ctx, "/a?"))
+	require.Equal(t, 1, cache.Len())
+}
@@ -41,14 +41,18 @@ var (
 )
 
 type callBuilder struct {
-	method string
-	path   string
-	params map[string]string
+	method       string
+	path         string
+	pathTemplate string
+	params       map[string]string
 
 	headers map[string]string
 	token   *string
 
-	body io.Reader
+	// body is kept as bytes so the request can be re-sent on retries.
+	body []byte
+
+	stream bool
 }
 
 func newCall(endpoint EndpointArg, args ...callArg) (*callBuilder, error) {
@@ -101,6 +105,7 @@ func Endpoint(method string, pathFmt string, args ...any) EndpointArg {
 	return callBuilderFn(func(call *callBuilder) error {
 		call.method = method
 		call.path = fmt.Sprintf(pathFmt, args...)
+		call.pathTemplate = strings.ReplaceAll(pathFmt, "%s", "{id}")
 
 		return nil
 	})
@@ -114,7 +119,7 @@ func JsonBody(body any) callArg {
 		}
 
 		call.headers["Content-Type"] = "application/json"
-		call.body = bytes.NewBuffer(payload)
+		call.body = payload
 
 		return nil
 	})
@@ -139,7 +144,7 @@ func Header(key, value string) callArg {
 func BytesBody(contentType string, body []byte) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
 		call.headers["Content-Type"] = contentType
-		call.body = bytes.NewReader(body)
+		call.body = body
 		return nil
 	})
 }
@@ -188,7 +193,7 @@ func MultipartBody(parts ...multipartFn) callArg {
 		}
 
 		call.headers["Content-Type"] = w.FormDataContentType()
-		call.body = buf
+		call.body = buf.Bytes()
 
 		return nil
 	})
@@ -217,6 +222,16 @@ func AcceptContentType(contentType string) callArg {
 	})
 }
 
+// StreamResponse decodes a successful response while it's read from the connection instead of
+// buffering the whole body first. Useful for large lists and file downloads. The response has to be
+// unmarshalled, or closed through io.Closer, to release the connection.
+func StreamResponse() callArg {
+	return callBuilderFn(func(call *callBuilder) error {
+		call.stream = true
+		return nil
+	})
+}
+
 func WaitFor(state string) callArg {
 	return callBuilderFn(func(call *callBuilder) error {
 		call.headers["X-Wait-For"] = state
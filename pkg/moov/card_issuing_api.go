@@ -2,6 +2,7 @@ package moov
 
 import (
 	"context"
+	"iter"
 	"net/http"
 )
 
@@ -32,6 +33,14 @@ func (c Client) ListIssuedCards(ctx context.Context, accountID string, filters .
 	return CompletedListOrError[IssuedCard](httpResp)
 }
 
+// AllIssuedCards iterates over all issued cards matching the filters, fetching pages of
+// WithIssuedCardCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllIssuedCards(ctx context.Context, accountID string, filters ...ListIssuedCardsFilter) iter.Seq2[IssuedCard, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]IssuedCard, error) {
+		return c.ListIssuedCards(ctx, accountID, append(filters[:len(filters):len(filters)], WithIssuedCardSkip(skip), WithIssuedCardCount(count))...)
+	})
+}
+
 // GetIssuedCard retrieves the specified issued card for the given account
 // https://docs.moov.io/api/money-movement/issuing/get/
 func (c Client) GetIssuedCard(ctx context.Context, accountID string, cardID string) (*IssuedCard, error) {
@@ -72,6 +81,14 @@ func (c Client) ListIssuedCardAuthorizations(ctx context.Context, accountID stri
 	return CompletedListOrError[IssuedCardAuthorization](httpResp)
 }
 
+// AllIssuedCardAuthorizations iterates over all issued card authorizations matching the filters, fetching pages of
+// WithIssuedCardAuthorizationCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllIssuedCardAuthorizations(ctx context.Context, accountID string, filters ...ListIssuedCardAuthorizationsFilter) iter.Seq2[IssuedCardAuthorization, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]IssuedCardAuthorization, error) {
+		return c.ListIssuedCardAuthorizations(ctx, accountID, append(filters[:len(filters):len(filters)], WithIssuedCardAuthorizationSkip(skip), WithIssuedCardAuthorizationCount(count))...)
+	})
+}
+
 // GetIssuedCardAuthorization retrieves the details of an issued card authorization for the given account
 // https://docs.moov.io/api/money-movement/issuing/get-authorization/
 func (c Client) GetIssuedCardAuthorization(ctx context.Context, accountID string, authorizationID string) (*IssuedCardAuthorization, error) {
@@ -98,6 +115,14 @@ func (c Client) ListIssuedCardAuthorizationEvents(ctx context.Context, accountID
 	return CompletedListOrError[IssuedCardAuthorizationEvent](httpResp)
 }
 
+// AllIssuedCardAuthorizationEvents iterates over all events of the issued card authorization matching the filters, fetching pages of
+// WithIssuedCardAuthorizationEventCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllIssuedCardAuthorizationEvents(ctx context.Context, accountID string, authorizationID string, filters ...ListIssuedCardAuthorizationEventsFilter) iter.Seq2[IssuedCardAuthorizationEvent, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]IssuedCardAuthorizationEvent, error) {
+		return c.ListIssuedCardAuthorizationEvents(ctx, accountID, authorizationID, append(filters[:len(filters):len(filters)], WithIssuedCardAuthorizationEventSkip(skip), WithIssuedCardAuthorizationEventCount(count))...)
+	})
+}
+
 // ListIssuedCardAuthorizations retrieves all issued card transactions for the given account
 // https://docs.moov.io/api/money-movement/issuing/list-card-transactions/
 func (c Client) ListIssuedCardTransactions(ctx context.Context, accountID string, filters ...ListIssuedCardTransactionsFilter) ([]IssuedCardTransaction, error) {
@@ -111,6 +136,14 @@ func (c Client) ListIssuedCardTransactions(ctx context.Context, accountID string
 	return CompletedListOrError[IssuedCardTransaction](httpResp)
 }
 
+// AllIssuedCardTransactions iterates over all issued card transactions matching the filters, fetching pages of
+// WithIssuedCardTransactionCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllIssuedCardTransactions(ctx context.Context, accountID string, filters ...ListIssuedCardTransactionsFilter) iter.Seq2[IssuedCardTransaction, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]IssuedCardTransaction, error) {
+		return c.ListIssuedCardTransactions(ctx, accountID, append(filters[:len(filters):len(filters)], WithIssuedCardTransactionSkip(skip), WithIssuedCardTransactionCount(count))...)
+	})
+}
+
 // GetIssuedCardAuthorization retrieves the details of an issued card transaction for the given account
 // https://docs.moov.io/api/money-movement/issuing/get-card-transaction/
 func (c Client) GetIssuedCardTransaction(ctx context.Context, accountID string, cardTransactionID string) (*IssuedCardTransaction, error) {
@@ -0,0 +1,270 @@
+package moov
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched with errors.Is by calls refused because the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned instead of calling Moov while the circuit of an endpoint group is open.
+type CircuitOpenError struct {
+	Group string
+	// When the circuit lets a trial request through again.
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s for %q until %s", ErrCircuitOpen, e.Group, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type CircuitState int
+
+const (
+	// Calls go through and failures are counted.
+	CircuitClosed CircuitState = iota
+	// Calls fail fast with a CircuitOpenError.
+	CircuitOpen
+	// A limited number of trial calls go through to decide if the circuit closes or opens again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+const (
+	defaultCircuitFailureRatio     = 0.5
+	defaultCircuitMinRequests      = 10
+	defaultCircuitWindow           = 30 * time.Second
+	defaultCircuitOpenTimeout      = 30 * time.Second
+	defaultCircuitHalfOpenRequests = 1
+)
+
+// CircuitBreakerConfig configures when the circuit of an endpoint group opens and closes again.
+// Server errors and network errors count as failures.
+type CircuitBreakerConfig struct {
+	// Group names the endpoint group a request belongs to, each group has its own circuit.
+	// Defaults to a single circuit for every call.
+	Group func(req *CallRequest) string
+
+	// Ratio of failed calls within the window that opens the circuit. Defaults to 0.5.
+	FailureRatio float64
+
+	// Calls needed within the window before the failure ratio is considered. Defaults to 10.
+	MinRequests int
+
+	// Length of the window failures are counted in. Defaults to 30s.
+	Window time.Duration
+
+	// How long the circuit stays open before letting trial calls through. Defaults to 30s.
+	OpenTimeout time.Duration
+
+	// Number of trial calls let through while half-open, all of which must succeed for the
+	// circuit to close. Defaults to 1.
+	HalfOpenRequests int
+
+	// OnStateChange is called every time the circuit of a group changes state.
+	OnStateChange func(group string, from, to CircuitState)
+}
+
+// WithCircuitBreaker stops calling Moov for an endpoint group after a streak of failures and
+// fails fast with a CircuitOpenError until its trial calls succeed again.
+func WithCircuitBreaker(config CircuitBreakerConfig) ClientConfigurable {
+	return func(c *Client) error {
+		if config.FailureRatio < 0 || config.FailureRatio > 1 {
+			return fmt.Errorf("circuit breaker failure ratio must be between 0 and 1, but was %v", config.FailureRatio)
+		}
+		if config.Group == nil {
+			config.Group = func(*CallRequest) string { return "" }
+		}
+		if config.FailureRatio == 0 {
+			config.FailureRatio = defaultCircuitFailureRatio
+		}
+		if config.MinRequests <= 0 {
+			config.MinRequests = defaultCircuitMinRequests
+		}
+		if config.Window <= 0 {
+			config.Window = defaultCircuitWindow
+		}
+		if config.OpenTimeout <= 0 {
+			config.OpenTimeout = defaultCircuitOpenTimeout
+		}
+		if config.HalfOpenRequests <= 0 {
+			config.HalfOpenRequests = defaultCircuitHalfOpenRequests
+		}
+
+		c.circuitBreaker = &circuitBreaker{
+			config:   config,
+			now:      time.Now,
+			circuits: map[string]*circuit{},
+		}
+		return nil
+	}
+}
+
+type circuitBreaker struct {
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	trials      int
+	successes   int
+}
+
+type callOutcome int
+
+const (
+	outcomeSuccess callOutcome = iota
+	outcomeFailure
+	outcomeIgnored
+)
+
+func (cb *circuitBreaker) middleware(next CallHandler) CallHandler {
+	return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+		group := cb.config.Group(req)
+
+		if err := cb.allow(group); err != nil {
+			return nil, err
+		}
+
+		resp, err := next(ctx, req)
+
+		outcome := outcomeSuccess
+		switch {
+		case err != nil && (ctx.Err() != nil || errors.Is(err, ErrResponseBodyTooLarge)):
+			outcome = outcomeIgnored
+		case err != nil, resp.Status() == StatusServerError:
+			outcome = outcomeFailure
+		}
+		cb.record(group, outcome)
+
+		return resp, err
+	}
+}
+
+// allow returns a CircuitOpenError if the group's circuit doesn't let the call through.
+func (cb *circuitBreaker) allow(group string) error {
+	cb.mu.Lock()
+	now := cb.now()
+
+	c, ok := cb.circuits[group]
+	if !ok {
+		c = &circuit{windowStart: now}
+		cb.circuits[group] = c
+	}
+
+	from := c.state
+	var err error
+
+	switch c.state {
+	case CircuitClosed:
+		if now.Sub(c.windowStart) > cb.config.Window {
+			c.reset(now)
+		}
+	case CircuitOpen:
+		retryAt := c.openedAt.Add(cb.config.OpenTimeout)
+		if now.Before(retryAt) {
+			err = &CircuitOpenError{Group: group, RetryAt: retryAt}
+			break
+		}
+		c.state = CircuitHalfOpen
+		c.trials = 0
+		c.successes = 0
+		fallthrough
+	case CircuitHalfOpen:
+		if c.trials >= cb.config.HalfOpenRequests {
+			err = &CircuitOpenError{Group: group, RetryAt: now}
+			break
+		}
+		c.trials++
+	}
+
+	to := c.state
+	cb.mu.Unlock()
+
+	cb.notify(group, from, to)
+	return err
+}
+
+// record counts the outcome of a call against the group's circuit.
+func (cb *circuitBreaker) record(group string, outcome callOutcome) {
+	cb.mu.Lock()
+	now := cb.now()
+	c := cb.circuits[group]
+	from := c.state
+
+	switch c.state {
+	case CircuitClosed:
+		if outcome == outcomeIgnored {
+			break
+		}
+		c.requests++
+		if outcome == outcomeFailure {
+			c.failures++
+		}
+		if c.requests >= cb.config.MinRequests && float64(c.failures)/float64(c.requests) >= cb.config.FailureRatio {
+			c.state = CircuitOpen
+			c.openedAt = now
+		}
+	case CircuitHalfOpen:
+		switch outcome {
+		case outcomeIgnored:
+			c.trials--
+		case outcomeFailure:
+			c.state = CircuitOpen
+			c.openedAt = now
+		case outcomeSuccess:
+			c.successes++
+			if c.successes >= cb.config.HalfOpenRequests {
+				c.state = CircuitClosed
+				c.reset(now)
+			}
+		}
+	case CircuitOpen:
+		// Calls started before the circuit opened don't change anything.
+	}
+
+	to := c.state
+	cb.mu.Unlock()
+
+	cb.notify(group, from, to)
+}
+
+func (c *circuit) reset(now time.Time) {
+	c.windowStart = now
+	c.requests = 0
+	c.failures = 0
+}
+
+func (cb *circuitBreaker) notify(group string, from, to CircuitState) {
+	if from != to && cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(group, from, to)
+	}
+}
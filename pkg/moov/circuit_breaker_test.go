@@ -0,0 +1,160 @@
+package moov
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	type change struct {
+		group    string
+		from, to CircuitState
+	}
+
+	newClient := func(t *testing.T, failing *atomic.Bool, calls *atomic.Int32, changes *[]change, halfOpenRequests int) (*Client, *circuitBreaker) {
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if failing.Load() && strings.HasPrefix(r.URL.Path, "/transfers") {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}, WithCircuitBreaker(CircuitBreakerConfig{
+			Group: func(req *CallRequest) string {
+				return strings.Split(req.Path, "/")[1]
+			},
+			FailureRatio:     0.5,
+			MinRequests:      4,
+			Window:           time.Minute,
+			OpenTimeout:      10 * time.Second,
+			HalfOpenRequests: halfOpenRequests,
+			OnStateChange: func(group string, from, to CircuitState) {
+				*changes = append(*changes, change{group, from, to})
+			},
+		}))
+		return c, c.circuitBreaker
+	}
+
+	call := func(c *Client, path string) error {
+		resp, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "%s", path))
+		if err != nil {
+			return err
+		}
+		if resp.Status() != StatusCompleted {
+			return resp
+		}
+		return nil
+	}
+
+	t.Run("opens per group and fails fast", func(t *testing.T) {
+		var failing atomic.Bool
+		var calls atomic.Int32
+		var changes []change
+		c, _ := newClient(t, &failing, &calls, &changes, 0)
+
+		failing.Store(true)
+		for range 4 {
+			require.Error(t, call(c, "/transfers"))
+		}
+		require.Equal(t, []change{{"transfers", CircuitClosed, CircuitOpen}}, changes)
+
+		err := call(c, "/transfers")
+		require.ErrorIs(t, err, ErrCircuitOpen)
+
+		var openErr *CircuitOpenError
+		require.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &openErr))
+		require.Equal(t, "transfers", openErr.Group)
+		require.Equal(t, int32(4), calls.Load())
+
+		// other groups are unaffected
+		require.NoError(t, call(c, "/accounts"))
+	})
+
+	t.Run("closes after a successful trial call", func(t *testing.T) {
+		var failing atomic.Bool
+		var calls atomic.Int32
+		var changes []change
+		c, cb := newClient(t, &failing, &calls, &changes, 0)
+
+		now := time.Now()
+		cb.now = func() time.Time { return now }
+
+		failing.Store(true)
+		for range 4 {
+			require.Error(t, call(c, "/transfers"))
+		}
+
+		now = now.Add(11 * time.Second)
+		require.Error(t, call(c, "/transfers"))
+		require.ErrorIs(t, call(c, "/transfers"), ErrCircuitOpen)
+
+		now = now.Add(11 * time.Second)
+		failing.Store(false)
+		require.NoError(t, call(c, "/transfers"))
+		require.NoError(t, call(c, "/transfers"))
+
+		require.Equal(t, []change{
+			{"transfers", CircuitClosed, CircuitOpen},
+			{"transfers", CircuitOpen, CircuitHalfOpen},
+			{"transfers", CircuitHalfOpen, CircuitOpen},
+			{"transfers", CircuitOpen, CircuitHalfOpen},
+			{"transfers", CircuitHalfOpen, CircuitClosed},
+		}, changes)
+	})
+
+	t.Run("closes after every trial call succeeded", func(t *testing.T) {
+		var failing atomic.Bool
+		var calls atomic.Int32
+		var changes []change
+		c, cb := newClient(t, &failing, &calls, &changes, 3)
+
+		now := time.Now()
+		cb.now = func() time.Time { return now }
+
+		failing.Store(true)
+		for range 4 {
+			require.Error(t, call(c, "/transfers"))
+		}
+
+		now = now.Add(11 * time.Second)
+		failing.Store(false)
+		require.NoError(t, call(c, "/transfers"))
+		require.NoError(t, call(c, "/transfers"))
+		require.Equal(t, CircuitHalfOpen, cb.circuits["transfers"].state)
+
+		require.NoError(t, call(c, "/transfers"))
+		require.Equal(t, CircuitClosed, cb.circuits["transfers"].state)
+
+		require.Equal(t, []change{
+			{"transfers", CircuitClosed, CircuitOpen},
+			{"transfers", CircuitOpen, CircuitHalfOpen},
+			{"transfers", CircuitHalfOpen, CircuitClosed},
+		}, changes)
+	})
+
+	t.Run("stays closed below the failure ratio", func(t *testing.T) {
+		var failing atomic.Bool
+		var calls atomic.Int32
+		var changes []change
+		c, _ := newClient(t, &failing, &calls, &changes, 0)
+
+		for i := range 10 {
+			failing.Store(i%4 == 0)
+			call(c, "/transfers")
+		}
+		require.Empty(t, changes)
+	})
+
+	t.Run("rejects invalid failure ratio", func(t *testing.T) {
+		_, err := NewClient(WithCircuitBreaker(CircuitBreakerConfig{FailureRatio: 2}))
+		require.Error(t, err)
+	})
+}
@@ -2,23 +2,50 @@ package moov
 
 import (
 	"cmp"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"os"
+	"sync"
 
 	"golang.org/x/time/rate"
 )
 
 type Client struct {
-	Credentials Credentials
-	HttpClient  *http.Client
-	rateLimiter *rate.Limiter
+	Credentials      Credentials
+	HttpClient       *http.Client
+	rateLimiter      *rate.Limiter
+	rateLimitBuckets []*adaptiveBucket
+	retryPolicy      *RetryPolicy
+	circuitBreaker   *circuitBreaker
+	middleware       []Middleware
+	idempotencyStore IdempotencyStore
+	tenant           string
+	modeCheck        *modeCheck
+	responseCache    *responseCache
+	coalescer        *coalescer
+
+	defaultVersion   *Version
+	endpointVersions []endpointVersion
+	onDeprecation    func(req *CallRequest, notice DeprecationNotice)
+
+	// Shared by copies of the client so each deprecation is logged once.
+	deprecationsLogged *sync.Map
+
+	logger       *slog.Logger
+	bodyLogLevel *slog.Level
+	maskedFields []string
 
 	decoder Decoder
 
 	bearerToken string
+	tokenSource *TokenSource
+	tokenScopes []ScopeBuilder
 
 	moovURLScheme string
+
+	maxResponseBodySize int64
 }
 
 const defaultMoovURLScheme = "https"
@@ -30,6 +57,8 @@ func NewClient(configurables ...ClientConfigurable) (*Client, error) {
 		Credentials:   CredentialsFromEnv(),
 		HttpClient:    DefaultHttpClient(),
 		moovURLScheme: cmp.Or(os.Getenv("MOOV_URL_SCHEME"), defaultMoovURLScheme),
+
+		deprecationsLogged: &sync.Map{},
 	}
 
 	// Apply all the configurable functions to the client
@@ -52,14 +81,9 @@ func NewClient(configurables ...ClientConfigurable) (*Client, error) {
 // revalidates. Intended for pass-through scenarios where a caller-supplied
 // access token should authenticate every call made by this client.
 func (c *Client) WithBearerToken(t string) *Client {
-	return &Client{
-		rateLimiter:   c.rateLimiter,
-		decoder:       c.decoder,
-		moovURLScheme: c.moovURLScheme,
-		Credentials:   c.Credentials,
-		HttpClient:    c.HttpClient,
-		bearerToken:   t,
-	}
+	clone := *c
+	clone.bearerToken = t
+	return &clone
 }
 
 type ClientConfigurable func(c *Client) error
@@ -78,6 +102,18 @@ func WithHttpClient(client *http.Client) ClientConfigurable {
 	}
 }
 
+// WithMaxResponseBodySize limits how many bytes of a response body are read. Larger bodies
+// fail with ErrResponseBodyTooLarge. Zero, the default, reads bodies of any size.
+func WithMaxResponseBodySize(n int64) ClientConfigurable {
+	return func(c *Client) error {
+		if n < 0 {
+			return fmt.Errorf("max response body size must not be negative, but was %d", n)
+		}
+		c.maxResponseBodySize = n
+		return nil
+	}
+}
+
 type Decoder func(r io.Reader, contentType string, item any) error
 
 func WithDecoder(dec Decoder) ClientConfigurable {
@@ -0,0 +1,119 @@
+package moov
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+)
+
+// WithRequestCoalescing sends identical GET calls made while one is already in flight only once,
+// e.g. many goroutines calling GetAccount for the same account at the same time. Calls are
+// identical when they have the same path, query, version, credentials and token scopes. Every
+// caller gets the same response.
+//
+// A caller giving up on its context returns right away without affecting the others. The call
+// to Moov is only canceled once every caller waiting for it gave up.
+func WithRequestCoalescing() ClientConfigurable {
+	return func(c *Client) error {
+		c.coalescer = &coalescer{flights: map[string]*flight{}}
+		return nil
+	}
+}
+
+type coalescer struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a call in progress shared by every caller waiting for it.
+type flight struct {
+	done    chan struct{}
+	waiters int
+	cancel  context.CancelFunc
+
+	resp     HttpCallResponse
+	err      error
+	attempts int
+}
+
+// coalesceMiddleware joins GET calls to an identical call in flight, or starts one for others to join.
+func (c *Client) coalesceMiddleware(next CallHandler) CallHandler {
+	co := c.coalescer
+	return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+		if req.Method != http.MethodGet || req.Stream {
+			return next(ctx, req)
+		}
+		key := c.coalesceKey(req)
+
+		co.mu.Lock()
+		f, ok := co.flights[key]
+		if !ok {
+			// The call outlives the caller starting it if others joined, so it only keeps its values.
+			flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+			f = &flight{done: make(chan struct{}), cancel: cancel}
+			co.flights[key] = f
+
+			sent := *req
+			go func() {
+				defer cancel()
+				f.resp, f.err = next(flightCtx, &sent)
+				f.attempts = sent.Attempts
+
+				co.mu.Lock()
+				co.forget(key, f)
+				co.mu.Unlock()
+				close(f.done)
+			}()
+		}
+		f.waiters++
+		co.mu.Unlock()
+
+		select {
+		case <-f.done:
+			req.Attempts = f.attempts
+			if r, ok := f.resp.(*httpCallResponse); ok {
+				// Every caller gets a response of its own since CallHttp sets the decoder on it.
+				shared := *r
+				return &shared, f.err
+			}
+			return f.resp, f.err
+
+		case <-ctx.Done():
+			co.mu.Lock()
+			f.waiters--
+			if f.waiters == 0 {
+				f.cancel()
+				co.forget(key, f)
+			}
+			co.mu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// forget removes the flight so later calls start a new one. It must be called with the lock held.
+func (co *coalescer) forget(key string, f *flight) {
+	if co.flights[key] == f {
+		delete(co.flights, key)
+	}
+}
+
+// coalesceKey identifies identical calls like the response cache does, by the credentials and
+// scopes they're made with, and also by the bearer token they're made with since it can see
+// different data than the client's credentials.
+func (c *Client) coalesceKey(req *CallRequest) string {
+	key := c.responseKey(c.cacheNamespace(), req)
+
+	token := c.bearerToken
+	if req.token != nil {
+		token = *req.token
+	}
+	if token == "" {
+		return key
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	return key + " " + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,161 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestCoalescing(t *testing.T) {
+	var (
+		calls    atomic.Int32
+		arrived  = make(chan struct{}, 100)
+		canceled = make(chan struct{})
+
+		mu      sync.Mutex
+		release = make(chan struct{})
+	)
+	released := func() chan struct{} {
+		mu.Lock()
+		defer mu.Unlock()
+		return release
+	}
+	reset := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		release = make(chan struct{})
+	}
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		arrived <- struct{}{}
+		select {
+		case <-released():
+		case <-r.Context().Done():
+			close(canceled)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Wallet{WalletID: r.URL.Path})
+	}, WithRequestCoalescing())
+
+	waiting := func(n int) {
+		require.Eventually(t, func() bool {
+			c.coalescer.mu.Lock()
+			defer c.coalescer.mu.Unlock()
+
+			waiters := 0
+			for _, f := range c.coalescer.flights {
+				waiters += f.waiters
+			}
+			return waiters == n
+		}, time.Second, time.Millisecond)
+	}
+
+	t.Run("identical calls are sent once", func(t *testing.T) {
+		ctx := context.Background()
+		first := make(chan *Wallet)
+		go func() {
+			wallet, err := c.GetWallet(ctx, "account", "wallet")
+			require.NoError(t, err)
+			first <- wallet
+		}()
+		<-arrived
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				wallet, err := c.GetWallet(ctx, "account", "wallet")
+				require.NoError(t, err)
+				require.Equal(t, "/accounts/account/wallets/wallet", wallet.WalletID)
+			})
+		}
+
+		// A different wallet, or the same one through a bearer token, is sent separately.
+		other := make(chan struct{})
+		go func() {
+			_, err := c.GetWallet(ctx, "account", "other")
+			require.NoError(t, err)
+			_, err = c.WithBearerToken("token").GetWallet(ctx, "account", "wallet")
+			require.NoError(t, err)
+			close(other)
+		}()
+		<-arrived
+		waiting(12)
+
+		close(released())
+		wg.Wait()
+		<-first
+		<-other
+		<-arrived
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("first caller giving up", func(t *testing.T) {
+		reset()
+		calls.Store(0)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		gaveUp := make(chan error)
+		go func() {
+			_, err := c.GetWallet(ctx, "account", "wallet")
+			gaveUp <- err
+		}()
+		<-arrived
+
+		joined := make(chan error)
+		go func() {
+			_, err := c.GetWallet(context.Background(), "account", "wallet")
+			joined <- err
+		}()
+		waiting(2)
+
+		cancel()
+		require.ErrorIs(t, <-gaveUp, context.Canceled)
+
+		close(released())
+		require.NoError(t, <-joined)
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("canceled once every caller gave up", func(t *testing.T) {
+		reset()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			_, err := c.GetWallet(ctx, "account", "abandoned")
+			done <- err
+		}()
+		<-arrived
+
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+		<-canceled
+	})
+}
+
+func TestCoalesceKey(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {}, WithRequestCoalescing())
+	req := &CallRequest{Method: http.MethodGet, Path: "/accounts/account"}
+
+	other := *c
+	other.Credentials.SecretKey = "other"
+	require.NotEqual(t, c.coalesceKey(req), other.coalesceKey(req))
+
+	ts := NewTokenSource(c)
+	ping := *c
+	require.NoError(t, WithTokenSource(ts, Scopes.Ping())(&ping))
+	accounts := *c
+	require.NoError(t, WithTokenSource(ts, Scopes.AccountsRead())(&accounts))
+	require.NotEqual(t, ping.coalesceKey(req), accounts.coalesceKey(req))
+	require.NotEqual(t, c.coalesceKey(req), ping.coalesceKey(req))
+
+	require.NotEqual(t, c.coalesceKey(req), c.WithBearerToken("token").coalesceKey(req))
+	require.Equal(t, c.coalesceKey(req), c.WithBearerToken("").coalesceKey(req))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"strings"
 	"time"
@@ -178,6 +179,14 @@ func (c Client) ListDisputes(ctx context.Context, accountID string, filters ...D
 	return CompletedListOrError[Dispute](resp)
 }
 
+// AllDisputes iterates over all disputes matching the filters, fetching pages of
+// WithDisputeCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllDisputes(ctx context.Context, accountID string, filters ...DisputeListFilter) iter.Seq2[Dispute, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]Dispute, error) {
+		return c.ListDisputes(ctx, accountID, append(filters[:len(filters):len(filters)], WithDisputeSkip(skip), WithDisputeCount(count))...)
+	})
+}
+
 // GetDispute retrieves a dispute for the given dispute id
 // https://docs.moov.io/api/money-movement/disputes/get/
 func (c Client) GetDispute(ctx context.Context, accountID string, disputeID string) (*Dispute, error) {
@@ -1,7 +1,12 @@
 package moov
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -35,6 +40,17 @@ var (
 	ErrMicroDepositAmountsIncorrect = errors.New("the amounts provided are incorrect or the bank account is in an unexpected state")
 	ErrInstantVerificationFailed    = errors.New("attempted verification failed")
 	ErrXIdempotencyKey              = errors.New("attempted to create a transfer using a duplicate X-Idempotency-Key header")
+	ErrResponseBodyTooLarge         = errors.New("response body is larger than the configured maximum")
+
+	// Matched with errors.Is against any error response from Moov based on its CallStatus.
+	ErrBadRequest       = errors.New("the request could not be processed")
+	ErrConflict         = errors.New("the request conflicts with the current state of the resource")
+	ErrFailedValidation = errors.New("the request failed validation")
+	ErrNotFound         = ErrResourceNotFound // the same sentinel, so errors.Is matches either name
+	ErrUnauthenticated  = errors.New("credentials are missing, invalid or expired")
+	ErrUnauthorized     = errors.New("credentials are not allowed to make the request")
+	ErrRateLimited      = errors.New("request was refused due to rate limiting")
+	ErrServerError      = errors.New("moov encountered a server error")
 
 	// ErrDuplicateBankAccount = errors.New("duplciate bank account or invalid routing number")
 	// ErrNoMicroDeposit       = errors.New("no account with the specified accountID was found or micro-deposits have not been sent for the source")
@@ -44,17 +60,121 @@ var (
 	// ErrCardDataInvalid      = errors.New("the supplied card data appeared invalid or was declined by the issuer")
 	// ErrRequestBody              = errors.New("request body could not be parsed")
 	// ErrAuthNetwork              = errors.New("network error")
-	// ErrBadRequest               = errors.New("the request body could not be processed")
 	// ErrInvalidBankAccount       = errors.New("the bank account is not a bank account or is already pending verification")
 	// ErrDuplicatedApplePayDomain = errors.New("apple pay domains already registered for this account")
 	// ErrDomainsNotVerified       = errors.New("domains not verified with Apple")
 	// ErrDomainsNotRegistered     = errors.New("no apple pay domains registered for this account were found")
 	// ErrLinkingApplePayToken     = errors.New("an error occurred when linking an apple pay token")
-	// ErrRateLimit                = errors.New("request was refused due to rate limiting")
 	// ErrURL                      = errors.New("invalid url")
 	// ErrNoCardUpdateFilters = errors.New("no card update filters provided")
 )
 
+// statusErrors maps each error CallStatus to the sentinel it matches with errors.Is.
+var statusErrors = map[CallStatus]error{
+	StatusBadRequest:       ErrBadRequest,
+	StatusStateConflict:    ErrConflict,
+	StatusFailedValidation: ErrFailedValidation,
+	StatusNotFound:         ErrNotFound,
+	StatusUnauthenticated:  ErrUnauthenticated,
+	StatusUnauthorized:     ErrUnauthorized,
+	StatusRateLimited:      ErrRateLimited,
+	StatusServerError:      ErrServerError,
+}
+
+// Error is an error response from Moov. Retrieve it from any error returned by the client with errors.As:
+//
+//	var moovErr *moov.Error
+//	if errors.As(err, &moovErr) {
+//		fmt.Println(moovErr.Fields["profile.business.taxID.ein.number"])
+//	}
+//
+// It also matches sentinels like ErrNotFound or ErrRateLimited with errors.Is.
+type Error struct {
+	Status    CallStatus
+	HTTPCode  int
+	RequestID string
+
+	// Top-level error message returned by Moov, if any.
+	Message string
+
+	// Field-level validation errors flattened into dotted paths, e.g.
+	//  {"profile":{"business":{"taxID":{"ein":{"number":"must be valid"}}}}}
+	// becomes
+	//  profile.business.taxID.ein.number: must be valid
+	Fields map[string]string
+}
+
+func (e *Error) Error() string {
+	out := fmt.Sprintf("error from moov - status: %s http.request_id: %s http.status_code: %d", e.Status.Name, e.RequestID, e.HTTPCode)
+
+	if e.Message != "" {
+		out = fmt.Sprintf("%s\n  %s", out, e.Message)
+	}
+
+	if len(e.Fields) > 0 {
+		lines := []string{}
+		for _, path := range slices.Sorted(maps.Keys(e.Fields)) {
+			lines = append(lines, fmt.Sprintf("%s: %s", path, e.Fields[path]))
+		}
+		out = fmt.Sprintf("%s - %s", out, strings.Join(lines, "\n"))
+	}
+
+	return out
+}
+
+// Is matches the sentinel error for the status of the response.
+func (e *Error) Is(target error) bool {
+	sentinel, ok := statusErrors[e.Status]
+	return ok && sentinel == target
+}
+
+// parseErrorBody reads the top-level message and the flattened field errors out of a JSON error body.
+func parseErrorBody(body []byte) (string, map[string]string) {
+	var doc map[string]any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return "", nil
+	}
+
+	message := ""
+	if msg, ok := doc["error"].(string); ok {
+		message = msg
+		delete(doc, "error")
+	}
+
+	fields := map[string]string{}
+	flattenFields(fields, "", doc)
+	if len(fields) == 0 {
+		fields = nil
+	}
+
+	return message, fields
+}
+
+func flattenFields(out map[string]string, path string, v any) {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			flattenFields(out, join(k), child)
+		}
+	case []any:
+		for i, child := range t {
+			flattenFields(out, join(strconv.Itoa(i)), child)
+		}
+	case string:
+		out[path] = t
+	case nil:
+	default:
+		out[path] = fmt.Sprint(t)
+	}
+}
+
 func DebugPrintResponse(err error, f func(format string, a ...any) (n int, err error)) {
 	if e := ErrorAsCallResponse(err); e != nil {
 		sb := strings.Builder{}
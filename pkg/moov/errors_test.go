@@ -0,0 +1,94 @@
+package moov
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	t.Run("flattens nested validation errors", func(t *testing.T) {
+		resp := &httpCallResponse{
+			resp: &http.Response{
+				StatusCode: http.StatusUnprocessableEntity,
+				Header:     http.Header{"X-Request-Id": []string{"req-1"}},
+			},
+			body: []byte(`{"profile":{"business":{"taxID":{"ein":{"number":"must be a valid employer identification number"}},"website":"must be a valid URL"}}}`),
+		}
+
+		var moovErr *Error
+		require.True(t, errors.As(fmt.Errorf("wrapped: %w", resp), &moovErr))
+		require.Equal(t, StatusFailedValidation, moovErr.Status)
+		require.Equal(t, http.StatusUnprocessableEntity, moovErr.HTTPCode)
+		require.Equal(t, "req-1", moovErr.RequestID)
+		require.Empty(t, moovErr.Message)
+		require.Equal(t, map[string]string{
+			"profile.business.taxID.ein.number": "must be a valid employer identification number",
+			"profile.business.website":          "must be a valid URL",
+		}, moovErr.Fields)
+
+		require.ErrorIs(t, resp, ErrFailedValidation)
+		require.NotErrorIs(t, resp, ErrNotFound)
+	})
+
+	t.Run("reads the top-level message", func(t *testing.T) {
+		resp := &httpCallResponse{
+			resp: &http.Response{StatusCode: http.StatusConflict},
+			body: []byte(`{"error":"duplicate bank account"}`),
+		}
+
+		var moovErr *Error
+		require.ErrorAs(t, resp, &moovErr)
+		require.Equal(t, "duplicate bank account", moovErr.Message)
+		require.Nil(t, moovErr.Fields)
+		require.ErrorIs(t, moovErr, ErrConflict)
+	})
+
+	t.Run("matches through errors.Join", func(t *testing.T) {
+		resp := &httpCallResponse{
+			resp: &http.Response{StatusCode: http.StatusConflict},
+		}
+		err := errors.Join(ErrXIdempotencyKey, resp)
+
+		require.ErrorIs(t, err, ErrXIdempotencyKey)
+		require.ErrorIs(t, err, ErrConflict)
+
+		var moovErr *Error
+		require.ErrorAs(t, err, &moovErr)
+		require.Equal(t, StatusStateConflict, moovErr.Status)
+	})
+
+	t.Run("sentinels for each status", func(t *testing.T) {
+		for code, sentinel := range map[int]error{
+			http.StatusBadRequest:          ErrBadRequest,
+			http.StatusNotFound:            ErrNotFound,
+			http.StatusUnauthorized:        ErrUnauthenticated,
+			http.StatusForbidden:           ErrUnauthorized,
+			http.StatusTooManyRequests:     ErrRateLimited,
+			http.StatusInternalServerError: ErrServerError,
+		} {
+			resp := &httpCallResponse{resp: &http.Response{StatusCode: code}}
+			require.ErrorIs(t, resp, sentinel, "status code %d", code)
+		}
+	})
+
+	t.Run("returned from client calls", func(t *testing.T) {
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"account not found"}`))
+		})
+
+		_, err := c.GetAccount(context.Background(), "missing")
+		require.ErrorIs(t, err, ErrNotFound)
+		require.ErrorIs(t, err, ErrResourceNotFound)
+
+		var moovErr *Error
+		require.ErrorAs(t, err, &moovErr)
+		require.Equal(t, "account not found", moovErr.Message)
+	})
+}
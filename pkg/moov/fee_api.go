@@ -2,6 +2,7 @@ package moov
 
 import (
 	"context"
+	"iter"
 	"net/http"
 )
 
@@ -42,3 +43,11 @@ func (c Client) ListFeeRevenue(ctx context.Context, accountID string, filters ..
 
 	return CompletedListOrError[IncurredFee](resp)
 }
+
+// AllFeeRevenue iterates over all fee revenue matching the filters, fetching pages of
+// WithFeeRevenueCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllFeeRevenue(ctx context.Context, accountID string, filters ...FeeRevenueFilter) iter.Seq2[IncurredFee, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]IncurredFee, error) {
+		return c.ListFeeRevenue(ctx, accountID, append(filters[:len(filters):len(filters)], WithFeeRevenueSkip(skip), WithFeeRevenueCount(count))...)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,62 +20,112 @@ func DefaultHttpClient() *http.Client {
 }
 
 func (c *Client) CallHttp(ctx context.Context, endpoint EndpointArg, args ...callArg) (CallResponse, error) {
-	// Request a slot from the rate limiter
-	c.waitForSlot(ctx)
-
 	call, err := newCall(endpoint, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s://%s%s", c.moovURLScheme, c.Credentials.Host, call.path)
+	resp, err := c.handler()(ctx, call.request())
+	if err != nil {
+		return nil, err
+	}
+
+	if r, ok := resp.(*httpCallResponse); ok && r.decoder == nil {
+		r.decoder = standardDecoder
+		if c.decoder != nil {
+			r.decoder = c.decoder
+		}
+	}
+
+	return resp, nil
+}
+
+// send makes the http request to Moov. It's the innermost CallHandler of the client.
+func (c *Client) send(ctx context.Context, call *CallRequest) (HttpCallResponse, error) {
+	url := fmt.Sprintf("%s://%s%s", c.moovURLScheme, c.Credentials.Host, call.Path)
 
-	req, err := http.NewRequestWithContext(ctx, call.method, url, call.body)
+	var body io.Reader
+	if call.Body != nil {
+		body = bytes.NewReader(call.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, call.Method, url, body)
 	if err != nil {
 		return nil, err
 	}
 
 	qry := req.URL.Query()
-	for k, v := range call.params {
+	for k, v := range call.Params {
 		qry.Add(k, v)
 	}
 	req.URL.RawQuery = qry.Encode()
 
-	for k, v := range call.headers {
+	for k, v := range call.Headers {
 		req.Header.Add(k, v)
 	}
 	req.Header.Add("User-Agent", fmt.Sprintf("moov-go/%s", moovgo.Version()))
 
-	switch {
-	case call.token != nil:
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", *call.token))
-	case c.bearerToken != "":
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
-	default:
-		req.SetBasicAuth(c.Credentials.PublicKey, c.Credentials.SecretKey)
-	}
-
 	resp, err := c.HttpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
+	respReader := io.Reader(resp.Body)
+	if c.maxResponseBodySize > 0 {
+		respReader = newLimitedBody(resp.Body, c.maxResponseBodySize)
+	}
 
-	decoder := standardDecoder
-	if c.decoder != nil {
-		decoder = c.decoder
+	// Only successful bodies are streamed, error bodies are small and needed to build the error.
+	if call.Stream && resp.StatusCode == http.StatusOK {
+		return &httpCallResponse{
+			resp:   resp,
+			stream: &streamedBody{Reader: respReader, Closer: resp.Body},
+		}, nil
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(respReader)
+	if errors.Is(err, ErrResponseBodyTooLarge) {
+		return nil, err
 	}
 
 	return &httpCallResponse{
 		resp: resp,
-		body: body,
-
-		decoder: decoder,
+		body: respBody,
 	}, nil
 }
 
+// limitedBody fails reads with ErrResponseBodyTooLarge once more than limit bytes were read.
+type limitedBody struct {
+	r     io.Reader
+	read  int64
+	limit int64
+}
+
+func newLimitedBody(r io.Reader, limit int64) *limitedBody {
+	return &limitedBody{r: io.LimitReader(r, limit+1), limit: limit}
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n - int(l.read-l.limit), fmt.Errorf("%w of %d bytes", ErrResponseBodyTooLarge, l.limit)
+	}
+	return n, err
+}
+
+// isStreamed reports if the response body is still unread on the connection.
+func isStreamed(resp HttpCallResponse) bool {
+	r, ok := resp.(*httpCallResponse)
+	return ok && r.stream != nil
+}
+
+type streamedBody struct {
+	io.Reader
+	io.Closer
+}
+
 var _ CallResponse = &httpCallResponse{}
 var _ HttpCallResponse = &httpCallResponse{}
 
@@ -86,12 +137,17 @@ type HttpCallResponse interface {
 
 	RequestId() string
 	StatusCode() int
+	Header() http.Header
 }
 
 type httpCallResponse struct {
 	resp *http.Response
 	body []byte
 
+	// stream is the unread body of a streamed response, it's nil once read or closed.
+	stream io.ReadCloser
+	closed bool
+
 	decoder Decoder
 }
 
@@ -105,6 +161,9 @@ func (r *httpCallResponse) Status() CallStatus {
 		return StatusCompleted
 	case http.StatusCreated, http.StatusAccepted:
 		return StatusStarted
+	case http.StatusNotModified:
+		// Only returned to revalidations sent by the response cache.
+		return StatusCompleted
 
 	case http.StatusBadRequest:
 		return StatusBadRequest
@@ -135,20 +194,45 @@ func standardDecoder(r io.Reader, contentType string, item any) error {
 	return fmt.Errorf("unknown content-type %s", contentType)
 }
 
+// Unmarshal decodes the body into item. Writers like a *bytes.Buffer or an *os.File receive the raw body.
 func (r *httpCallResponse) Unmarshal(item any) error {
 	ct := strings.ToLower(r.resp.Header.Get("content-type"))
 
-	if sb, ok := item.(*strings.Builder); ok {
-		_, err := sb.Write(r.body)
-		return err
+	if r.closed {
+		return errors.New("streamed response body was already read")
+	}
+
+	body := io.Reader(bytes.NewReader(r.body))
+	if r.stream != nil {
+		defer r.Close()
+		body = r.stream
 	}
 
-	if bb, ok := item.(*bytes.Buffer); ok {
-		_, err := bb.Write(r.body)
+	if w, ok := item.(io.Writer); ok {
+		_, err := io.Copy(w, body)
 		return err
 	}
 
-	return r.decoder(bytes.NewReader(r.body), ct, item)
+	decoder := r.decoder
+	if decoder == nil {
+		decoder = standardDecoder
+	}
+	return decoder(body, ct, item)
+}
+
+// Close releases the body of a streamed response that wasn't read with Unmarshal.
+// It does nothing for buffered responses.
+func (r *httpCallResponse) Close() error {
+	if r.stream == nil {
+		return nil
+	}
+	stream := r.stream
+	r.stream = nil
+	r.closed = true
+
+	// Drain what's left of small bodies so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(stream, 4<<10))
+	return stream.Close()
 }
 
 func (r *httpCallResponse) StatusCode() int {
@@ -158,6 +242,13 @@ func (r *httpCallResponse) StatusCode() int {
 	return 0
 }
 
+func (r *httpCallResponse) Header() http.Header {
+	if r.resp != nil {
+		return r.resp.Header
+	}
+	return http.Header{}
+}
+
 func (r *httpCallResponse) RequestId() string {
 	if r.resp != nil {
 		return r.resp.Header.Get("X-Request-ID")
@@ -165,34 +256,31 @@ func (r *httpCallResponse) RequestId() string {
 	return ""
 }
 
-type errorResponse struct {
-	Error string `json:"error"`
+// moovError converts the response into an *Error.
+func (r *httpCallResponse) moovError() *Error {
+	e := &Error{
+		Status:    r.Status(),
+		HTTPCode:  r.StatusCode(),
+		RequestID: r.RequestId(),
+	}
+	e.Message, e.Fields = parseErrorBody(r.body)
+	return e
 }
 
 func (r *httpCallResponse) Error() string {
-	generic := fmt.Sprintf("error from moov - status: %s http.request_id: %s http.status_code: %d", r.Status().Name, r.RequestId(), r.StatusCode())
-
-	switch r.StatusCode() {
-	case http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity:
-		// Check if the response contains an error in JSON
-		if json.Valid(r.body) {
-			var wrapper errorResponse
-			json.Unmarshal(r.body, &wrapper)
-			if wrapper.Error != "" {
-				return fmt.Sprintf("%s\n  %s", generic, wrapper.Error)
-			}
-
-			// a JSON response like
-			//  {"profile":{"business":{"taxID":{"ein":{"number":"must be a valid employer identification number"}}}}}
-			// gets transformed into
-			//  profile.business.taxID.ein.number: must be a valid employer identification number
-			out := strings.TrimPrefix(string(r.body), `{"`)
-			out = strings.ReplaceAll(out, `":{"`, ".")
-			out = strings.ReplaceAll(out, `":"`, ": ")
-			out = strings.ReplaceAll(out, `"}`, "\n")
-			out = strings.ReplaceAll(out, "}", "")
-			return fmt.Sprintf("%s - %v", generic, strings.TrimSpace(out))
-		}
+	return r.moovError().Error()
+}
+
+// As allows errors.As to retrieve an *Error from the response.
+func (r *httpCallResponse) As(target any) bool {
+	if t, ok := target.(**Error); ok {
+		*t = r.moovError()
+		return true
 	}
-	return generic
+	return false
+}
+
+// Is matches the sentinel error for the status of the response, e.g. ErrNotFound.
+func (r *httpCallResponse) Is(target error) bool {
+	return r.moovError().Is(target)
 }
@@ -1,7 +1,9 @@
 package moov
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +12,23 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// newLocalTestClient returns a client that sends its requests to a local server running handler.
+func newLocalTestClient(t *testing.T, handler http.HandlerFunc, cfg ...ClientConfigurable) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg = append([]ClientConfigurable{
+		WithCredentials(Credentials{PublicKey: "pk", SecretKey: "sk", Host: strings.TrimPrefix(srv.URL, "http://")}),
+		WithMoovURLScheme("http"),
+	}, cfg...)
+
+	c, err := NewClient(cfg...)
+	require.NoError(t, err)
+	return c
+}
+
 func TestHTTPCallResponse(t *testing.T) {
 	t.Run("400", func(t *testing.T) {
 		resp := &httpCallResponse{
@@ -100,3 +119,95 @@ func TestCredentials_Validate(t *testing.T) {
 		require.ErrorIs(t, (&Credentials{SecretKey: "sk"}).Validate(), ErrCredentialsNotSet)
 	})
 }
+
+func TestCallHttp_StreamResponse(t *testing.T) {
+	pdf := bytes.Repeat([]byte("%PDF-1.7 "), 1000)
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/a1/statements/s1":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write(pdf)
+		case "/accounts/a1/transfers":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[{"transferID":"t1"},{"transferID":"t2"}]`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"statement not found"}`))
+		}
+	}
+
+	t.Run("writes statement PDF to writer", func(t *testing.T) {
+		c := newLocalTestClient(t, handler)
+
+		buf := bytes.Buffer{}
+		require.NoError(t, c.GetStatementPDFTo(context.Background(), "a1", "s1", &buf))
+		require.Equal(t, pdf, buf.Bytes())
+
+		got, err := c.GetStatementPDF(context.Background(), "a1", "s1")
+		require.NoError(t, err)
+		require.Equal(t, pdf, got)
+	})
+
+	t.Run("decodes lists from the body", func(t *testing.T) {
+		c := newLocalTestClient(t, handler)
+
+		transfers, err := c.ListTransfers(context.Background(), "a1")
+		require.NoError(t, err)
+		require.Len(t, transfers, 2)
+		require.Equal(t, "t2", transfers[1].TransferID)
+	})
+
+	t.Run("buffers error responses", func(t *testing.T) {
+		c := newLocalTestClient(t, handler)
+
+		err := c.GetStatementPDFTo(context.Background(), "a1", "missing", io.Discard)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		var moovErr *Error
+		require.ErrorAs(t, err, &moovErr)
+		require.Equal(t, "statement not found", moovErr.Message)
+	})
+
+	t.Run("can only be read once", func(t *testing.T) {
+		c := newLocalTestClient(t, handler)
+
+		resp, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, pathStatement, "a1", "s1"), StreamResponse())
+		require.NoError(t, err)
+		require.NoError(t, resp.Unmarshal(io.Discard))
+		require.Error(t, resp.Unmarshal(io.Discard))
+	})
+}
+
+func TestWithMaxResponseBodySize(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(bytes.Repeat([]byte("x"), 100))
+	}
+
+	t.Run("fails buffered responses", func(t *testing.T) {
+		c := newLocalTestClient(t, handler, WithMaxResponseBodySize(50))
+
+		_, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/ping"))
+		require.ErrorIs(t, err, ErrResponseBodyTooLarge)
+		require.ErrorContains(t, err, "50 bytes")
+	})
+
+	t.Run("fails streamed responses", func(t *testing.T) {
+		c := newLocalTestClient(t, handler, WithMaxResponseBodySize(50))
+
+		buf := bytes.Buffer{}
+		err := c.GetStatementPDFTo(context.Background(), "a1", "s1", &buf)
+		require.ErrorIs(t, err, ErrResponseBodyTooLarge)
+		require.Equal(t, 50, buf.Len())
+	})
+
+	t.Run("allows bodies within the limit", func(t *testing.T) {
+		c := newLocalTestClient(t, handler, WithMaxResponseBodySize(100))
+
+		got, err := c.GetStatementPDF(context.Background(), "a1", "s1")
+		require.NoError(t, err)
+		require.Len(t, got, 100)
+	})
+}
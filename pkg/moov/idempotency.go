@@ -0,0 +1,356 @@
+package moov
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IdempotencyStore remembers the X-Idempotency-Key used for each business key, e.g. a payout ID,
+// along with the response Moov returned for it. With a durable store a call retried after a crash
+// reuses the original key, so Moov doesn't move the money twice.
+//
+// Records are stored per business key and endpoint: the key given to the store is made of the
+// business key, the HTTP method and the path template of the call, e.g.
+// "payout-1 POST /accounts/{id}/transfers". Clients of a ClientPool prefix it with their tenant,
+// other clients sharing a store need business keys that are unique between them.
+type IdempotencyStore interface {
+	// Load returns the record saved for the key, or nil if there is none.
+	Load(ctx context.Context, key string) (*IdempotencyRecord, error)
+
+	// Add saves the record unless the key has one already, as a single atomic step. It returns
+	// the record the key has afterwards, and whether it's the one given.
+	Add(ctx context.Context, key string, record IdempotencyRecord) (IdempotencyRecord, bool, error)
+
+	// Save stores the record for the key, replacing any previous one.
+	Save(ctx context.Context, key string, record IdempotencyRecord) error
+}
+
+// IdempotencyRecord is what an IdempotencyStore keeps for a business key.
+type IdempotencyRecord struct {
+	// Key is the X-Idempotency-Key sent to Moov.
+	Key string `json:"key"`
+
+	// Path and RequestHash identify the request the key was sent with, so the business key can't
+	// be reused for a different one.
+	Path        string `json:"path"`
+	RequestHash string `json:"requestHash"`
+
+	// CreatedOn is when the key was first sent.
+	CreatedOn time.Time `json:"createdOn"`
+
+	// Response is the completed or started response Moov returned, nil until there is one.
+	Response *IdempotencyResponse `json:"response,omitempty"`
+
+	// TransferID is the transfer created by a CreateTransfer call, saved along with its response.
+	TransferID string `json:"transferID,omitempty"`
+}
+
+// IdempotencyResponse is a response cached by an IdempotencyStore.
+type IdempotencyResponse struct {
+	StatusCode  int    `json:"statusCode"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+var (
+	// ErrBusinessKeyReused is returned for calls whose business key was used before with another
+	// request to the same endpoint, e.g. for a transfer of a different amount.
+	ErrBusinessKeyReused = errors.New("business key was used before for a different request")
+
+	// ErrIdempotencyResponseLost is returned when Moov rejects a stored X-Idempotency-Key as a
+	// conflict and there is nothing to resolve it to: the request was sent before, but neither
+	// its response nor the transfer it created was ever stored, e.g. when the process stopped
+	// while waiting for it. Look up what the first request created before deciding how to
+	// continue.
+	ErrIdempotencyResponseLost = errors.New("request was sent before with the same X-Idempotency-Key but its response wasn't stored")
+)
+
+// WithIdempotencyStore keeps the X-Idempotency-Key of CreateTransfer, RefundTransfer and
+// ReverseTransfer calls, and of CreateReceipts calls given WithReceiptIdempotencyKey, in the
+// store for calls whose context carries a business key from ContextWithBusinessKey.
+//
+// The first call for a business key saves its key before it's sent. Calls made for the same
+// business key afterwards, e.g. after a restart, send the same key again until Moov returned a
+// response, and then return the cached response. Calls reusing the business key for a different
+// request fail with ErrBusinessKeyReused.
+//
+// Transfers Moov only started, e.g. when WaitForRailResponse timed out, are looked up with
+// GetTransfer instead of returning the cached response, so a repeated call returns the transfer
+// as it is now. When Moov rejects a repeated key of a CreateTransfer call as a conflict, the call
+// returns the transfer created before if its ID was stored, and fails with
+// ErrIdempotencyResponseLost otherwise.
+func WithIdempotencyStore(store IdempotencyStore) ClientConfigurable {
+	return func(c *Client) error {
+		c.idempotencyStore = store
+		return nil
+	}
+}
+
+type businessKeyContextKey struct{}
+
+// ContextWithBusinessKey returns a context making calls idempotent per businessKey, see
+// WithIdempotencyStore. Use a key that identifies the operation on your side, e.g. a payout ID.
+func ContextWithBusinessKey(ctx context.Context, businessKey string) context.Context {
+	return context.WithValue(ctx, businessKeyContextKey{}, businessKey)
+}
+
+func businessKeyFrom(ctx context.Context) string {
+	key, _ := ctx.Value(businessKeyContextKey{}).(string)
+	return key
+}
+
+// idempotencyMiddleware reuses stored idempotency keys and caches responses for calls made with
+// a business key.
+func (c *Client) idempotencyMiddleware(next CallHandler) CallHandler {
+	return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+		businessKey := businessKeyFrom(ctx)
+		if _, ok := req.Headers["X-Idempotency-Key"]; !ok || businessKey == "" {
+			return next(ctx, req)
+		}
+
+		key := businessKey + " " + req.Method + " " + req.PathTemplate
+		if c.tenant != "" {
+			key = c.tenant + " " + key
+		}
+		sum := sha256.Sum256(req.Body)
+		record, added, err := c.idempotencyStore.Add(ctx, key, IdempotencyRecord{
+			Key:         req.Headers["X-Idempotency-Key"],
+			Path:        req.Path,
+			RequestHash: hex.EncodeToString(sum[:]),
+			CreatedOn:   time.Now(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("saving idempotency key: %w", err)
+		}
+		if record.Path != req.Path || record.RequestHash != hex.EncodeToString(sum[:]) {
+			return nil, fmt.Errorf("%w: %s", ErrBusinessKeyReused, businessKey)
+		}
+		if record.Response != nil {
+			if record.TransferID != "" && cachedResponse(record.Response).Status() == StatusStarted {
+				return getCreatedTransfer(ctx, next, req, record.TransferID)
+			}
+			return cachedResponse(record.Response), nil
+		}
+		req.Headers["X-Idempotency-Key"] = record.Key
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !added && resp.Status() == StatusStateConflict {
+			// Another call, e.g. of another process, may have stored the transfer since.
+			if stored, err := c.idempotencyStore.Load(ctx, key); err == nil && stored != nil && stored.TransferID != "" {
+				return getCreatedTransfer(ctx, next, req, stored.TransferID)
+			}
+			return nil, fmt.Errorf("%w: business key %s, X-Idempotency-Key %s: %w", ErrIdempotencyResponseLost, businessKey, record.Key, resp)
+		}
+
+		// The money already moved, so the response is returned even if it can't be cached. The
+		// saved key still keeps a retry from moving it again.
+		status := resp.Status()
+		if r, ok := resp.(*httpCallResponse); ok && r.stream == nil && (status == StatusCompleted || status == StatusStarted) {
+			record.Response = &IdempotencyResponse{
+				StatusCode:  r.StatusCode(),
+				ContentType: r.Header().Get("Content-Type"),
+				Body:        r.body,
+			}
+			if isTransferCreation(req) {
+				var created TransferStarted
+				if json.Unmarshal(r.body, &created) == nil {
+					record.TransferID = created.TransferID
+				}
+			}
+			_ = c.idempotencyStore.Save(ctx, key, record)
+		}
+
+		return resp, nil
+	}
+}
+
+var transfersPathTemplate = strings.ReplaceAll(pathTransfers, "%s", "{id}")
+
+func isTransferCreation(req *CallRequest) bool {
+	return req.Method == http.MethodPost && req.PathTemplate == transfersPathTemplate
+}
+
+// getCreatedTransfer gets the transfer created by the CreateTransfer call req, with the version
+// and authentication of the call.
+func getCreatedTransfer(ctx context.Context, next CallHandler, req *CallRequest, transferID string) (HttpCallResponse, error) {
+	headers := maps.Clone(req.Headers)
+	delete(headers, "X-Idempotency-Key")
+	delete(headers, "Content-Type")
+
+	return next(ctx, &CallRequest{
+		Method:       http.MethodGet,
+		Path:         req.Path + "/" + url.PathEscape(transferID),
+		PathTemplate: strings.ReplaceAll(pathTransfer, "%s", "{id}"),
+		Headers:      headers,
+		token:        req.token,
+	})
+}
+
+func cachedResponse(cached *IdempotencyResponse) *httpCallResponse {
+	return &httpCallResponse{
+		resp: &http.Response{
+			StatusCode: cached.StatusCode,
+			Header:     http.Header{"Content-Type": []string{cached.ContentType}},
+			Body:       io.NopCloser(bytes.NewReader(cached.Body)),
+		},
+		body: cached.Body,
+	}
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore kept in memory. It protects retries within the
+// process, use a FileIdempotencyStore to survive restarts.
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{records: map[string]IdempotencyRecord{}}
+}
+
+func (s *MemoryIdempotencyStore) Load(_ context.Context, key string) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+func (s *MemoryIdempotencyStore) Add(_ context.Context, key string, record IdempotencyRecord) (IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.records[key]; ok {
+		return existing, false, nil
+	}
+	s.records[key] = record
+	return record, true, nil
+}
+
+func (s *MemoryIdempotencyStore) Save(_ context.Context, key string, record IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[key] = record
+	return nil
+}
+
+// FileIdempotencyStore is an IdempotencyStore keeping a JSON file per key in a directory. Files
+// are written atomically and synced to disk before Add and Save return, so processes sharing the
+// directory never add two records for a key.
+type FileIdempotencyStore struct {
+	dir string
+}
+
+// NewFileIdempotencyStore returns a store in dir, creating it if needed.
+func NewFileIdempotencyStore(dir string) (*FileIdempotencyStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileIdempotencyStore{dir: dir}, nil
+}
+
+func (s *FileIdempotencyStore) Load(_ context.Context, key string) (*IdempotencyRecord, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record IdempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("reading idempotency record %s: %w", key, err)
+	}
+	return &record, nil
+}
+
+// Add links the written record to its name, which fails if the key has a file already.
+func (s *FileIdempotencyStore) Add(ctx context.Context, key string, record IdempotencyRecord) (IdempotencyRecord, bool, error) {
+	tmp, err := s.write(record)
+	if err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+	defer os.Remove(tmp)
+
+	err = os.Link(tmp, s.path(key))
+	if err == nil {
+		return record, true, nil
+	}
+	if !errors.Is(err, os.ErrExist) {
+		return IdempotencyRecord{}, false, err
+	}
+
+	existing, err := s.Load(ctx, key)
+	if err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+	if existing == nil {
+		return IdempotencyRecord{}, false, fmt.Errorf("idempotency record %s disappeared", key)
+	}
+	return *existing, false, nil
+}
+
+func (s *FileIdempotencyStore) Save(_ context.Context, key string, record IdempotencyRecord) error {
+	tmp, err := s.write(record)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	return os.Rename(tmp, s.path(key))
+}
+
+// write writes the record to a temporary file synced to disk and returns its name.
+func (s *FileIdempotencyStore) write(record IdempotencyRecord) (string, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+// path names files by a hash of the key so any key is a valid file name.
+func (s *FileIdempotencyStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
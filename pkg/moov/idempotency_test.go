@@ -0,0 +1,280 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	transfer := CreateTransfer{
+		Source:      CreateTransfer_Source{PaymentMethodID: "source"},
+		Destination: CreateTransfer_Destination{PaymentMethodID: "destination"},
+		Amount:      Amount{Currency: "USD", Value: 1000},
+	}
+
+	// fakeMoov creates a transfer per idempotency key and answers repeated keys with a conflict,
+	// failing the first failures calls after creating the transfer.
+	type fakeMoov struct {
+		mu        sync.Mutex
+		keys      []string
+		transfers []Transfer
+		failures  int
+	}
+	handler := func(f *fakeMoov) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodGet {
+				for _, transfer := range f.transfers {
+					if r.URL.Path == "/accounts/partner/transfers/"+transfer.TransferID {
+						json.NewEncoder(w).Encode(transfer)
+						return
+					}
+				}
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			key := r.Header.Get("X-Idempotency-Key")
+			for _, k := range f.keys {
+				if k == key {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+			}
+			f.keys = append(f.keys, key)
+			f.transfers = append(f.transfers, Transfer{
+				TransferID:  "transfer" + key,
+				Amount:      transfer.Amount,
+				Source:      TransferSource{PaymentMethodID: "source"},
+				Destination: TransferDestination{PaymentMethodID: "destination"},
+			})
+
+			if f.failures > 0 {
+				f.failures--
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			json.NewEncoder(w).Encode(TransferStarted{TransferID: "transfer" + key})
+		}
+	}
+
+	t.Run("repeated calls return the cached response", func(t *testing.T) {
+		f := &fakeMoov{}
+		store := NewMemoryIdempotencyStore()
+		c := newLocalTestClient(t, handler(f), WithIdempotencyStore(store))
+		ctx := ContextWithBusinessKey(context.Background(), "payout-1")
+
+		first, err := c.CreateTransfer(ctx, "partner", transfer).Started()
+		require.NoError(t, err)
+		second, err := c.CreateTransfer(ctx, "partner", transfer).Started()
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+		require.Len(t, f.keys, 1)
+
+		record, err := store.Load(ctx, "payout-1 POST /accounts/{id}/transfers")
+		require.NoError(t, err)
+		require.Equal(t, f.keys[0], record.Key)
+		require.NotNil(t, record.Response)
+
+		_, err = c.CreateTransfer(context.Background(), "partner", transfer).Started()
+		require.NoError(t, err)
+		require.Len(t, f.keys, 2)
+	})
+
+	t.Run("rejects a business key reused for another request", func(t *testing.T) {
+		f := &fakeMoov{}
+		c := newLocalTestClient(t, handler(f), WithIdempotencyStore(NewMemoryIdempotencyStore()))
+		ctx := ContextWithBusinessKey(context.Background(), "payout-1")
+
+		_, err := c.CreateTransfer(ctx, "partner", transfer).Started()
+		require.NoError(t, err)
+
+		larger := transfer
+		larger.Amount.Value = 2000
+		_, err = c.CreateTransfer(ctx, "partner", larger).Started()
+		require.ErrorIs(t, err, ErrBusinessKeyReused)
+		_, err = c.CreateTransfer(ctx, "other-partner", transfer).Started()
+		require.ErrorIs(t, err, ErrBusinessKeyReused)
+		require.Len(t, f.keys, 1)
+	})
+
+	t.Run("fails when the response of a sent key was lost", func(t *testing.T) {
+		f := &fakeMoov{failures: 1}
+		store, err := NewFileIdempotencyStore(t.TempDir())
+		require.NoError(t, err)
+		ctx := ContextWithBusinessKey(context.Background(), "payout-1")
+
+		c := newLocalTestClient(t, handler(f), WithIdempotencyStore(store))
+		_, err = c.CreateTransfer(ctx, "partner", transfer).Started()
+		require.ErrorIs(t, err, ErrServerError)
+
+		restarted := newLocalTestClient(t, handler(f), WithIdempotencyStore(store))
+		_, err = restarted.CreateTransfer(ctx, "partner", transfer).Started()
+		require.ErrorIs(t, err, ErrIdempotencyResponseLost)
+		require.ErrorIs(t, err, ErrConflict)
+		require.ErrorContains(t, err, f.keys[0])
+		require.Len(t, f.transfers, 1)
+	})
+
+	t.Run("conflicts resolve to the stored transfer", func(t *testing.T) {
+		f := &fakeMoov{}
+		store := NewMemoryIdempotencyStore()
+		ctx := ContextWithBusinessKey(context.Background(), "payout-1")
+
+		first := newLocalTestClient(t, handler(f), WithIdempotencyStore(store))
+		started, err := first.CreateTransfer(ctx, "partner", transfer).Started()
+		require.NoError(t, err)
+
+		// Another process sends the key while the first one is about to store the transfer.
+		record, err := store.Load(ctx, "payout-1 POST /accounts/{id}/transfers")
+		require.NoError(t, err)
+		pending := *record
+		pending.Response, pending.TransferID = nil, ""
+		require.NoError(t, store.Save(ctx, "payout-1 POST /accounts/{id}/transfers", pending))
+
+		other := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				require.NoError(t, store.Save(ctx, "payout-1 POST /accounts/{id}/transfers", *record))
+			}
+			handler(f)(w, r)
+		}, WithIdempotencyStore(store))
+		got, _, err := other.CreateTransfer(ctx, "partner", transfer).WaitForRailResponse()
+		require.NoError(t, err)
+		require.Equal(t, started.TransferID, got.TransferID)
+		require.Equal(t, transfer.Amount, got.Amount)
+		require.Len(t, f.transfers, 1)
+	})
+
+	t.Run("started transfers are looked up after a restart", func(t *testing.T) {
+		var posts []string
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodGet {
+				require.Equal(t, "/accounts/partner/transfers/transfer", r.URL.Path)
+				require.Empty(t, r.Header.Get("X-Idempotency-Key"))
+				json.NewEncoder(w).Encode(Transfer{TransferID: "transfer", Status: TransferStatus_Completed})
+				return
+			}
+			posts = append(posts, r.Header.Get("X-Idempotency-Key"))
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(TransferStarted{TransferID: "transfer"})
+		}, WithIdempotencyStore(NewMemoryIdempotencyStore()))
+		ctx := ContextWithBusinessKey(context.Background(), "payout-1")
+
+		// Waiting for the rail timed out.
+		_, started, err := c.CreateTransfer(ctx, "partner", transfer).WaitForRailResponse()
+		require.NoError(t, err)
+		require.Equal(t, "transfer", started.TransferID)
+
+		completed, _, err := c.CreateTransfer(ctx, "partner", transfer).WaitForRailResponse()
+		require.NoError(t, err)
+		require.Equal(t, TransferStatus_Completed, completed.Status)
+		require.Len(t, posts, 1)
+	})
+
+	t.Run("concurrent calls send one key", func(t *testing.T) {
+		f := &fakeMoov{}
+		c := newLocalTestClient(t, handler(f), WithIdempotencyStore(NewMemoryIdempotencyStore()))
+		ctx := ContextWithBusinessKey(context.Background(), "payout-1")
+
+		var wg sync.WaitGroup
+		for range 2 {
+			wg.Go(func() {
+				// The call that loses the race to Moov gets a conflict.
+				_, _ = c.CreateTransfer(ctx, "partner", transfer).Started()
+			})
+		}
+		wg.Wait()
+
+		require.Len(t, f.keys, 1)
+		require.Len(t, f.transfers, 1)
+	})
+
+	t.Run("refunds reuse the key", func(t *testing.T) {
+		var keys []string
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get("X-Idempotency-Key"))
+			w.WriteHeader(http.StatusInternalServerError)
+		}, WithIdempotencyStore(NewMemoryIdempotencyStore()))
+		ctx := ContextWithBusinessKey(context.Background(), "refund-1")
+
+		for range 2 {
+			_, _, err := c.RefundTransfer(ctx, "partner", "transfer", CreateRefund{Amount: 100})
+			require.ErrorIs(t, err, ErrServerError)
+		}
+		require.Len(t, keys, 2)
+		require.Equal(t, keys[0], keys[1])
+	})
+
+	t.Run("receipts only send a key when given one", func(t *testing.T) {
+		var keys []string
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get("X-Idempotency-Key"))
+			w.WriteHeader(http.StatusInternalServerError)
+		}, WithIdempotencyStore(NewMemoryIdempotencyStore()))
+		ctx := ContextWithBusinessKey(context.Background(), "receipt-1")
+
+		_, err := c.CreateReceipt(ctx, CreateReceipt{Kind: "sale.customer.v1"})
+		require.ErrorIs(t, err, ErrServerError)
+		require.Equal(t, []string{""}, keys)
+
+		for range 2 {
+			_, err := c.CreateReceipts(ctx, []CreateReceipt{{Kind: "sale.customer.v1"}}, WithReceiptIdempotencyKey(uuid.New()))
+			require.ErrorIs(t, err, ErrServerError)
+		}
+		require.Len(t, keys, 3)
+		require.NotEmpty(t, keys[1])
+		require.Equal(t, keys[1], keys[2])
+	})
+}
+
+func TestIdempotencyStore_Add(t *testing.T) {
+	file, err := NewFileIdempotencyStore(t.TempDir())
+	require.NoError(t, err)
+
+	for name, store := range map[string]IdempotencyStore{
+		"memory": NewMemoryIdempotencyStore(),
+		"file":   file,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var (
+				wg    sync.WaitGroup
+				mu    sync.Mutex
+				added []string
+			)
+			for i := range 10 {
+				wg.Go(func() {
+					key := strconv.Itoa(i)
+					record, ok, err := store.Add(ctx, "payout-1", IdempotencyRecord{Key: key})
+					require.NoError(t, err)
+
+					mu.Lock()
+					defer mu.Unlock()
+					if ok {
+						require.Equal(t, key, record.Key)
+						added = append(added, key)
+					}
+				})
+			}
+			wg.Wait()
+			require.Len(t, added, 1)
+
+			record, ok, err := store.Add(ctx, "payout-1", IdempotencyRecord{Key: "later"})
+			require.NoError(t, err)
+			require.False(t, ok)
+			require.Equal(t, added[0], record.Key)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"iter"
 	"net/http"
 )
 
@@ -42,6 +43,14 @@ func (c Client) ListImageMetadata(ctx context.Context, accountID string, filters
 	return CompletedListOrError[ImageMetadata](resp)
 }
 
+// AllImageMetadata iterates over all image metadata matching the filters, fetching pages of
+// WithImageCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllImageMetadata(ctx context.Context, accountID string, filters ...ImageListFilter) iter.Seq2[ImageMetadata, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]ImageMetadata, error) {
+		return c.ListImageMetadata(ctx, accountID, append(filters[:len(filters):len(filters)], WithImageSkip(skip), WithImageCount(count))...)
+	})
+}
+
 // GetImageMetadata retrieves metadata for a specific image by its ID.
 // https://docs.moov.io/api/tools/images/get/
 func (c Client) GetImageMetadata(ctx context.Context, accountID string, imageID string) (*ImageMetadata, error) {
@@ -3,6 +3,7 @@ package moov
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"time"
 )
@@ -141,6 +142,14 @@ func (c Client) ListInvoices(ctx context.Context, accountID string, filters ...L
 	return CompletedListOrError[Invoice](resp)
 }
 
+// AllInvoices iterates over all invoices matching the filters, fetching pages of
+// WithInvoiceCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllInvoices(ctx context.Context, accountID string, filters ...ListInvoiceFilter) iter.Seq2[Invoice, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]Invoice, error) {
+		return c.ListInvoices(ctx, accountID, append(filters[:len(filters):len(filters)], WithInvoiceSkip(skip), WithInvoiceCount(count))...)
+	})
+}
+
 // DeleteInvoice deletes a draft invoice. Only invoices in draft status can be deleted.
 func (c Client) DeleteInvoice(ctx context.Context, accountID, invoiceID string) error {
 	resp, err := c.CallHttp(ctx, Endpoint(http.MethodDelete, pathInvoice, accountID, invoiceID))
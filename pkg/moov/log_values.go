@@ -0,0 +1,107 @@
+package moov
+
+import (
+	"log/slog"
+)
+
+// slog.LogValuer implementations for models carrying PII, PCI or secrets so they can be passed
+// to a logger as-is and only render identifiers and non-sensitive details.
+
+var (
+	_ slog.LogValuer = Account{}
+	_ slog.LogValuer = Profile{}
+	_ slog.LogValuer = BankAccount{}
+	_ slog.LogValuer = Card{}
+	_ slog.LogValuer = Representative{}
+	_ slog.LogValuer = Credentials{}
+	_ slog.LogValuer = AccessTokenResponse{}
+)
+
+func (a Account) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("accountID", a.AccountID),
+		slog.String("mode", string(a.Mode)),
+		slog.String("accountType", string(a.AccountType)),
+		slog.String("foreignID", a.ForeignID),
+		slog.Any("profile", a.Profile),
+	)
+}
+
+func (p Profile) LogValue() slog.Value {
+	switch {
+	case p.Business != nil:
+		return slog.GroupValue(
+			slog.String("type", string(AccountType_Business)),
+			slog.String("legalBusinessName", p.Business.LegalBusinessName),
+			slog.String("businessType", string(p.Business.BusinessType)),
+			slog.Bool("taxIDProvided", p.Business.TaxIDProvided),
+		)
+	case p.Individual != nil:
+		return slog.GroupValue(
+			slog.String("type", string(AccountType_Individual)),
+			slog.String("name", redacted),
+			slog.String("email", redactIfSet(p.Individual.Email)),
+		)
+	default:
+		return slog.GroupValue()
+	}
+}
+
+func (b BankAccount) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("bankAccountID", b.BankAccountID),
+		slog.String("fingerprint", b.Fingerprint),
+		slog.String("status", string(b.Status)),
+		slog.String("bankName", b.BankName),
+		slog.String("bankAccountType", string(b.BankAccountType)),
+		slog.String("routingNumber", b.RoutingNumber),
+		slog.String("lastFourAccountNumber", b.LastFourAccountNumber),
+		slog.String("holderName", redactIfSet(b.HolderName)),
+	)
+}
+
+func (c Card) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("cardID", c.CardID),
+		slog.String("fingerprint", c.Fingerprint),
+		slog.String("brand", c.Brand),
+		slog.String("cardType", c.CardType),
+		slog.String("lastFourCardNumber", c.LastFourCardNumber),
+		slog.String("holderName", redactIfSet(c.HolderName)),
+	)
+}
+
+func (r Representative) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("representativeID", r.RepresentativeID),
+		slog.String("name", redacted),
+		slog.String("email", redactIfSet(r.Email)),
+		slog.Bool("birthDateProvided", r.BirthDateProvided),
+		slog.Bool("governmentIDProvided", r.GovernmentIDProvided),
+	)
+}
+
+func (c Credentials) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("public_key", c.PublicKey),
+		slog.String("secret_key", redactIfSet(c.SecretKey)),
+		slog.String("host", c.Host),
+	)
+}
+
+func (t AccessTokenResponse) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("access_token", redactIfSet(t.AccessToken)),
+		slog.String("refresh_token", redactIfSet(t.RefreshToken)),
+		slog.String("token_type", t.TokenType),
+		slog.Int("expires_in", int(t.ExpiresIn)),
+		slog.String("scope", t.Scope),
+	)
+}
+
+func redactIfSet(s string) string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
@@ -0,0 +1,142 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// Body fields masked by default when request and response bodies are logged.
+// Matching is case-insensitive and masks everything below a matching field.
+var defaultMaskedFields = []string{
+	"accountNumber",
+	"cardNumber",
+	"cardCvv",
+	"cvv",
+	"ssn",
+	"itin",
+	"taxID",
+	"governmentID",
+	"birthDate",
+	"secret",
+	"secretKey",
+	"client_secret",
+	"access_token",
+	"refresh_token",
+	"token",
+}
+
+// WithLogger logs every call made to Moov with its method, path template, status, request ID
+// and duration. Successful calls are logged at debug level, error responses at warn and
+// calls that failed to get a response at error level.
+func WithLogger(logger *slog.Logger) ClientConfigurable {
+	return func(c *Client) error {
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithBodyLogging includes request and response bodies in the call logs at the given level.
+// Values of sensitive JSON fields such as account and card numbers, tax IDs and secrets are
+// masked, along with any extra fields listed. Meant for debugging in sandbox, has no effect
+// without WithLogger.
+func WithBodyLogging(level slog.Level, maskFields ...string) ClientConfigurable {
+	return func(c *Client) error {
+		c.bodyLogLevel = &level
+		c.maskedFields = append(slices.Clone(defaultMaskedFields), maskFields...)
+		return nil
+	}
+}
+
+// logMiddleware writes a log entry for every call once it returns.
+func (c *Client) logMiddleware(next CallHandler) CallHandler {
+	return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+		start := time.Now()
+		resp, err := next(ctx, req)
+		elapsed := time.Since(start)
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("path", req.PathTemplate),
+			slog.Duration("duration", elapsed),
+			slog.Int("attempts", req.Attempts),
+		}
+
+		level := slog.LevelDebug
+		msg := "moov call"
+		switch {
+		case err != nil:
+			level = slog.LevelError
+			msg = "moov call failed"
+			attrs = append(attrs, slog.Any("error", err))
+		default:
+			attrs = append(attrs,
+				slog.String("status", resp.Status().Name),
+				slog.Int("status_code", resp.StatusCode()),
+				slog.String("request_id", resp.RequestId()),
+			)
+			switch resp.Status() {
+			case StatusCompleted, StatusStarted:
+			default:
+				level = slog.LevelWarn
+			}
+		}
+
+		if c.bodyLogLevel != nil && c.logger.Enabled(ctx, *c.bodyLogLevel) {
+			level = max(level, *c.bodyLogLevel)
+			attrs = append(attrs, slog.String("request_body", maskBody(req.Body, c.maskedFields)))
+			// Reading a streamed body here would leave nothing for the caller.
+			if err == nil && !isStreamed(resp) {
+				sb := strings.Builder{}
+				_ = resp.Unmarshal(&sb)
+				attrs = append(attrs, slog.String("response_body", maskBody([]byte(sb.String()), c.maskedFields)))
+			}
+		}
+
+		c.logger.LogAttrs(ctx, level, msg, attrs...)
+
+		return resp, err
+	}
+}
+
+// maskBody replaces the values of sensitive fields in a JSON body. Anything that isn't JSON is
+// left out entirely as it can't be masked.
+func maskBody(body []byte, fields []string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return redacted
+	}
+
+	masked, err := json.Marshal(maskValue(doc, fields))
+	if err != nil {
+		return redacted
+	}
+	return string(masked)
+}
+
+func maskValue(v any, fields []string) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if slices.ContainsFunc(fields, func(f string) bool { return strings.EqualFold(f, k) }) {
+				t[k] = redacted
+				continue
+			}
+			t[k] = maskValue(child, fields)
+		}
+	case []any:
+		for i, child := range t {
+			t[i] = maskValue(child, fields)
+		}
+	}
+	return v
+}
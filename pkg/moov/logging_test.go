@@ -0,0 +1,104 @@
+package moov
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLogger(t *testing.T) {
+	t.Run("logs calls without bodies", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request-ID", "req-1")
+			w.WriteHeader(http.StatusNotFound)
+		}, WithLogger(logger))
+
+		_, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, pathTransfer, "a", "b"))
+		require.NoError(t, err)
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		require.Equal(t, "WARN", entry["level"])
+		require.Equal(t, "GET", entry["method"])
+		require.Equal(t, "/accounts/{id}/transfers/{id}", entry["path"])
+		require.Equal(t, "not_found", entry["status"])
+		require.Equal(t, "req-1", entry["request_id"])
+		require.Contains(t, entry, "duration")
+		require.NotContains(t, entry, "request_body")
+		require.NotContains(t, buf.String(), "Basic ")
+	})
+
+	t.Run("masks logged bodies", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"bankAccountID":"ba-1","lastFourAccountNumber":"6789","nickname":"savings"}`))
+		}, WithLogger(logger), WithBodyLogging(slog.LevelDebug, "nickname"))
+
+		_, err := c.CallHttp(context.Background(),
+			Endpoint(http.MethodPost, pathBankAccounts, "a"),
+			JsonBody(map[string]any{
+				"account": map[string]string{
+					"accountNumber": "123456789",
+					"routingNumber": "273976369",
+				},
+			}))
+		require.NoError(t, err)
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		require.JSONEq(t, `{"account":{"accountNumber":"[REDACTED]","routingNumber":"273976369"}}`, entry["request_body"].(string))
+		require.JSONEq(t, `{"bankAccountID":"ba-1","lastFourAccountNumber":"6789","nickname":"[REDACTED]"}`, entry["response_body"].(string))
+		require.NotContains(t, buf.String(), "123456789")
+	})
+
+	t.Run("skips bodies when the level is disabled", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}, WithLogger(logger), WithBodyLogging(slog.LevelDebug))
+
+		_, err := c.CallHttp(context.Background(), Endpoint(http.MethodPost, pathAccounts), JsonBody(map[string]string{}))
+		require.NoError(t, err)
+		require.NotContains(t, buf.String(), "request_body")
+	})
+}
+
+func TestLogValuers(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, nil))
+
+	logger.Info("models",
+		"credentials", Credentials{PublicKey: "pk", SecretKey: "super-secret"},
+		"token", AccessTokenResponse{AccessToken: "at-1234", RefreshToken: "rt-5678", ExpiresIn: 60},
+		"account", Account{
+			AccountID: "acct-1",
+			Profile: Profile{Individual: &Individual{
+				Name:  Name{FirstName: "Jane", LastName: "Doe"},
+				Email: "jane@example.com",
+			}},
+		},
+		"card", Card{CardID: "card-1", LastFourCardNumber: "1111", HolderName: "Jane Doe"},
+	)
+
+	out := buf.String()
+	for _, secret := range []string{"super-secret", "at-1234", "rt-5678", "Jane", "jane@example.com"} {
+		require.NotContains(t, out, secret)
+	}
+	require.Contains(t, out, "credentials.public_key=pk")
+	require.Contains(t, out, "account.accountID=acct-1")
+	require.Contains(t, out, "card.lastFourCardNumber=1111")
+	require.Contains(t, out, "token.expires_in=60")
+}
@@ -0,0 +1,152 @@
+package moov
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"net/http"
+)
+
+// CallRequest is a call to Moov after all the call arguments have been applied and before it's sent.
+// Middleware can read and modify any of its fields.
+type CallRequest struct {
+	Method string
+	// Path with the arguments expanded, e.g. /accounts/1234/transfers
+	Path string
+	// Path before the arguments were expanded, e.g. /accounts/{id}/transfers
+	PathTemplate string
+
+	Params  map[string]string
+	Headers map[string]string
+	Body    []byte
+
+	// Stream is set when a successful response body is decoded while it's read instead of being
+	// buffered. Streamed responses can only be unmarshalled once.
+	Stream bool
+
+	// Number of times the request was sent to Moov, including retries.
+	// Set by the client once the call returns to the middleware.
+	Attempts int
+
+	token *string
+}
+
+// CallHandler sends a CallRequest and returns the response from Moov.
+type CallHandler func(ctx context.Context, req *CallRequest) (HttpCallResponse, error)
+
+// Middleware wraps a CallHandler to add behavior around every call made by the client.
+// It can modify the request before calling next, inspect or replace the response afterward,
+// or short-circuit the call by not calling next at all.
+type Middleware func(next CallHandler) CallHandler
+
+// WithMiddleware adds middleware around every call made by the client. The first middleware
+// given is the outermost one. Middleware sees each call once, outside of any retries configured
+// with WithRetryPolicy.
+func WithMiddleware(middleware ...Middleware) ClientConfigurable {
+	return func(c *Client) error {
+		c.middleware = append(c.middleware, middleware...)
+		return nil
+	}
+}
+
+func (call *callBuilder) request() *CallRequest {
+	return &CallRequest{
+		Method:       call.method,
+		Path:         call.path,
+		PathTemplate: call.pathTemplate,
+		Params:       maps.Clone(call.params),
+		Headers:      maps.Clone(call.headers),
+		Body:         call.body,
+		Stream:       call.stream,
+		token:        call.token,
+	}
+}
+
+// handler builds the chain every call goes through: version selection, the mode check, the
+// configured middleware, then idempotency keys, the response cache, coalescing, retries, rate
+// limiting and authentication before the request is sent.
+func (c *Client) handler() CallHandler {
+	retries := c.retryPolicy
+	if retries == nil {
+		retries = &RetryPolicy{MaxAttempts: 1}
+	}
+
+	chain := []Middleware{c.versionMiddleware}
+	if c.modeCheck != nil {
+		chain = append(chain, c.modeMiddleware)
+	}
+	chain = append(chain, c.middleware...)
+	if c.idempotencyStore != nil {
+		chain = append(chain, c.idempotencyMiddleware)
+	}
+	if c.responseCache != nil {
+		chain = append(chain, c.cacheMiddleware)
+	}
+	if c.coalescer != nil {
+		chain = append(chain, c.coalesceMiddleware)
+	}
+	if c.logger != nil {
+		chain = append(chain, c.logMiddleware)
+	}
+	if c.circuitBreaker != nil {
+		chain = append(chain, c.circuitBreaker.middleware)
+	}
+	chain = append(chain, retries.middleware, c.rateLimitMiddleware, c.authMiddleware)
+
+	h := c.send
+	for i := len(chain) - 1; i >= 0; i-- {
+		h = chain[i](h)
+	}
+	return h
+}
+
+// authMiddleware sets the Authorization header, preferring a bearer token or a token from the
+// TokenSource over the client's Basic auth credentials. An Authorization header set by earlier middleware is left alone.
+func (c *Client) authMiddleware(next CallHandler) CallHandler {
+	return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+		if _, ok := req.Headers["Authorization"]; ok {
+			return next(ctx, req)
+		}
+
+		// Work on a copy so the credentials never show up in the request seen by outer middleware.
+		authed := *req
+		authed.Headers = maps.Clone(req.Headers)
+		switch {
+		case req.token != nil:
+			authed.Headers["Authorization"] = fmt.Sprintf("Bearer %s", *req.token)
+		case c.bearerToken != "":
+			authed.Headers["Authorization"] = fmt.Sprintf("Bearer %s", c.bearerToken)
+		case c.tokenSource != nil && !isOAuthPath(req.Path):
+			token, err := c.tokenSource.Token(ctx, c.tokenScopes...)
+			if err != nil {
+				return nil, fmt.Errorf("getting access token: %w", err)
+			}
+			authed.Headers["Authorization"] = fmt.Sprintf("Bearer %s", token.AccessToken)
+		default:
+			authed.Headers["Authorization"] = basicAuth(c.Credentials.PublicKey, c.Credentials.SecretKey)
+		}
+		return next(ctx, &authed)
+	}
+}
+
+// isOAuthPath reports if the call is made to the token endpoints, which always use the client credentials.
+func isOAuthPath(path string) bool {
+	return path == pathOAuth2Token || path == pathOAuth2Revoke
+}
+
+func basicAuth(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+// NewHttpCallResponse creates a response from Moov out of a raw http.Response and its body.
+// It allows middleware to short-circuit calls with a response of its own.
+func NewHttpCallResponse(resp *http.Response, body []byte) HttpCallResponse {
+	if resp.Header == nil {
+		resp.Header = http.Header{}
+	}
+	return &httpCallResponse{
+		resp: resp,
+		body: body,
+	}
+}
@@ -0,0 +1,117 @@
+package moov
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Run("sees the request before and the response after", func(t *testing.T) {
+		var seen *CallRequest
+		var seenStatus int
+
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "audit", r.Header.Get("X-Audit"))
+			require.Equal(t, "/accounts/abc/transfers", r.URL.Path)
+			require.Equal(t, "5", r.URL.Query().Get("count"))
+			w.Header().Set("X-Request-ID", "req-1")
+			w.WriteHeader(http.StatusOK)
+		}, WithMiddleware(func(next CallHandler) CallHandler {
+			return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+				seen = req
+				req.Headers["X-Audit"] = "audit"
+
+				resp, err := next(ctx, req)
+				if err == nil {
+					seenStatus = resp.StatusCode()
+					require.Equal(t, "req-1", resp.RequestId())
+				}
+				return resp, err
+			}
+		}))
+
+		_, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, pathTransfers, "abc"), Count(5))
+		require.NoError(t, err)
+
+		require.Equal(t, http.MethodGet, seen.Method)
+		require.Equal(t, "/accounts/abc/transfers", seen.Path)
+		require.Equal(t, "/accounts/{id}/transfers", seen.PathTemplate)
+		require.Equal(t, "5", seen.Params["count"])
+		require.Equal(t, http.StatusOK, seenStatus)
+
+		// authentication is applied after the configured middleware
+		_, ok := seen.Headers["Authorization"]
+		require.False(t, ok)
+	})
+
+	t.Run("can short-circuit the call", func(t *testing.T) {
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.FailNow(t, "request should not reach the server")
+		}, WithMiddleware(func(next CallHandler) CallHandler {
+			return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+				resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+				resp.Header.Set("Content-Type", "application/json")
+				return NewHttpCallResponse(resp, []byte(`{"accountID":"fake"}`)), nil
+			}
+		}))
+
+		account, err := c.GetAccount(context.Background(), "fake")
+		require.NoError(t, err)
+		require.Equal(t, "fake", account.AccountID)
+	})
+
+	t.Run("runs in the order given", func(t *testing.T) {
+		var order []string
+		record := func(name string) Middleware {
+			return func(next CallHandler) CallHandler {
+				return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+					order = append(order, name+" before")
+					resp, err := next(ctx, req)
+					order = append(order, name+" after")
+					return resp, err
+				}
+			}
+		}
+
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}, WithMiddleware(record("first"), record("second")))
+
+		_, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/ping"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"first before", "second before", "second after", "first after"}, order)
+	})
+
+	t.Run("can set its own Authorization header", func(t *testing.T) {
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "Bearer from-middleware", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+		}, WithMiddleware(func(next CallHandler) CallHandler {
+			return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+				req.Headers["Authorization"] = "Bearer from-middleware"
+				return next(ctx, req)
+			}
+		}))
+
+		_, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/ping"))
+		require.NoError(t, err)
+	})
+
+	t.Run("sends Basic auth by default", func(t *testing.T) {
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			pk, sk, ok := r.BasicAuth()
+			require.True(t, ok)
+			require.Equal(t, "pk", pk)
+			require.Equal(t, "sk", sk)
+			require.True(t, strings.HasPrefix(r.Header.Get("User-Agent"), "moov-go/"))
+			w.WriteHeader(http.StatusOK)
+		})
+
+		_, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/ping"))
+		require.NoError(t, err)
+	})
+}
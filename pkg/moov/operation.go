@@ -0,0 +1,97 @@
+package moov
+
+import (
+	"context"
+	"time"
+)
+
+// Backoff returns how long to wait before the given poll attempt, starting at 1.
+type Backoff func(attempt int) time.Duration
+
+// ExponentialBackoff waits initial before the first poll and doubles the wait up to maxWait, with jitter.
+func ExponentialBackoff(initial, maxWait time.Duration) Backoff {
+	p := RetryPolicy{InitialBackoff: initial, MaxBackoff: maxWait}
+	return p.backoff
+}
+
+// DefaultOperationBackoff is used by Operation.Wait when no backoff is given.
+var DefaultOperationBackoff = ExponentialBackoff(time.Second, 30*time.Second)
+
+// Operation is the result of a call Moov may finish asynchronously, e.g. a transfer started with
+// a 202 response. It holds the latest known state of the resource and polls its Get endpoint
+// until the resource reaches a terminal state. It's not safe for concurrent use.
+type Operation[T any] struct {
+	result *T
+	done   func(result *T) bool
+	get    func(ctx context.Context) (*T, error)
+}
+
+// NewOperation returns an operation starting at result, which may be nil if Moov didn't return
+// the resource yet. done reports if a result is terminal and get fetches the current one.
+func NewOperation[T any](result *T, done func(result *T) bool, get func(ctx context.Context) (*T, error)) *Operation[T] {
+	return &Operation[T]{result: result, done: done, get: get}
+}
+
+// Done reports if the resource reached a terminal state.
+func (o *Operation[T]) Done() bool {
+	return o.result != nil && o.done(o.result)
+}
+
+// Result returns the latest known state of the resource, which isn't terminal until Done
+// returns true. It's nil until Moov returned the resource.
+func (o *Operation[T]) Result() *T {
+	return o.result
+}
+
+// Poll fetches the current state of the resource unless it's already done, and reports if it is now.
+func (o *Operation[T]) Poll(ctx context.Context) (bool, error) {
+	if o.Done() {
+		return true, nil
+	}
+
+	result, err := o.get(ctx)
+	if err != nil {
+		return false, err
+	}
+	o.result = result
+
+	return o.Done(), nil
+}
+
+// Wait polls until the resource reaches a terminal state and returns it. A nil backoff uses
+// DefaultOperationBackoff. It stops at the first failed poll or when ctx is done, returning the
+// error along with the latest known result.
+func (o *Operation[T]) Wait(ctx context.Context, backoff Backoff) (*T, error) {
+	if backoff == nil {
+		backoff = DefaultOperationBackoff
+	}
+
+	for attempt := 1; !o.Done(); attempt++ {
+		timer := time.NewTimer(backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return o.result, ctx.Err()
+		case <-timer.C:
+		}
+
+		if _, err := o.Poll(ctx); err != nil {
+			return o.result, err
+		}
+	}
+
+	return o.result, nil
+}
+
+func transferDone(t *Transfer) bool {
+	switch t.Status {
+	case TransferStatus_Completed, TransferStatus_Failed, TransferStatus_Reversed, TransferStatus_Canceled:
+		return true
+	default:
+		return false
+	}
+}
+
+func refundDone(r *Refund) bool {
+	return r.Status == RefundStatus_Completed || r.Status == RefundStatus_Failed
+}
@@ -0,0 +1,80 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperation(t *testing.T) {
+	noWait := func(int) time.Duration { return time.Millisecond }
+
+	t.Run("transfer started then polled", func(t *testing.T) {
+		polls := 0
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusAccepted)
+				json.NewEncoder(w).Encode(TransferStarted{TransferID: "transfer"})
+				return
+			}
+
+			require.Equal(t, "/accounts/partner/transfers/transfer", r.URL.Path)
+			polls++
+			status := TransferStatus_Pending
+			if polls == 3 {
+				status = TransferStatus_Completed
+			}
+			json.NewEncoder(w).Encode(Transfer{TransferID: "transfer", Status: status})
+		})
+
+		op, err := c.CreateTransfer(context.Background(), "partner", CreateTransfer{}).Operation()
+		require.NoError(t, err)
+		require.False(t, op.Done())
+		require.Nil(t, op.Result())
+
+		done, err := op.Poll(context.Background())
+		require.NoError(t, err)
+		require.False(t, done)
+		require.Equal(t, TransferStatus_Pending, op.Result().Status)
+
+		transfer, err := op.Wait(context.Background(), noWait)
+		require.NoError(t, err)
+		require.Equal(t, TransferStatus_Completed, transfer.Status)
+		require.True(t, op.Done())
+		require.Equal(t, 3, polls)
+	})
+
+	t.Run("completed refund", func(t *testing.T) {
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(Refund{RefundID: "refund", Status: RefundStatus_Completed})
+		})
+
+		op, err := c.RefundTransferOperation(context.Background(), "partner", "transfer", CreateRefund{Amount: 100})
+		require.NoError(t, err)
+		require.True(t, op.Done())
+
+		refund, err := op.Wait(context.Background(), nil)
+		require.NoError(t, err)
+		require.Equal(t, "refund", refund.RefundID)
+	})
+
+	t.Run("wait stops with the context", func(t *testing.T) {
+		op := NewOperation(&Transfer{Status: TransferStatus_Pending}, transferDone, func(context.Context) (*Transfer, error) {
+			return &Transfer{Status: TransferStatus_Pending}, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		transfer, err := op.Wait(ctx, noWait)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, TransferStatus_Pending, transfer.Status)
+	})
+}
@@ -0,0 +1,99 @@
+package moov
+
+import (
+	"context"
+	"iter"
+	"strconv"
+)
+
+// DefaultPageSize is how many items the All* iterators request per page when no count
+// filter is given.
+const DefaultPageSize = 200
+
+// paginate walks a skip/count based list endpoint one page at a time. The page size and
+// starting offset are read from the count and skip filters passed in, so callers configure
+// iteration with the same filters as the matching List* call. Iteration stops after a short
+// page, at the first error or when the context is done.
+func paginate[T any, F callArg](ctx context.Context, filters []F, list func(skip, count int) ([]T, error)) iter.Seq2[T, error] {
+	skip, count := pageParams(filters)
+
+	return func(yield func(T, error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			items, err := list(skip, count)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) < count {
+				return
+			}
+			skip += len(items)
+		}
+	}
+}
+
+// paginateCursor walks a cursor based list endpoint, following each page's next cursor
+// until there isn't one.
+func paginateCursor[T any](ctx context.Context, list func(cursor string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		cursor := ""
+		for {
+			if err := ctx.Err(); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			items, next, err := list(cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if next == "" || next == cursor {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// pageParams reads the skip and count query params the filters would set.
+func pageParams[F callArg](filters []F) (skip, count int) {
+	call := &callBuilder{
+		params:  map[string]string{},
+		headers: map[string]string{},
+	}
+	for _, f := range filters {
+		// Errors surface when the filters are applied to the actual requests.
+		_ = f.apply(call)
+	}
+
+	skip, _ = strconv.Atoi(call.params["skip"])
+	count, _ = strconv.Atoi(call.params["count"])
+	if count <= 0 {
+		count = DefaultPageSize
+	}
+	return max(skip, 0), count
+}
@@ -0,0 +1,108 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllTransfers(t *testing.T) {
+	var requests []string
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		requests = append(requests, q.Get("skip")+"/"+q.Get("count")+"/"+q.Get("status"))
+
+		skip, _ := strconv.Atoi(q.Get("skip"))
+		count, _ := strconv.Atoi(q.Get("count"))
+
+		w.Header().Set("Content-Type", "application/json")
+		page := []Transfer{}
+		for i := skip; i < min(skip+count, 5); i++ {
+			page = append(page, Transfer{TransferID: fmt.Sprintf("t%d", i)})
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+
+	t.Run("pages until a short page", func(t *testing.T) {
+		requests = nil
+
+		var ids []string
+		for transfer, err := range c.AllTransfers(context.Background(), "accountID", WithTransferStatus("completed"), WithTransferCount(2)) {
+			require.NoError(t, err)
+			ids = append(ids, transfer.TransferID)
+		}
+		require.Equal(t, []string{"t0", "t1", "t2", "t3", "t4"}, ids)
+		require.Equal(t, []string{"0/2/completed", "2/2/completed", "4/2/completed"}, requests)
+	})
+
+	t.Run("starts at skip with the default page size", func(t *testing.T) {
+		requests = nil
+
+		var ids []string
+		for transfer, err := range c.AllTransfers(context.Background(), "accountID", WithTransferSkip(3)) {
+			require.NoError(t, err)
+			ids = append(ids, transfer.TransferID)
+		}
+		require.Equal(t, []string{"t3", "t4"}, ids)
+		require.Equal(t, []string{fmt.Sprintf("3/%d/", DefaultPageSize)}, requests)
+	})
+
+	t.Run("stops early", func(t *testing.T) {
+		requests = nil
+
+		for range c.AllTransfers(context.Background(), "accountID", WithTransferCount(2)) {
+			break
+		}
+		require.Len(t, requests, 1)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		requests = nil
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var errs []error
+		for _, err := range c.AllTransfers(ctx, "accountID", WithTransferCount(2)) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			cancel()
+		}
+		require.Len(t, requests, 1)
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], context.Canceled)
+	})
+}
+
+func TestAllTickets(t *testing.T) {
+	var cursors []string
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch cursor {
+		case "":
+			json.NewEncoder(w).Encode(ListTicket{
+				Items:    []Ticket{{ID: "a"}, {ID: "b"}},
+				NextPage: &ListTicketNextPage{Cursor: "next"},
+			})
+		case "next":
+			json.NewEncoder(w).Encode(ListTicket{Items: []Ticket{{ID: "c"}}})
+		}
+	})
+
+	var ids []string
+	for ticket, err := range c.AllTickets(context.Background(), "accountID") {
+		require.NoError(t, err)
+		ids = append(ids, ticket.ID)
+	}
+	require.Equal(t, []string{"a", "b", "c"}, ids)
+	require.Equal(t, []string{"", "next"}, cursors)
+}
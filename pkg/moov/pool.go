@@ -0,0 +1,147 @@
+package moov
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// TenantConfig is how a ClientPool builds the client of a tenant.
+type TenantConfig struct {
+	Credentials Credentials
+
+	// Options applied to the tenant's client after the pool's options, e.g. a WithRateLimit
+	// for a tenant with a different limit.
+	Options []ClientConfigurable
+
+	// Scopes, when set, authenticate the tenant's calls with bearer tokens for them from a
+	// TokenSource of its own instead of the secret key.
+	Scopes []ScopeBuilder
+}
+
+// CredentialsProvider returns the config of a tenant, e.g. by reading its API keys from a
+// secret manager. It's called the first time a tenant is used and every time it's rotated.
+type CredentialsProvider func(ctx context.Context, tenant string) (TenantConfig, error)
+
+// ClientPool builds and caches a Client per tenant, for platforms operating several Moov
+// partner accounts with different API keys. It's safe for concurrent use.
+//
+// Clients share the pool's http.Client, and with it the connections to Moov, while rate
+// limiters, circuit breakers and token sources are built for each tenant so one busy tenant
+// doesn't slow down the others.
+//
+// Stores given in the pool's options are shared by every tenant. The pool namespaces the keys
+// of a shared IdempotencyStore by tenant, so tenants may use the same business keys, and a
+// CacheConfig.Store keeps responses per credentials.
+type ClientPool struct {
+	provider CredentialsProvider
+	options  []ClientConfigurable
+
+	mu      sync.Mutex
+	tenants map[string]*pooledClient
+}
+
+type pooledClient struct {
+	ready  chan struct{}
+	client *Client
+	err    error
+}
+
+// NewClientPool returns a pool building clients from the provider's config. The options are
+// applied to every client, where options like WithRateLimit give each tenant a limiter of its
+// own. Avoid WithTokenSource, it would share a single token source between tenants; set
+// TenantConfig.Scopes instead.
+func NewClientPool(provider CredentialsProvider, options ...ClientConfigurable) *ClientPool {
+	return &ClientPool{
+		provider: provider,
+		options:  append([]ClientConfigurable{WithHttpClient(DefaultHttpClient())}, options...),
+		tenants:  map[string]*pooledClient{},
+	}
+}
+
+// Client returns the client of the tenant, building it on first use. Concurrent calls for a
+// tenant that isn't built yet wait for a single build. A failed build isn't cached.
+func (p *ClientPool) Client(ctx context.Context, tenant string) (*Client, error) {
+	p.mu.Lock()
+	pc, ok := p.tenants[tenant]
+	if !ok {
+		pc = &pooledClient{ready: make(chan struct{})}
+		p.tenants[tenant] = pc
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		pc.client, pc.err = p.build(ctx, tenant)
+		close(pc.ready)
+
+		if pc.err != nil {
+			p.mu.Lock()
+			if p.tenants[tenant] == pc {
+				delete(p.tenants, tenant)
+			}
+			p.mu.Unlock()
+		}
+		return pc.client, pc.err
+	}
+
+	select {
+	case <-pc.ready:
+		return pc.client, pc.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Rotate rebuilds the client of the tenant with fresh config from the provider, e.g. after
+// its API keys were rotated. Calls already made with the previous client finish with it, only
+// calls getting the client afterwards use the new one. The previous client is kept when the
+// provider or the build fails.
+func (p *ClientPool) Rotate(ctx context.Context, tenant string) error {
+	client, err := p.build(ctx, tenant)
+	if err != nil {
+		return err
+	}
+
+	pc := &pooledClient{ready: make(chan struct{}), client: client}
+	close(pc.ready)
+
+	p.mu.Lock()
+	p.tenants[tenant] = pc
+	p.mu.Unlock()
+
+	return nil
+}
+
+// Remove drops the client of the tenant from the pool. Calls already made with it aren't affected.
+func (p *ClientPool) Remove(tenant string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.tenants, tenant)
+}
+
+func (p *ClientPool) build(ctx context.Context, tenant string) (*Client, error) {
+	cfg, err := p.provider(ctx, tenant)
+	if err != nil {
+		return nil, fmt.Errorf("getting credentials of tenant %s: %w", tenant, err)
+	}
+
+	creds := cfg.Credentials
+	creds.Host = cmp.Or(creds.Host, CredentialsFromEnv().Host)
+
+	options := append(append(p.options[:len(p.options):len(p.options)], cfg.Options...), WithCredentials(creds))
+	client, err := NewClient(options...)
+	if err != nil {
+		return nil, fmt.Errorf("building client of tenant %s: %w", tenant, err)
+	}
+	client.tenant = tenant
+
+	if len(cfg.Scopes) > 0 {
+		if err := WithTokenSource(NewTokenSource(client), cfg.Scopes...)(client); err != nil {
+			return nil, fmt.Errorf("building client of tenant %s: %w", tenant, err)
+		}
+	}
+
+	return client, nil
+}
@@ -0,0 +1,190 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientPool(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		seen     []string
+		started  = make(chan struct{})
+		release  = make(chan struct{})
+		blocking atomic.Bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if blocking.CompareAndSwap(true, false) {
+			close(started)
+			<-release
+		}
+		user, _, _ := r.BasicAuth()
+		mu.Lock()
+		seen = append(seen, user)
+		mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+
+	var (
+		builds  atomic.Int32
+		version atomic.Int32
+	)
+	provider := func(_ context.Context, tenant string) (TenantConfig, error) {
+		builds.Add(1)
+		return TenantConfig{Credentials: Credentials{
+			PublicKey: tenant + "-pk" + strings.Repeat("'", int(version.Load())),
+			SecretKey: "sk",
+			Host:      strings.TrimPrefix(srv.URL, "http://"),
+		}}, nil
+	}
+	pool := NewClientPool(provider, WithMoovURLScheme("http"), WithRateLimit(100))
+	ctx := context.Background()
+
+	t.Run("builds a client per tenant once", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				c, err := pool.Client(ctx, "a")
+				require.NoError(t, err)
+				require.NoError(t, c.Ping(ctx))
+			})
+		}
+		wg.Wait()
+		require.Equal(t, int32(1), builds.Load())
+
+		a, err := pool.Client(ctx, "a")
+		require.NoError(t, err)
+		b, err := pool.Client(ctx, "b")
+		require.NoError(t, err)
+		require.NoError(t, b.Ping(ctx))
+
+		require.Same(t, a.HttpClient, b.HttpClient)
+		require.NotSame(t, a.rateLimiter, b.rateLimiter)
+		require.Contains(t, seen, "a-pk")
+		require.Contains(t, seen, "b-pk")
+	})
+
+	t.Run("rotation keeps in-flight calls", func(t *testing.T) {
+		old, err := pool.Client(ctx, "a")
+		require.NoError(t, err)
+
+		blocking.Store(true)
+		done := make(chan error)
+		go func() { done <- old.Ping(ctx) }()
+		<-started
+
+		version.Add(1)
+		require.NoError(t, pool.Rotate(ctx, "a"))
+		rotated, err := pool.Client(ctx, "a")
+		require.NoError(t, err)
+		require.NotSame(t, old, rotated)
+		require.NoError(t, rotated.Ping(ctx))
+
+		close(release)
+		require.NoError(t, <-done)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, []string{"a-pk'", "a-pk"}, seen[len(seen)-2:])
+	})
+}
+
+func TestClientPool_Errors(t *testing.T) {
+	fail := true
+	pool := NewClientPool(func(_ context.Context, tenant string) (TenantConfig, error) {
+		if fail {
+			return TenantConfig{}, ErrCredentialsNotSet
+		}
+		return TenantConfig{Credentials: Credentials{PublicKey: "pk", SecretKey: "sk"}}, nil
+	})
+
+	_, err := pool.Client(context.Background(), "a")
+	require.ErrorIs(t, err, ErrCredentialsNotSet)
+
+	fail = false
+	c, err := pool.Client(context.Background(), "a")
+	require.NoError(t, err)
+
+	fail = true
+	require.ErrorIs(t, pool.Rotate(context.Background(), "a"), ErrCredentialsNotSet)
+	kept, err := pool.Client(context.Background(), "a")
+	require.NoError(t, err)
+	require.Same(t, c, kept)
+}
+
+func TestClientPool_SharedIdempotencyStore(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("X-Idempotency-Key"))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(TransferStarted{TransferID: r.URL.Path})
+	}))
+	t.Cleanup(srv.Close)
+
+	pool := NewClientPool(func(_ context.Context, tenant string) (TenantConfig, error) {
+		return TenantConfig{Credentials: Credentials{
+			PublicKey: tenant + "-pk",
+			SecretKey: "sk",
+			Host:      strings.TrimPrefix(srv.URL, "http://"),
+		}}, nil
+	}, WithMoovURLScheme("http"), WithIdempotencyStore(NewMemoryIdempotencyStore()))
+	ctx := ContextWithBusinessKey(context.Background(), "payout-1")
+
+	// Both tenants use the same business key for their own transfer.
+	for _, tenant := range []string{"a", "b"} {
+		c, err := pool.Client(ctx, tenant)
+		require.NoError(t, err)
+
+		started, err := c.CreateTransfer(ctx, tenant, CreateTransfer{}).Started()
+		require.NoError(t, err)
+		require.Equal(t, "/accounts/"+tenant+"/transfers", started.TransferID)
+	}
+	require.Len(t, keys, 2)
+	require.NotEqual(t, keys[0], keys[1])
+}
+
+func TestClientPool_SharedResponseCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Brand{Colors: BrandColors{Dark: BrandColor{Accent: user}}})
+	}))
+	t.Cleanup(srv.Close)
+
+	store := NewLRUResponseCache(10)
+	pool := NewClientPool(func(_ context.Context, tenant string) (TenantConfig, error) {
+		return TenantConfig{Credentials: Credentials{
+			PublicKey: tenant + "-pk",
+			SecretKey: "sk",
+			Host:      strings.TrimPrefix(srv.URL, "http://"),
+		}}, nil
+	}, WithMoovURLScheme("http"), WithResponseCache(CacheConfig{Store: store}))
+	ctx := context.Background()
+
+	// Both tenants read the branding of an account with the same ID from one store.
+	for range 2 {
+		for _, tenant := range []string{"a", "b"} {
+			c, err := pool.Client(ctx, tenant)
+			require.NoError(t, err)
+
+			brand, err := c.GetAccountBranding(ctx, "account")
+			require.NoError(t, err)
+			require.Equal(t, tenant+"-pk", brand.Colors.Dark.Accent)
+		}
+	}
+	require.Equal(t, 2, store.Len())
+}
@@ -2,6 +2,7 @@ package moov
 
 import (
 	"context"
+	"iter"
 	"net/http"
 )
 
@@ -31,6 +32,14 @@ func (c Client) ListProducts(ctx context.Context, accountID string, filters ...P
 	return CompletedListOrError[Product](resp)
 }
 
+// AllProducts iterates over all products matching the filters, fetching pages of
+// WithProductCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllProducts(ctx context.Context, accountID string, filters ...ProductListFilter) iter.Seq2[Product, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]Product, error) {
+		return c.ListProducts(ctx, accountID, append(filters[:len(filters):len(filters)], WithProductSkip(skip), WithProductCount(count))...)
+	})
+}
+
 // GetProduct retrieves a product by ID.
 // https://docs.moov.io/api/tools/products/get/
 func (c Client) GetProduct(ctx context.Context, accountID string, productID string) (*Product, error) {
@@ -0,0 +1,258 @@
+package moov
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const ENV_MOOV_PROFILE = "MOOV_PROFILE"
+const ENV_MOOV_CREDENTIALS_FILE = "MOOV_CREDENTIALS_FILE"
+
+var (
+	ErrProfileNotFound = errors.New("credentials profile not found")
+	ErrModeMismatch    = errors.New("credentials are for a different mode than expected")
+)
+
+// CredentialsFile is the layout of a credentials file, by default ~/.moov/credentials.yaml. JSON
+// files with the same layout are read as well.
+//
+//	default: sandbox
+//	profiles:
+//	  sandbox:
+//	    public_key: ...
+//	    secret_key: ...
+//	    mode: sandbox
+//	  production:
+//	    public_key: ...
+//	    secret_key: ...
+//	    mode: production
+//	    account_id: ...
+type CredentialsFile struct {
+	// Default names the profile used when none is selected.
+	Default  string                        `yaml:"default,omitempty"`
+	Profiles map[string]CredentialsProfile `yaml:"profiles"`
+}
+
+// CredentialsProfile is a named set of credentials along with the mode they're expected to be for.
+type CredentialsProfile struct {
+	Credentials `yaml:",inline"`
+
+	// Mode the credentials must be for, checked on the first call made by the client.
+	Mode Mode `yaml:"mode"`
+
+	// AccountID of an account the credentials can read, used to check their mode. The first
+	// account listed is used if it's not set.
+	AccountID string `yaml:"account_id,omitempty"`
+}
+
+// DefaultCredentialsFile returns the file profiles are read from: MOOV_CREDENTIALS_FILE if set,
+// otherwise ~/.moov/credentials.yaml.
+func DefaultCredentialsFile() (string, error) {
+	if path := os.Getenv(ENV_MOOV_CREDENTIALS_FILE); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".moov", "credentials.yaml"), nil
+}
+
+// LoadCredentialsProfile reads the named profile from the credentials file at path. An empty
+// path reads DefaultCredentialsFile and an empty name selects the profile named by MOOV_PROFILE,
+// falling back to the file's default.
+func LoadCredentialsProfile(path, name string) (*CredentialsProfile, error) {
+	if path == "" {
+		p, err := DefaultCredentialsFile()
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading credentials file: %w", err)
+	}
+
+	var file CredentialsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing credentials file %s: %w", path, err)
+	}
+
+	name = cmp.Or(name, os.Getenv(ENV_MOOV_PROFILE), file.Default)
+	if name == "" {
+		return nil, fmt.Errorf("%w: no profile selected and %s has no default", ErrProfileNotFound, path)
+	}
+
+	profile, ok := file.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s in %s", ErrProfileNotFound, name, path)
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", name, err)
+	}
+
+	return &profile, nil
+}
+
+func (p *CredentialsProfile) Validate() error {
+	if err := p.Credentials.Validate(); err != nil {
+		return err
+	}
+	if !slices.Contains([]Mode{MODE_SANDBOX, MODE_PRODUCTION}, p.Mode) {
+		return fmt.Errorf("mode must be %s or %s, but was %q", MODE_SANDBOX, MODE_PRODUCTION, p.Mode)
+	}
+	return nil
+}
+
+// credentials returns the profile's credentials, defaulting the host like CredentialsFromEnv.
+func (p *CredentialsProfile) credentials() Credentials {
+	creds := p.Credentials
+	creds.Host = cmp.Or(creds.Host, CredentialsFromEnv().Host)
+	return creds
+}
+
+// WithProfile uses the credentials of the named profile from DefaultCredentialsFile and checks
+// they're for the profile's mode. An empty name selects the profile the same way
+// LoadCredentialsProfile does.
+func WithProfile(name string) ClientConfigurable {
+	return WithProfileFrom("", name)
+}
+
+// WithProfileFrom is WithProfile reading the credentials file at path.
+func WithProfileFrom(path, name string) ClientConfigurable {
+	return func(c *Client) error {
+		profile, err := LoadCredentialsProfile(path, name)
+		if err != nil {
+			return err
+		}
+
+		c.Credentials = profile.credentials()
+		c.modeCheck = &modeCheck{expected: profile.Mode, accountID: profile.AccountID}
+		return nil
+	}
+}
+
+// WithExpectedMode fails every call made by the client with ErrModeMismatch unless its
+// credentials are for the given mode, e.g. so a test suite can never run with production keys.
+// The mode is checked once, before the first call, by pinging Moov and looking up an account.
+func WithExpectedMode(mode Mode) ClientConfigurable {
+	return func(c *Client) error {
+		c.modeCheck = &modeCheck{expected: mode}
+		return nil
+	}
+}
+
+type modeCheck struct {
+	expected  Mode
+	accountID string
+
+	mu       sync.Mutex
+	verified bool
+	err      error
+	running  *modeCheckRun
+}
+
+// modeCheckRun is a check in progress shared by every call waiting for it.
+type modeCheckRun struct {
+	done chan struct{}
+	err  error
+}
+
+type modeCheckContextKey struct{}
+
+// modeMiddleware holds back every call until the credentials are known to be for the expected mode.
+func (c *Client) modeMiddleware(next CallHandler) CallHandler {
+	return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+		if ctx.Value(modeCheckContextKey{}) == nil {
+			if err := c.modeCheck.verify(ctx, c); err != nil {
+				return nil, err
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// verify checks the mode of the credentials once, with calls made meanwhile waiting for the
+// check in progress. A mismatch is remembered, other failures are retried on the next call.
+func (m *modeCheck) verify(ctx context.Context, c *Client) error {
+	m.mu.Lock()
+	if m.verified || m.err != nil {
+		m.mu.Unlock()
+		return m.err
+	}
+
+	run := m.running
+	if run == nil {
+		run = &modeCheckRun{done: make(chan struct{})}
+		m.running = run
+		m.mu.Unlock()
+
+		run.err = m.check(ctx, c)
+
+		m.mu.Lock()
+		m.running = nil
+		if run.err == nil {
+			m.verified = true
+		} else if errors.Is(run.err, ErrModeMismatch) {
+			m.err = run.err
+		}
+		m.mu.Unlock()
+		close(run.done)
+		return run.err
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-run.done:
+		return run.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// check pings Moov and compares the mode of an account to the expected one.
+func (m *modeCheck) check(ctx context.Context, c *Client) error {
+	ctx = context.WithValue(ctx, modeCheckContextKey{}, true)
+	if err := c.Ping(ctx); err != nil {
+		return fmt.Errorf("checking credentials mode: %w", err)
+	}
+
+	mode, err := m.lookupMode(ctx, c)
+	if err != nil {
+		return fmt.Errorf("checking credentials mode: %w", err)
+	}
+	if mode != m.expected {
+		return fmt.Errorf("%w: expected %s but they're for %s", ErrModeMismatch, m.expected, mode)
+	}
+	return nil
+}
+
+func (m *modeCheck) lookupMode(ctx context.Context, c *Client) (Mode, error) {
+	if m.accountID != "" {
+		account, err := c.GetAccount(ctx, m.accountID)
+		if err != nil {
+			return "", err
+		}
+		return account.Mode, nil
+	}
+
+	accounts, err := c.ListAccounts(ctx, WithAccountCount(1))
+	if err != nil {
+		return "", err
+	}
+	if len(accounts) == 0 {
+		return "", errors.New("no account to read the mode from, set the profile's account_id")
+	}
+	return accounts[0].Mode, nil
+}
@@ -0,0 +1,171 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCredentialsFile = `
+default: sandbox
+profiles:
+  sandbox:
+    public_key: sandbox-pk
+    secret_key: sandbox-sk
+    mode: sandbox
+  production:
+    public_key: production-pk
+    secret_key: production-sk
+    host: api.example.com
+    mode: production
+    account_id: partner
+  nomode:
+    public_key: pk
+    secret_key: sk
+`
+
+func TestLoadCredentialsProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testCredentialsFile), 0o600))
+
+	t.Run("default profile", func(t *testing.T) {
+		t.Setenv(ENV_MOOV_PROFILE, "")
+
+		profile, err := LoadCredentialsProfile(path, "")
+		require.NoError(t, err)
+		require.Equal(t, "sandbox-pk", profile.PublicKey)
+		require.Equal(t, MODE_SANDBOX, profile.Mode)
+	})
+
+	t.Run("selected by env", func(t *testing.T) {
+		t.Setenv(ENV_MOOV_PROFILE, "production")
+
+		profile, err := LoadCredentialsProfile(path, "")
+		require.NoError(t, err)
+		require.Equal(t, "api.example.com", profile.Host)
+		require.Equal(t, "partner", profile.AccountID)
+
+		profile, err = LoadCredentialsProfile(path, "sandbox")
+		require.NoError(t, err)
+		require.Equal(t, MODE_SANDBOX, profile.Mode)
+	})
+
+	t.Run("file from env", func(t *testing.T) {
+		t.Setenv(ENV_MOOV_CREDENTIALS_FILE, path)
+
+		profile, err := LoadCredentialsProfile("", "production")
+		require.NoError(t, err)
+		require.Equal(t, MODE_PRODUCTION, profile.Mode)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		jsonPath := filepath.Join(t.TempDir(), "credentials.json")
+		require.NoError(t, os.WriteFile(jsonPath, []byte(`{"profiles":{"ci":{"public_key":"pk","secret_key":"sk","mode":"sandbox"}}}`), 0o600))
+
+		profile, err := LoadCredentialsProfile(jsonPath, "ci")
+		require.NoError(t, err)
+		require.Equal(t, "pk", profile.PublicKey)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := LoadCredentialsProfile(path, "missing")
+		require.ErrorIs(t, err, ErrProfileNotFound)
+
+		_, err = LoadCredentialsProfile(path, "nomode")
+		require.ErrorContains(t, err, "mode must be")
+	})
+}
+
+func TestExpectedMode(t *testing.T) {
+	newServer := func(mode Mode) (*atomic.Int32, http.HandlerFunc) {
+		var calls atomic.Int32
+		return &calls, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/ping":
+			case r.URL.Path == "/accounts":
+				json.NewEncoder(w).Encode([]Account{{AccountID: "first", Mode: mode}})
+			case strings.HasPrefix(r.URL.Path, "/accounts/"):
+				json.NewEncoder(w).Encode(Account{AccountID: "partner", Mode: mode})
+			case r.URL.Path == "/wallets":
+				calls.Add(1)
+			}
+		}
+	}
+
+	t.Run("matching mode", func(t *testing.T) {
+		calls, handler := newServer(MODE_SANDBOX)
+		c := newLocalTestClient(t, handler, WithExpectedMode(MODE_SANDBOX))
+
+		for range 2 {
+			_, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/wallets"))
+			require.NoError(t, err)
+		}
+		require.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("production credentials refused", func(t *testing.T) {
+		calls, handler := newServer(MODE_PRODUCTION)
+		c := newLocalTestClient(t, handler, WithExpectedMode(MODE_SANDBOX))
+
+		_, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/wallets"))
+		require.ErrorIs(t, err, ErrModeMismatch)
+		require.Zero(t, calls.Load())
+	})
+
+	t.Run("concurrent calls share one check", func(t *testing.T) {
+		var pings atomic.Int32
+		pinged, release := make(chan struct{}), make(chan struct{})
+		_, handler := newServer(MODE_SANDBOX)
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/ping" && pings.Add(1) == 1 {
+				close(pinged)
+				<-release
+			}
+			handler(w, r)
+		}, WithExpectedMode(MODE_SANDBOX))
+
+		first := make(chan error)
+		go func() {
+			_, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/wallets"))
+			first <- err
+		}()
+		<-pinged
+
+		// A caller waiting for the check in progress can give up.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := c.CallHttp(ctx, Endpoint(http.MethodGet, "/wallets"))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		close(release)
+		require.NoError(t, <-first)
+		_, err = c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/wallets"))
+		require.NoError(t, err)
+		require.Equal(t, int32(1), pings.Load())
+	})
+
+	t.Run("profile", func(t *testing.T) {
+		_, handler := newServer(MODE_SANDBOX)
+		host := newLocalTestClient(t, handler).Credentials.Host
+
+		path := filepath.Join(t.TempDir(), "credentials.yaml")
+		profiles := "profiles:\n  live:\n    public_key: pk\n    secret_key: sk\n    host: " + host + "\n    mode: production\n    account_id: partner\n"
+		require.NoError(t, os.WriteFile(path, []byte(profiles), 0o600))
+
+		c, err := NewClient(WithProfileFrom(path, "live"), WithMoovURLScheme("http"))
+		require.NoError(t, err)
+
+		_, err = c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/wallets"))
+		require.ErrorIs(t, err, ErrModeMismatch)
+		require.ErrorContains(t, err, "they're for sandbox")
+	})
+}
@@ -3,6 +3,12 @@ package moov
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/time/rate"
 )
@@ -19,12 +25,217 @@ func WithRateLimit(rps int) ClientConfigurable {
 	}
 }
 
+// RateLimitBucket is a group of endpoints sharing an adaptive rate limit.
+type RateLimitBucket struct {
+	// Name of the bucket, e.g. "transfers" or "reads".
+	Name string
+
+	// Requests per second allowed when Moov isn't pushing back.
+	RPS float64
+
+	// Number of requests allowed at once. Defaults to 1.
+	Burst int
+
+	// Lowest rate the bucket slows down to after 429 responses. Defaults to a tenth of RPS.
+	MinRPS float64
+
+	// Match selects the requests counted against this bucket. Nil matches every request.
+	Match func(req *CallRequest) bool
+}
+
+// MatchPaths matches requests whose path template contains any of the given fragments, e.g. "/transfers".
+func MatchPaths(fragments ...string) func(req *CallRequest) bool {
+	return func(req *CallRequest) bool {
+		return slices.ContainsFunc(fragments, func(f string) bool {
+			return strings.Contains(req.PathTemplate, f)
+		})
+	}
+}
+
+// MatchMethods matches requests made with any of the given HTTP methods.
+func MatchMethods(methods ...string) func(req *CallRequest) bool {
+	return func(req *CallRequest) bool {
+		return slices.Contains(methods, req.Method)
+	}
+}
+
+// WithAdaptiveRateLimit limits calls with separate buckets per group of endpoints, so bursty
+// traffic on one group can't starve another. Each request uses the first bucket matching it,
+// requests matching no bucket are not limited.
+//
+// Buckets adapt to Moov: a 429 response halves the bucket's rate and pauses it for the time given
+// by Retry-After, and rate-limit headers reporting no remaining requests pause it until the reset.
+// Successful responses bring the rate back up to the configured RPS.
+//
+//	moov.WithAdaptiveRateLimit(
+//		moov.RateLimitBucket{Name: "transfers", RPS: 20, Burst: 5, Match: moov.MatchPaths("/transfers")},
+//		moov.RateLimitBucket{Name: "reads", RPS: 50, Burst: 10, Match: moov.MatchMethods(http.MethodGet)},
+//	)
+func WithAdaptiveRateLimit(buckets ...RateLimitBucket) ClientConfigurable {
+	return func(c *Client) error {
+		for _, b := range buckets {
+			if b.RPS <= 0 {
+				return fmt.Errorf("rate limit bucket %q rps must be positive, but was %v", b.Name, b.RPS)
+			}
+			if b.Burst <= 0 {
+				b.Burst = 1
+			}
+			if b.MinRPS <= 0 || b.MinRPS > b.RPS {
+				b.MinRPS = b.RPS / 10
+			}
+
+			c.rateLimitBuckets = append(c.rateLimitBuckets, &adaptiveBucket{
+				config:  b,
+				limiter: rate.NewLimiter(rate.Limit(b.RPS), b.Burst),
+				now:     time.Now,
+			})
+		}
+		return nil
+	}
+}
+
+type adaptiveBucket struct {
+	config  RateLimitBucket
+	limiter *rate.Limiter
+	now     func() time.Time
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// wait blocks until the bucket allows another request or the context is done.
+func (b *adaptiveBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	pause := b.pausedUntil.Sub(b.now())
+	b.mu.Unlock()
+
+	if pause > 0 {
+		timer := time.NewTimer(pause)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return limiterWaitError(ctx, b.limiter.Wait(ctx))
+}
+
+// observe adjusts the rate of the bucket to the response from Moov.
+func (b *adaptiveBucket) observe(resp HttpCallResponse) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	limit := float64(b.limiter.Limit())
+
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		b.limiter.SetLimitAt(now, rate.Limit(max(limit/2, b.config.MinRPS)))
+		if wait, ok := retryAfter(resp.Header().Get("Retry-After")); ok {
+			b.pauseUntil(now.Add(wait))
+		}
+		return
+	}
+
+	if reset, ok := rateLimitReset(resp.Header(), now); ok {
+		b.pauseUntil(reset)
+	}
+
+	if limit < b.config.RPS {
+		b.limiter.SetLimitAt(now, rate.Limit(min(limit+b.config.RPS/10, b.config.RPS)))
+	}
+}
+
+func (b *adaptiveBucket) pauseUntil(t time.Time) {
+	if t.After(b.pausedUntil) {
+		b.pausedUntil = t
+	}
+}
+
+// rateLimitReset returns when requests are allowed again if the rate-limit headers report no
+// requests remaining in the current window. The reset is given either in seconds from now or,
+// for large values, as a unix timestamp.
+func rateLimitReset(h http.Header, now time.Time) (time.Time, bool) {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		remaining, err := strconv.Atoi(h.Get(prefix + "Remaining"))
+		if err != nil || remaining > 0 {
+			continue
+		}
+
+		reset, err := strconv.ParseInt(h.Get(prefix+"Reset"), 10, 64)
+		if err != nil || reset < 0 {
+			continue
+		}
+
+		if reset > unixTimestampThreshold {
+			return time.Unix(reset, 0), true
+		}
+		return now.Add(time.Duration(reset) * time.Second), true
+	}
+	return time.Time{}, false
+}
+
+// Reset values above this are unix timestamps rather than seconds to wait.
+const unixTimestampThreshold = 1_000_000_000
+
+func (c *Client) rateLimitBucket(req *CallRequest) *adaptiveBucket {
+	for _, b := range c.rateLimitBuckets {
+		if b.config.Match == nil || b.config.Match(req) {
+			return b
+		}
+	}
+	return nil
+}
+
 // waitForSlot blocks until a rate limit slot is available, if a rate limiter is configured
 // on the client. If the client or rate limiter is nil, it returns immediately without blocking.
-func (c *Client) waitForSlot(ctx context.Context) {
+// It returns the context's error instead if the context is done before a slot is available.
+func (c *Client) waitForSlot(ctx context.Context) error {
 	if c == nil || c.rateLimiter == nil {
-		return
+		return nil
 	}
 
-	c.rateLimiter.Wait(ctx)
+	return limiterWaitError(ctx, c.rateLimiter.Wait(ctx))
+}
+
+// limiterWaitError turns an error from rate.Limiter.Wait into the context's error. The limiter
+// fails early when waiting would run past the context's deadline, which is reported as
+// context.DeadlineExceeded.
+func limiterWaitError(ctx context.Context, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case ctx.Err() != nil:
+		return ctx.Err()
+	default:
+		if _, ok := ctx.Deadline(); ok {
+			return context.DeadlineExceeded
+		}
+		return err
+	}
+}
+
+// rateLimitMiddleware waits for a rate limit slot before every request sent to Moov and feeds
+// the responses back to the adaptive rate limit buckets.
+func (c *Client) rateLimitMiddleware(next CallHandler) CallHandler {
+	return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+		if err := c.waitForSlot(ctx); err != nil {
+			return nil, err
+		}
+
+		bucket := c.rateLimitBucket(req)
+		if bucket != nil {
+			if err := bucket.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := next(ctx, req)
+		if err == nil && bucket != nil {
+			bucket.observe(resp)
+		}
+		return resp, err
+	}
 }
@@ -0,0 +1,136 @@
+package moov
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestWithRateLimit_ContextCancelled(t *testing.T) {
+	calls := 0
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}, WithRateLimit(1))
+
+	_, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/ping"))
+	require.NoError(t, err)
+
+	// The next slot is a second away, past the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = c.CallHttp(ctx, Endpoint(http.MethodGet, "/ping"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	_, err = c.CallHttp(ctx, Endpoint(http.MethodGet, "/ping"))
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.Equal(t, 1, calls)
+}
+
+func TestWithAdaptiveRateLimit(t *testing.T) {
+	t.Run("requires positive rps", func(t *testing.T) {
+		_, err := NewClient(
+			WithCredentials(Credentials{PublicKey: "pk", SecretKey: "sk"}),
+			WithAdaptiveRateLimit(RateLimitBucket{Name: "reads"}),
+		)
+		require.Error(t, err)
+	})
+
+	t.Run("selects buckets per endpoint group", func(t *testing.T) {
+		c, err := NewClient(
+			WithCredentials(Credentials{PublicKey: "pk", SecretKey: "sk"}),
+			WithAdaptiveRateLimit(
+				RateLimitBucket{Name: "transfers", RPS: 10, Match: MatchPaths("/transfers")},
+				RateLimitBucket{Name: "reads", RPS: 50, Burst: 5, Match: MatchMethods(http.MethodGet)},
+			),
+		)
+		require.NoError(t, err)
+
+		bucket := func(method, path string, args ...any) string {
+			call, err := newCall(Endpoint(method, path, args...))
+			require.NoError(t, err)
+			if b := c.rateLimitBucket(call.request()); b != nil {
+				return b.config.Name
+			}
+			return ""
+		}
+
+		require.Equal(t, "transfers", bucket(http.MethodPost, pathTransfers, "a"))
+		require.Equal(t, "transfers", bucket(http.MethodGet, pathTransfer, "a", "b"))
+		require.Equal(t, "reads", bucket(http.MethodGet, pathWallets, "a"))
+		require.Equal(t, "", bucket(http.MethodPost, pathAccounts))
+
+		require.Equal(t, 1, c.rateLimitBuckets[0].limiter.Burst())
+		require.Equal(t, 5, c.rateLimitBuckets[1].limiter.Burst())
+	})
+
+	t.Run("slows down on 429 and recovers", func(t *testing.T) {
+		now := time.Now()
+		b := &adaptiveBucket{config: RateLimitBucket{RPS: 10, MinRPS: 2}, now: func() time.Time { return now }}
+		b.limiter = rate.NewLimiter(10, 1)
+
+		rateLimited := NewHttpCallResponse(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"3"}}}, nil)
+		ok := NewHttpCallResponse(&http.Response{StatusCode: http.StatusOK}, nil)
+
+		b.observe(rateLimited)
+		require.InDelta(t, 5, float64(b.limiter.Limit()), 0.001)
+		require.Equal(t, now.Add(3*time.Second), b.pausedUntil)
+
+		b.observe(rateLimited)
+		b.observe(rateLimited)
+		require.InDelta(t, 2, float64(b.limiter.Limit()), 0.001)
+
+		for range 20 {
+			b.observe(ok)
+		}
+		require.InDelta(t, 10, float64(b.limiter.Limit()), 0.001)
+	})
+
+	t.Run("pauses when no requests remain", func(t *testing.T) {
+		now := time.Now()
+		b := &adaptiveBucket{config: RateLimitBucket{RPS: 10, MinRPS: 1}, now: func() time.Time { return now }}
+		b.limiter = rate.NewLimiter(10, 1)
+
+		b.observe(NewHttpCallResponse(&http.Response{StatusCode: http.StatusOK, Header: http.Header{
+			"X-Ratelimit-Remaining": []string{"0"},
+			"X-Ratelimit-Reset":     []string{"2"},
+		}}, nil))
+		require.Equal(t, now.Add(2*time.Second), b.pausedUntil)
+
+		reset := now.Add(time.Minute).Truncate(time.Second)
+		b.observe(NewHttpCallResponse(&http.Response{StatusCode: http.StatusOK, Header: http.Header{
+			"Ratelimit-Remaining": []string{"0"},
+			"Ratelimit-Reset":     []string{strconv.FormatInt(reset.Unix(), 10)},
+		}}, nil))
+		require.Equal(t, reset.Unix(), b.pausedUntil.Unix())
+	})
+
+	t.Run("returns the context error while paused", func(t *testing.T) {
+		calls := 0
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}, WithAdaptiveRateLimit(RateLimitBucket{Name: "all", RPS: 100}))
+
+		_, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/ping"))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = c.CallHttp(ctx, Endpoint(http.MethodGet, "/ping"))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, 1, calls)
+	})
+}
@@ -3,13 +3,28 @@ package moov
 import (
 	"context"
 	"net/http"
+
+	"github.com/google/uuid"
 )
 
 func (c Client) CreateReceipt(ctx context.Context, receipts ...CreateReceipt) ([]Receipt, error) {
+	return c.CreateReceipts(ctx, receipts)
+}
+
+type CreateReceiptArgs callArg
+
+// Sends the X-Idempotency-Key header, which receipts are created without otherwise.
+func WithReceiptIdempotencyKey(key uuid.UUID) CreateReceiptArgs {
+	return IdempotencyKey(key.String())
+}
+
+// CreateReceipts creates the receipts like CreateReceipt, with options.
+func (c Client) CreateReceipts(ctx context.Context, receipts []CreateReceipt, options ...CreateReceiptArgs) ([]Receipt, error) {
 	resp, err := c.CallHttp(ctx,
 		Endpoint(http.MethodPost, pathReceipts),
-		AcceptJson(),
-		JsonBody(receipts))
+		prependArgs(options,
+			AcceptJson(),
+			JsonBody(receipts))...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,207 @@
+package moov
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 250 * time.Millisecond
+	defaultRetryMaxBackoff     = 10 * time.Second
+)
+
+// RetryPolicy controls how the client retries calls that failed with a retryable CallStatus
+// (StatusRateLimited or StatusServerError) or a network error.
+//
+// Only idempotent calls are retried: GET and HEAD requests, and any request carrying an
+// X-Idempotency-Key header (e.g. CreateTransfer and RefundTransfer).
+type RetryPolicy struct {
+	// Total number of attempts, including the first one. Defaults to 3.
+	MaxAttempts int
+
+	// Delay before the first retry. Each following retry doubles it. Defaults to 250ms.
+	InitialBackoff time.Duration
+
+	// Upper bound for the delay between attempts. A Retry-After header asking for a longer
+	// wait stops retrying and returns the last response. Defaults to 10s.
+	MaxBackoff time.Duration
+
+	// OnAttempt is called after every attempt so it can be logged or measured.
+	OnAttempt func(RetryAttempt)
+}
+
+// RetryAttempt describes the outcome of a single attempt made under a RetryPolicy.
+type RetryAttempt struct {
+	// Attempt number, starting at 1.
+	Attempt int
+
+	Method string
+	Path   string
+
+	// Status and StatusCode are set when a response was received.
+	Status     CallStatus
+	StatusCode int
+
+	// Err is set when no response was received, such as on network errors.
+	Err error
+
+	// WillRetry is true if another attempt follows after Delay.
+	WillRetry bool
+	Delay     time.Duration
+}
+
+// WithRetryPolicy enables automatic retries of idempotent calls.
+// Zero values in the policy are replaced by their defaults.
+func WithRetryPolicy(policy RetryPolicy) ClientConfigurable {
+	return func(c *Client) error {
+		if policy.MaxAttempts < 0 {
+			return fmt.Errorf("retry max attempts must not be negative, but was %d", policy.MaxAttempts)
+		}
+		if policy.MaxAttempts == 0 {
+			policy.MaxAttempts = defaultRetryMaxAttempts
+		}
+		if policy.InitialBackoff <= 0 {
+			policy.InitialBackoff = defaultRetryInitialBackoff
+		}
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = defaultRetryMaxBackoff
+		}
+
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+// idempotent reports if the call can safely be sent more than once.
+func (req *CallRequest) idempotent() bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+
+	_, ok := req.Headers["X-Idempotency-Key"]
+	return ok
+}
+
+// middleware retries idempotent calls according to the policy.
+func (p *RetryPolicy) middleware(next CallHandler) CallHandler {
+	return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+		if !req.idempotent() {
+			req.Attempts = 1
+			return next(ctx, req)
+		}
+
+		for attempt := 1; ; attempt++ {
+			req.Attempts = attempt
+
+			// Each attempt gets its own copy so inner middleware can't leak changes into the next one.
+			attemptReq := *req
+			attemptReq.Headers = maps.Clone(req.Headers)
+			attemptReq.Params = maps.Clone(req.Params)
+
+			resp, err := next(ctx, &attemptReq)
+
+			delay, retry := p.next(attempt, resp, err)
+			if ctx.Err() != nil {
+				retry = false
+			}
+
+			if p.OnAttempt != nil {
+				info := RetryAttempt{
+					Attempt:   attempt,
+					Method:    req.Method,
+					Path:      req.Path,
+					Err:       err,
+					WillRetry: retry,
+				}
+				if err == nil {
+					info.Status = resp.Status()
+					info.StatusCode = resp.StatusCode()
+				}
+				if retry {
+					info.Delay = delay
+				}
+				p.OnAttempt(info)
+			}
+
+			if !retry {
+				return resp, err
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return resp, err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// next decides if another attempt should be made and how long to wait before it.
+func (p *RetryPolicy) next(attempt int, resp HttpCallResponse, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	if errors.Is(err, ErrResponseBodyTooLarge) {
+		return 0, false
+	}
+
+	if err == nil {
+		status := resp.Status()
+		if !status.Retryable || status == StatusStarted {
+			return 0, false
+		}
+
+		switch resp.StatusCode() {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			if wait, ok := retryAfter(resp.Header().Get("Retry-After")); ok {
+				return wait, wait <= p.MaxBackoff
+			}
+		}
+	}
+
+	return p.backoff(attempt), true
+}
+
+// backoff returns an exponential delay for the given attempt with jitter applied to the
+// upper half so concurrent clients don't retry in lockstep.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, p.MaxBackoff)
+
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,146 @@
+package moov
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	fastPolicy := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
+
+	t.Run("retries GET on server errors", func(t *testing.T) {
+		var calls atomic.Int32
+		var attempts []RetryAttempt
+
+		policy := fastPolicy
+		policy.OnAttempt = func(a RetryAttempt) { attempts = append(attempts, a) }
+
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}, WithRetryPolicy(policy))
+
+		resp, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/ping"))
+		require.NoError(t, err)
+		require.Equal(t, StatusCompleted, resp.Status())
+		require.Equal(t, int32(3), calls.Load())
+
+		require.Len(t, attempts, 3)
+		require.True(t, attempts[0].WillRetry)
+		require.Equal(t, StatusServerError, attempts[0].Status)
+		require.Equal(t, http.StatusBadGateway, attempts[0].StatusCode)
+		require.False(t, attempts[2].WillRetry)
+		require.Equal(t, 3, attempts[2].Attempt)
+	})
+
+	t.Run("stops after max attempts", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}, WithRetryPolicy(fastPolicy))
+
+		resp, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/ping"))
+		require.NoError(t, err)
+		require.Equal(t, StatusRateLimited, resp.Status())
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("does not retry POST without idempotency key", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}, WithRetryPolicy(fastPolicy))
+
+		resp, err := c.CallHttp(context.Background(), Endpoint(http.MethodPost, "/accounts"), JsonBody(map[string]string{"a": "b"}))
+		require.NoError(t, err)
+		require.Equal(t, StatusServerError, resp.Status())
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("re-sends body of POST with idempotency key", func(t *testing.T) {
+		var bodies []string
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			if len(bodies) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}, WithRetryPolicy(fastPolicy))
+
+		resp, err := c.CallHttp(context.Background(),
+			Endpoint(http.MethodPost, "/accounts/%s/transfers", "abc"),
+			IdempotencyKey("key"),
+			JsonBody(map[string]string{"a": "b"}))
+		require.NoError(t, err)
+		require.Equal(t, StatusCompleted, resp.Status())
+		require.Equal(t, []string{`{"a":"b"}`, `{"a":"b"}`}, bodies)
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}, WithRetryPolicy(fastPolicy))
+
+		resp, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/ping"))
+		require.NoError(t, err)
+		require.Equal(t, StatusNotFound, resp.Status())
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("gives up when Retry-After exceeds max backoff", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}, WithRetryPolicy(fastPolicy))
+
+		resp, err := c.CallHttp(context.Background(), Endpoint(http.MethodGet, "/ping"))
+		require.NoError(t, err)
+		require.Equal(t, StatusRateLimited, resp.Status())
+		require.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	d, ok := retryAfter("2")
+	require.True(t, ok)
+	require.Equal(t, 2*time.Second, d)
+
+	d, ok = retryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	require.True(t, ok)
+	require.Equal(t, time.Duration(0), d)
+
+	_, ok = retryAfter("soon")
+	require.False(t, ok)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		want *= time.Millisecond
+		got := p.backoff(attempt + 1)
+		require.GreaterOrEqual(t, got, want/2)
+		require.LessOrEqual(t, got, want)
+	}
+}
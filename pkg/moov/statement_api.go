@@ -3,6 +3,8 @@ package moov
 import (
 	"bytes"
 	"context"
+	"io"
+	"iter"
 	"net/http"
 )
 
@@ -23,19 +25,29 @@ func (c Client) GetStatement(ctx context.Context, accountID, statementID string)
 // GetStatementPDF retrieves a statement in PDF format as []byte
 // https://docs.moov.io/api/moov-accounts/billing/get-statement/
 func (c Client) GetStatementPDF(ctx context.Context, accountID, statementID string) ([]byte, error) {
+	buf := bytes.Buffer{}
+	if err := c.GetStatementPDFTo(ctx, accountID, statementID, &buf); err != nil {
+		return []byte{}, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GetStatementPDFTo streams a statement in PDF format to w without holding it in memory
+// https://docs.moov.io/api/moov-accounts/billing/get-statement/
+func (c Client) GetStatementPDFTo(ctx context.Context, accountID, statementID string, w io.Writer) error {
 	resp, err := c.CallHttp(ctx,
 		Endpoint(http.MethodGet, pathStatement, accountID, statementID),
 		AcceptContentType("application/pdf"),
+		StreamResponse(),
 	)
 	if err != nil {
-		return []byte{}, err
+		return err
 	}
 
-	buf, err := CompletedObjectOrError[bytes.Buffer](resp)
-	if err != nil {
-		return []byte{}, err
+	if err := CompletedNilOrError(resp); err != nil {
+		return err
 	}
-	return buf.Bytes(), nil
+	return resp.Unmarshal(w)
 }
 
 // ListStatements lists statements for a Moov account
@@ -49,3 +61,11 @@ func (c Client) ListStatements(ctx context.Context, accountID string, filters ..
 
 	return CompletedListOrError[Statement](resp)
 }
+
+// AllStatements iterates over all statements matching the filters, fetching pages of
+// WithStatementCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllStatements(ctx context.Context, accountID string, filters ...ListStatementFilter) iter.Seq2[Statement, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]Statement, error) {
+		return c.ListStatements(ctx, accountID, append(filters[:len(filters):len(filters)], WithStatementSkip(skip), WithStatementCount(count))...)
+	})
+}
@@ -2,6 +2,7 @@ package moov
 
 import (
 	"context"
+	"iter"
 	"net/http"
 	"strconv"
 )
@@ -65,6 +66,26 @@ func (c Client) ListTickets(ctx context.Context, accountID string, filters ...Li
 	return CompletedObjectOrError[ListTicket](resp)
 }
 
+// AllTickets iterates over all tickets matching the filters, following the next page cursor
+// until the last page. WithTicketCount sets the page size.
+func (c Client) AllTickets(ctx context.Context, accountID string, filters ...ListTicketFilter) iter.Seq2[Ticket, error] {
+	return paginateCursor(ctx, func(cursor string) ([]Ticket, string, error) {
+		args := filters[:len(filters):len(filters)]
+		if cursor != "" {
+			args = append(args, WithTicketCursor(cursor))
+		}
+
+		page, err := c.ListTickets(ctx, accountID, args...)
+		if err != nil {
+			return nil, "", err
+		}
+		if page.NextPage == nil {
+			return page.Items, "", nil
+		}
+		return page.Items, page.NextPage.Cursor, nil
+	})
+}
+
 func (c Client) ListTicketMessages(ctx context.Context, accountID, ticketID string) ([]TicketMessage, error) {
 	resp, err := c.CallHttp(ctx,
 		Endpoint(http.MethodGet, pathTicketMessages, accountID, ticketID),
@@ -3,6 +3,7 @@ package moov
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -120,6 +121,14 @@ func (c Client) ListSweeps(ctx context.Context, accountID string, walletID strin
 	return CompletedListOrError[Sweep](resp)
 }
 
+// AllSweeps iterates over all sweeps of the wallet matching the filters, fetching pages of
+// WithSweepCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllSweeps(ctx context.Context, accountID string, walletID string, filters ...ListSweepsFilter) iter.Seq2[Sweep, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]Sweep, error) {
+		return c.ListSweeps(ctx, accountID, walletID, append(filters[:len(filters):len(filters)], WithSweepSkip(skip), WithSweepCount(count))...)
+	})
+}
+
 // GetSweep retrives a sweep for a given sweepID.
 // https://docs.moov.io/api/money-movement/sweeps/get/
 func (c Client) GetSweep(ctx context.Context, accountID string, walletID string, sweepID string) (*Sweep, error) {
@@ -0,0 +1,155 @@
+package moov
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultTokenRefreshBefore = time.Minute
+
+// TokenSource mints scoped access tokens and keeps them fresh. Tokens are cached per set of scopes
+// and replaced shortly before they expire, using the refresh token when Moov returned one and the
+// client credentials otherwise. It's safe for concurrent use.
+//
+// Plug it into a Client with WithTokenSource so every call is made with a least-privilege token
+// instead of the secret key.
+type TokenSource struct {
+	client        *Client
+	refreshBefore time.Duration
+	now           func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]*cachedToken
+}
+
+type cachedToken struct {
+	mu      sync.Mutex
+	scopes  []ScopeBuilder
+	token   *AccessTokenResponse
+	expires time.Time
+}
+
+type TokenSourceOption func(ts *TokenSource)
+
+// WithRefreshBefore sets how long before expiry a token is replaced. Defaults to one minute.
+func WithRefreshBefore(d time.Duration) TokenSourceOption {
+	return func(ts *TokenSource) {
+		ts.refreshBefore = d
+	}
+}
+
+// NewTokenSource returns a TokenSource minting tokens with the credentials of the given client.
+func NewTokenSource(client *Client, opts ...TokenSourceOption) *TokenSource {
+	ts := &TokenSource{
+		client:        client,
+		refreshBefore: defaultTokenRefreshBefore,
+		now:           time.Now,
+		tokens:        map[string]*cachedToken{},
+	}
+
+	for _, opt := range opts {
+		opt(ts)
+	}
+
+	return ts
+}
+
+// Token returns a valid access token for the scopes, minting or refreshing it when needed.
+func (ts *TokenSource) Token(ctx context.Context, scopes ...ScopeBuilder) (*AccessTokenResponse, error) {
+	key, err := scopeKey(scopes...)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.mu.Lock()
+	cached, ok := ts.tokens[key]
+	if !ok {
+		cached = &cachedToken{scopes: scopes}
+		ts.tokens[key] = cached
+	}
+	ts.mu.Unlock()
+
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+
+	if cached.token != nil && ts.now().Before(cached.expires.Add(-ts.refreshBefore)) {
+		return cached.token, nil
+	}
+
+	issued := ts.now()
+	token, err := ts.mint(ctx, cached)
+	if err != nil {
+		return nil, err
+	}
+
+	cached.token = token
+	cached.expires = issued.Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return token, nil
+}
+
+func (ts *TokenSource) mint(ctx context.Context, cached *cachedToken) (*AccessTokenResponse, error) {
+	if cached.token != nil && cached.token.RefreshToken != "" {
+		token, err := ts.client.RefreshAccessToken(ctx, cached.token.RefreshToken)
+		if err == nil {
+			return token, nil
+		}
+		// The refresh token may have expired or been revoked, mint a new one below.
+	}
+
+	return ts.client.AccessToken(ctx, cached.scopes...)
+}
+
+// Revoke revokes every cached token and clears the cache.
+func (ts *TokenSource) Revoke(ctx context.Context) error {
+	ts.mu.Lock()
+	tokens := ts.tokens
+	ts.tokens = map[string]*cachedToken{}
+	ts.mu.Unlock()
+
+	var errs []error
+	for _, cached := range tokens {
+		cached.mu.Lock()
+		token := cached.token
+		cached.mu.Unlock()
+
+		if token == nil {
+			continue
+		}
+		if err := ts.client.RevokeAccessToken(ctx, token.AccessToken); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// scopeKey builds a key identifying the set of scopes regardless of their order.
+func scopeKey(scopes ...ScopeBuilder) (string, error) {
+	scp, err := buildScopes(scopes...)
+	if err != nil {
+		return "", err
+	}
+
+	list := strings.Fields(scp)
+	slices.Sort(list)
+	return strings.Join(slices.Compact(list), " "), nil
+}
+
+// WithTokenSource authenticates every call made by the client with a bearer token for the
+// given scopes from the TokenSource, instead of the secret key.
+func WithTokenSource(ts *TokenSource, scopes ...ScopeBuilder) ClientConfigurable {
+	return func(c *Client) error {
+		if _, err := scopeKey(scopes...); err != nil {
+			return err
+		}
+
+		c.tokenSource = ts
+		c.tokenScopes = scopes
+		return nil
+	}
+}
@@ -0,0 +1,123 @@
+package moov
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tokenServer struct {
+	minted    atomic.Int32
+	refreshed atomic.Int32
+	lastAuth  atomic.Value
+}
+
+func (s *tokenServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case pathOAuth2Token:
+			var req accessTokenRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+			var n int32
+			switch req.GrantType {
+			case "refresh_token":
+				n = s.refreshed.Add(1)
+			default:
+				n = s.minted.Add(1)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(AccessTokenResponse{
+				AccessToken:  fmt.Sprintf("%s-%d", req.GrantType, n),
+				RefreshToken: "refresh",
+				ExpiresIn:    3600,
+				Scope:        req.Scope,
+			})
+		default:
+			s.lastAuth.Store(r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+}
+
+func TestTokenSource(t *testing.T) {
+	t.Run("caches tokens per scope set", func(t *testing.T) {
+		srv := &tokenServer{}
+		ts := NewTokenSource(newLocalTestClient(t, srv.handler(t)))
+
+		first, err := ts.Token(context.Background(), Scopes.WalletsRead("a"), Scopes.TransfersWrite("a"))
+		require.NoError(t, err)
+
+		second, err := ts.Token(context.Background(), Scopes.TransfersWrite("a"), Scopes.WalletsRead("a"))
+		require.NoError(t, err)
+		require.Same(t, first, second)
+
+		other, err := ts.Token(context.Background(), Scopes.WalletsRead("b"))
+		require.NoError(t, err)
+		require.NotEqual(t, first.AccessToken, other.AccessToken)
+		require.Equal(t, int32(2), srv.minted.Load())
+	})
+
+	t.Run("refreshes before expiry", func(t *testing.T) {
+		srv := &tokenServer{}
+		now := time.Now()
+		ts := NewTokenSource(newLocalTestClient(t, srv.handler(t)), WithRefreshBefore(5*time.Minute))
+		ts.now = func() time.Time { return now }
+
+		token, err := ts.Token(context.Background(), Scopes.Ping())
+		require.NoError(t, err)
+		require.Equal(t, "client_credentials-1", token.AccessToken)
+
+		now = now.Add(50 * time.Minute)
+		token, err = ts.Token(context.Background(), Scopes.Ping())
+		require.NoError(t, err)
+		require.Equal(t, "client_credentials-1", token.AccessToken)
+
+		now = now.Add(6 * time.Minute)
+		token, err = ts.Token(context.Background(), Scopes.Ping())
+		require.NoError(t, err)
+		require.Equal(t, "refresh_token-1", token.AccessToken)
+		require.Equal(t, int32(1), srv.minted.Load())
+	})
+
+	t.Run("mints once for concurrent callers", func(t *testing.T) {
+		srv := &tokenServer{}
+		ts := NewTokenSource(newLocalTestClient(t, srv.handler(t)))
+
+		wg := sync.WaitGroup{}
+		for range 20 {
+			wg.Go(func() {
+				_, err := ts.Token(context.Background(), Scopes.Ping())
+				require.NoError(t, err)
+			})
+		}
+		wg.Wait()
+
+		require.Equal(t, int32(1), srv.minted.Load())
+	})
+
+	t.Run("authenticates client calls", func(t *testing.T) {
+		srv := &tokenServer{}
+		c := newLocalTestClient(t, srv.handler(t))
+		c, err := NewClient(
+			WithCredentials(c.Credentials),
+			WithMoovURLScheme("http"),
+			WithTokenSource(NewTokenSource(c), Scopes.Ping()),
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, c.Ping(context.Background()))
+		require.Equal(t, "Bearer client_credentials-1", srv.lastAuth.Load())
+
+		require.NoError(t, c.Ping(context.Background()))
+		require.Equal(t, int32(1), srv.minted.Load())
+	})
+}
@@ -3,6 +3,7 @@ package moov
 import (
 	"context"
 	"errors"
+	"iter"
 	"net/http"
 	"strings"
 	"time"
@@ -37,23 +38,41 @@ func (c Client) CreateTransfer(ctx context.Context, partnerAccountID string, tra
 		callArgs = append(callArgs, opt(builder))
 	}
 
-	return CreateTransferBuilder{
-		client:   c,
-		ctx:      ctx,
-		endpoint: Endpoint(http.MethodPost, pathTransfers, partnerAccountID),
-		callArgs: callArgs,
+	return createTransferCall{
+		client:           c,
+		ctx:              ctx,
+		partnerAccountID: partnerAccountID,
+		endpoint:         Endpoint(http.MethodPost, pathTransfers, partnerAccountID),
+		callArgs:         callArgs,
 	}
 }
 
-type CreateTransferBuilder struct {
-	client   Client
-	ctx      context.Context
-	endpoint EndpointArg
-	callArgs []callArg
+// CreateTransferBuilder is returned by CreateTransfer and sends the transfer request once one of
+// its methods is called. Fakes of TransfersAPI implement it to return canned results.
+type CreateTransferBuilder interface {
+	// Started initiates the transfers request and doesn't wait beyond creating the transfer
+	Started() (*TransferStarted, error)
+
+	// WaitForRailResponse starts a transfer request and waits for a response from the rail (e.g.
+	// authorized or declined). Only one of the return values is non-nil: the transfer with
+	// rail-specific details, the started transfer if waiting for the rail timed out, or an error.
+	WaitForRailResponse() (*Transfer, *TransferStarted, error)
+
+	// Operation creates the transfer like WaitForRailResponse and returns it as an Operation,
+	// polling GetTransfer until the transfer is completed, failed, reversed or canceled.
+	Operation() (*Operation[Transfer], error)
+}
+
+type createTransferCall struct {
+	client           Client
+	ctx              context.Context
+	partnerAccountID string
+	endpoint         EndpointArg
+	callArgs         []callArg
 }
 
 // Started initiates the transfers request and doesn't wait beyond creating the transfer
-func (r CreateTransferBuilder) Started() (*TransferStarted, error) {
+func (r createTransferCall) Started() (*TransferStarted, error) {
 	resp, err := r.client.CallHttp(r.ctx, r.endpoint, r.callArgs...)
 	if err != nil {
 		return nil, err
@@ -75,7 +94,7 @@ func (r CreateTransferBuilder) Started() (*TransferStarted, error) {
 // 1) A full transfer with rail-specific details as a result of waiting for the response from the rail.
 // 2) A transfer that started but the request timed out waiting for a response from the rail.
 // 3) An error attempting to create the transfer.
-func (r CreateTransferBuilder) WaitForRailResponse() (*Transfer, *TransferStarted, error) {
+func (r createTransferCall) WaitForRailResponse() (*Transfer, *TransferStarted, error) {
 	resp, err := r.client.CallHttp(r.ctx, r.endpoint, append(r.callArgs, WaitFor("rail-response"))...)
 	if err != nil {
 		return nil, nil, err
@@ -95,6 +114,27 @@ func (r CreateTransferBuilder) WaitForRailResponse() (*Transfer, *TransferStarte
 	}
 }
 
+// Operation creates the transfer like WaitForRailResponse and returns it as an Operation,
+// polling GetTransfer until the transfer is completed, failed, reversed or canceled.
+func (r createTransferCall) Operation() (*Operation[Transfer], error) {
+	transfer, started, err := r.WaitForRailResponse()
+	if err != nil {
+		return nil, err
+	}
+
+	transferID := ""
+	switch {
+	case transfer != nil:
+		transferID = transfer.TransferID
+	case started != nil:
+		transferID = started.TransferID
+	}
+
+	return NewOperation(transfer, transferDone, func(ctx context.Context) (*Transfer, error) {
+		return r.client.GetTransfer(ctx, r.partnerAccountID, transferID)
+	}), nil
+}
+
 type ListTransferFilter callArg
 
 func WithTransferAccountIDs(accountIDs []string) ListTransferFilter {
@@ -173,7 +213,7 @@ func WithTransferCount(count int) ListTransferFilter {
 func (c Client) ListTransfers(ctx context.Context, accountID string, filters ...ListTransferFilter) ([]Transfer, error) {
 	resp, err := c.CallHttp(ctx,
 		Endpoint(http.MethodGet, pathTransfers, accountID),
-		prependArgs(filters, AcceptJson())...)
+		prependArgs(filters, AcceptJson(), StreamResponse())...)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +221,14 @@ func (c Client) ListTransfers(ctx context.Context, accountID string, filters ...
 	return CompletedListOrError[Transfer](resp)
 }
 
+// AllTransfers iterates over all transfers matching the filters, fetching pages of
+// WithTransferCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllTransfers(ctx context.Context, accountID string, filters ...ListTransferFilter) iter.Seq2[Transfer, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]Transfer, error) {
+		return c.ListTransfers(ctx, accountID, append(filters[:len(filters):len(filters)], WithTransferSkip(skip), WithTransferCount(count))...)
+	})
+}
+
 // GetTransfer retrieves a transfer
 // https://docs.moov.io/api/index.html#tag/Transfers/operation/getTransfer
 func (c Client) GetTransfer(ctx context.Context, accountID, transferID string) (*Transfer, error) {
@@ -389,6 +437,35 @@ func (c Client) RefundTransfer(ctx context.Context, partnerAccountID, transferID
 	}
 }
 
+// RefundTransferOperation refunds the transfer like RefundTransfer and returns the refund as an
+// Operation, polling GetRefund until it's completed or failed. When Moov only started the refund,
+// it's the latest refund of the transfer returned with the response.
+func (c Client) RefundTransferOperation(ctx context.Context, partnerAccountID, transferID string, refund CreateRefund, options ...CreateRefundArgs) (*Operation[Refund], error) {
+	created, started, err := c.RefundTransfer(ctx, partnerAccountID, transferID, refund, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var refundID string
+	if created != nil {
+		refundID = created.RefundID
+	} else if started != nil {
+		var latest time.Time
+		for _, r := range started.Refunds {
+			if refundID == "" || r.CreatedOn.After(latest) {
+				refundID, latest = r.RefundID, r.CreatedOn
+			}
+		}
+	}
+
+	return NewOperation(created, refundDone, func(ctx context.Context) (*Refund, error) {
+		if refundID == "" {
+			return nil, errors.New("refund was started without an ID to poll it with")
+		}
+		return c.GetRefund(ctx, partnerAccountID, transferID, refundID)
+	}), nil
+}
+
 func RefundTransferGeneric[TRequest any, TRefund any](ctx context.Context, client *Client, version Version, partnerAccountID, transferID string, refund TRequest, options ...CreateRefundArgs) (*TRefund, *RefundStarted, error) {
 	if client == nil {
 		return nil, nil, errors.New("client is nil")
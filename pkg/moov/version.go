@@ -1,7 +1,13 @@
 package moov
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
 )
 
 const VersionHeader = "X-Moov-Version"
@@ -39,3 +45,151 @@ func (t Version) String() string {
 func NewVersion(year int, month int, build int) Version {
 	return Version{fmt.Sprintf("v%04d.%02d.%02d", year, month, build)}
 }
+
+// WithDefaultVersion sends calls with the given version when they don't select one themselves.
+// Without it calls made by Client methods without a version of their own are PreVersioning.
+func WithDefaultVersion(version Version) ClientConfigurable {
+	return func(c *Client) error {
+		c.defaultVersion = &version
+		return nil
+	}
+}
+
+// WithEndpointVersion sends the calls matched by match with the given version, e.g. to move an
+// API to a new version endpoint by endpoint:
+//
+//	moov.WithEndpointVersion(moov.Version2026_07, moov.MatchPaths("/transfers"))
+//
+// It takes precedence over WithDefaultVersion, the first matching endpoint version applies.
+func WithEndpointVersion(version Version, match func(req *CallRequest) bool) ClientConfigurable {
+	return func(c *Client) error {
+		if match == nil {
+			return fmt.Errorf("endpoint version %s needs a match", version)
+		}
+		c.endpointVersions = append(c.endpointVersions, endpointVersion{version: version, match: match})
+		return nil
+	}
+}
+
+type endpointVersion struct {
+	version Version
+	match   func(req *CallRequest) bool
+}
+
+type versionContextKey struct{}
+
+// ContextWithVersion returns a context sending the calls made with it with the given version,
+// overriding WithEndpointVersion and WithDefaultVersion.
+func ContextWithVersion(ctx context.Context, version Version) context.Context {
+	return context.WithValue(ctx, versionContextKey{}, version)
+}
+
+// DeprecationNotice is what Moov reported about a deprecated version or endpoint in the
+// Deprecation, Sunset and Link response headers.
+type DeprecationNotice struct {
+	// Version the call was sent with, empty for PreVersioning.
+	Version string
+
+	// Deprecation is the raw Deprecation header, e.g. "true" or a date like "@1688169599".
+	Deprecation string
+
+	// Sunset is when the version or endpoint stops working, zero if Moov didn't say.
+	Sunset time.Time
+
+	// Link points to documentation about the deprecation, when Moov returned one.
+	Link string
+}
+
+// WithDeprecationHandler calls fn for every response reporting a deprecation or sunset. Without
+// it, clients with a logger log a warning once for every endpoint and version.
+func WithDeprecationHandler(fn func(req *CallRequest, notice DeprecationNotice)) ClientConfigurable {
+	return func(c *Client) error {
+		c.onDeprecation = fn
+		return nil
+	}
+}
+
+// versionMiddleware sets the version of calls that don't select one and reports deprecations.
+func (c *Client) versionMiddleware(next CallHandler) CallHandler {
+	return func(ctx context.Context, req *CallRequest) (HttpCallResponse, error) {
+		if _, ok := req.Headers[VersionHeader]; !ok {
+			if version, ok := c.selectVersion(ctx, req); ok {
+				req.Headers = maps.Clone(req.Headers)
+				if req.Headers == nil {
+					req.Headers = map[string]string{}
+				}
+				req.Headers[VersionHeader] = version.String()
+			}
+		}
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		notice, ok := deprecationNotice(req, resp.Header())
+		switch {
+		case !ok:
+		case c.onDeprecation != nil:
+			c.onDeprecation(req, notice)
+		case c.logger != nil:
+			if !c.deprecationLogged(req, notice) {
+				c.logger.LogAttrs(ctx, slog.LevelWarn, "moov endpoint deprecated",
+					slog.String("method", req.Method),
+					slog.String("path", req.PathTemplate),
+					slog.String("version", notice.Version),
+					slog.String("deprecation", notice.Deprecation),
+					slog.Time("sunset", notice.Sunset),
+					slog.String("link", notice.Link),
+				)
+			}
+		}
+		return resp, nil
+	}
+}
+
+func (c *Client) selectVersion(ctx context.Context, req *CallRequest) (Version, bool) {
+	if version, ok := ctx.Value(versionContextKey{}).(Version); ok {
+		return version, true
+	}
+	for _, ev := range c.endpointVersions {
+		if ev.match(req) {
+			return ev.version, true
+		}
+	}
+	if c.defaultVersion != nil {
+		return *c.defaultVersion, true
+	}
+	return Version{}, false
+}
+
+func deprecationNotice(req *CallRequest, h http.Header) (DeprecationNotice, bool) {
+	notice := DeprecationNotice{
+		Version:     req.Headers[VersionHeader],
+		Deprecation: h.Get("Deprecation"),
+	}
+	if sunset := h.Get("Sunset"); sunset != "" {
+		notice.Sunset, _ = http.ParseTime(sunset)
+	}
+	if notice.Deprecation == "" && notice.Sunset.IsZero() {
+		return notice, false
+	}
+
+	for _, link := range h.Values("Link") {
+		if strings.Contains(link, `rel="deprecation"`) || strings.Contains(link, `rel="sunset"`) {
+			target, _, _ := strings.Cut(link, ";")
+			notice.Link = strings.Trim(strings.TrimSpace(target), "<>")
+			break
+		}
+	}
+	return notice, true
+}
+
+// deprecationLogged reports if the deprecation was logged before, marking it as logged.
+func (c *Client) deprecationLogged(req *CallRequest, notice DeprecationNotice) bool {
+	if c.deprecationsLogged == nil {
+		return false
+	}
+	_, seen := c.deprecationsLogged.LoadOrStore(req.Method+" "+req.PathTemplate+" "+notice.Version, true)
+	return seen
+}
@@ -0,0 +1,77 @@
+package moov
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionSelection(t *testing.T) {
+	var versions []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		versions = append(versions, r.Header.Get(VersionHeader))
+	}
+	ctx := context.Background()
+
+	c := newLocalTestClient(t, handler)
+	require.NoError(t, c.Ping(ctx))
+	require.Equal(t, []string{""}, versions)
+
+	versions = nil
+	c = newLocalTestClient(t, handler,
+		WithDefaultVersion(Version2025_07),
+		WithEndpointVersion(Version2026_07, MatchPaths("/transfers")),
+	)
+	require.NoError(t, c.Ping(ctx))
+	_, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathTransfers, "account"))
+	require.NoError(t, err)
+	require.NoError(t, c.Ping(ContextWithVersion(ctx, Version2026_10)))
+	_, err = c.CallHttp(ctx, Endpoint(http.MethodGet, pathTransfers, "account"), MoovVersion(Version2025_01))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		Version2025_07.String(),
+		Version2026_07.String(),
+		Version2026_10.String(),
+		Version2025_01.String(),
+	}, versions)
+}
+
+func TestDeprecationNotice(t *testing.T) {
+	sunset := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "@1790000000")
+		w.Header().Set("Sunset", sunset.Format(http.TimeFormat))
+		w.Header().Add("Link", `<https://docs.moov.io/api/changelog/>; rel="deprecation"; type="text/html"`)
+	}
+	ctx := context.Background()
+
+	t.Run("handler", func(t *testing.T) {
+		var notices []DeprecationNotice
+		c := newLocalTestClient(t, handler, WithDefaultVersion(Version2025_07), WithDeprecationHandler(func(_ *CallRequest, n DeprecationNotice) {
+			notices = append(notices, n)
+		}))
+		require.NoError(t, c.Ping(ctx))
+
+		require.Equal(t, []DeprecationNotice{{
+			Version:     Version2025_07.String(),
+			Deprecation: "@1790000000",
+			Sunset:      sunset,
+			Link:        "https://docs.moov.io/api/changelog/",
+		}}, notices)
+	})
+
+	t.Run("logged once", func(t *testing.T) {
+		var buf bytes.Buffer
+		c := newLocalTestClient(t, handler, WithLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+		for range 3 {
+			require.NoError(t, c.Ping(ctx))
+		}
+		require.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("moov endpoint deprecated")))
+	})
+}
@@ -3,6 +3,7 @@ package moov
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"time"
 )
@@ -155,6 +156,14 @@ func (c Client) ListWallets(ctx context.Context, accountID string, filters ...Li
 	return CompletedListOrError[Wallet](resp)
 }
 
+// AllWallets iterates over all wallets matching the filters, fetching pages of
+// WithWalletCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllWallets(ctx context.Context, accountID string, filters ...ListWalletFilter) iter.Seq2[Wallet, error] {
+	return paginate(ctx, filters, func(skip, count int) ([]Wallet, error) {
+		return c.ListWallets(ctx, accountID, append(filters[:len(filters):len(filters)], WithWalletSkip(skip), WithWalletCount(count))...)
+	})
+}
+
 // GetWallet retrieves a wallet for the given wallet id
 // https://docs.moov.io/api/index.html#tag/Wallets/operation/getWalletForAccount
 func (c Client) GetWallet(ctx context.Context, accountID string, walletID string) (*Wallet, error) {
@@ -316,7 +325,7 @@ func WithSweepID(sweepID string) ListTransactionFilter {
 // ListWalletTransactions lists all transactions for the given wallet id
 // https://docs.moov.io/api/index.html#tag/Wallet-transactions
 func (c Client) ListWalletTransactions(ctx context.Context, accountID string, walletID string, opts ...ListTransactionFilter) ([]WalletTransaction, error) {
-	args := prependArgs(opts, AcceptJson())
+	args := prependArgs(opts, AcceptJson(), StreamResponse())
 	resp, err := c.CallHttp(ctx, Endpoint(http.MethodGet, pathWalletTransactions, accountID, walletID), args...)
 	if err != nil {
 		return nil, err
@@ -325,6 +334,14 @@ func (c Client) ListWalletTransactions(ctx context.Context, accountID string, wa
 	return CompletedListOrError[WalletTransaction](resp)
 }
 
+// AllWalletTransactions iterates over all transactions of the wallet matching the filters, fetching pages of
+// WithTransactionCount items (DefaultPageSize if not set) as it goes.
+func (c Client) AllWalletTransactions(ctx context.Context, accountID string, walletID string, opts ...ListTransactionFilter) iter.Seq2[WalletTransaction, error] {
+	return paginate(ctx, opts, func(skip, count int) ([]WalletTransaction, error) {
+		return c.ListWalletTransactions(ctx, accountID, walletID, append(opts[:len(opts):len(opts)], WithTransactionSkip(skip), WithTransactionCount(count))...)
+	})
+}
+
 // GetWalletTransaction retrieves a transaction for the given wallet id and transaction id
 // https://docs.moov.io/api/index.html#tag/Wallet-transactions/operation/getWalletTransaction
 func (c Client) GetWalletTransaction(ctx context.Context, accountID string, walletID string, transactionID string) (*WalletTransaction, error) {
@@ -3,6 +3,8 @@ package moov
 import (
 	"context"
 	"net/http"
+	"sync"
+	"time"
 )
 
 func (c *Client) CreateWebhook(ctx context.Context, webhook CreateWebhook) (*Webhook, error) {
@@ -99,3 +101,74 @@ func (c *Client) ListWebhookEventTypes(ctx context.Context) ([]WebhookEventType,
 
 	return CompletedListOrError[WebhookEventType](resp)
 }
+
+// WebhookSecretRotation keeps the signing secrets of a webhook active while its secret is
+// rotated. After Rotate picks up a new secret, webhooks signed with the previous one are still
+// accepted for a grace period. It implements mhooks.SecretProvider and is safe for concurrent use.
+type WebhookSecretRotation struct {
+	client    *Client
+	webhookID string
+	grace     time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	current  string
+	previous string
+	retireAt time.Time
+}
+
+// NewWebhookSecretRotation fetches the current secret of the webhook and returns a rotation
+// keeping a replaced secret active for grace.
+func (c *Client) NewWebhookSecretRotation(ctx context.Context, webhookID string, grace time.Duration) (*WebhookSecretRotation, error) {
+	secret, err := c.GetWebhookSecret(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookSecretRotation{
+		client:    c,
+		webhookID: webhookID,
+		grace:     grace,
+		now:       time.Now,
+		current:   secret.Secret,
+	}, nil
+}
+
+// Rotate fetches the secret of the webhook again. When it changed, the new secret becomes current
+// and the previous one stays active until the grace period ends. It reports if the secret changed.
+func (r *WebhookSecretRotation) Rotate(ctx context.Context) (bool, error) {
+	secret, err := r.client.GetWebhookSecret(ctx, r.webhookID)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if secret.Secret == r.current {
+		return false, nil
+	}
+	r.previous, r.current = r.current, secret.Secret
+	r.retireAt = r.now().Add(r.grace)
+	return true, nil
+}
+
+// Retire stops accepting the previous secret before the grace period ends.
+func (r *WebhookSecretRotation) Retire() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.previous = ""
+}
+
+// Secrets returns the current secret, followed by the previous one during its grace period.
+func (r *WebhookSecretRotation) Secrets(context.Context, string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.previous != "" && r.now().Before(r.retireAt) {
+		return []string{r.current, r.previous}, nil
+	}
+	r.previous = ""
+	return []string{r.current}, nil
+}
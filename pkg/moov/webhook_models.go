@@ -7,43 +7,54 @@ import (
 type EventType string
 
 const (
-	EventTypeAccountCreated           EventType = "account.created"
-	EventTypeAccountDisconnected      EventType = "account.disconnected"
-	EventTypeAccountUpdated           EventType = "account.updated"
-	EventTypeAuthorizationExpiring    EventType = "authorization.expiring"
-	EventTypeBalanceUpdated           EventType = "balance.updated"
-	EventTypeBankAccountCreated       EventType = "bankAccount.created"
-	EventTypeBankAccountDeleted       EventType = "bankAccount.deleted"
-	EventTypeBankAccountUpdated       EventType = "bankAccount.updated"
-	EventTypeBillingStatementCreated  EventType = "billingStatement.created"
-	EventTypeCancellationCreated      EventType = "cancellation.created"
-	EventTypeCancellationUpdated      EventType = "cancellation.updated"
-	EventTypeCardAutoUpdated          EventType = "card.autoUpdated"
-	EventTypeCapabilityRequested      EventType = "capability.requested"
-	EventTypeCapabilityUpdated        EventType = "capability.updated"
-	EventTypeDisputeCreated           EventType = "dispute.created"
-	EventTypeDisputeUpdated           EventType = "dispute.updated"
-	EventTypeInvoiceCreated           EventType = "invoice.created"
-	EventTypeInvoiceUpdated           EventType = "invoice.updated"
-	EventTypeNetworkIDUpdated         EventType = "networkID.updated"
-	EventTypePaymentMethodDisabled    EventType = "paymentMethod.disabled"
-	EventTypePaymentMethodEnabled     EventType = "paymentMethod.enabled"
-	EventTypeRefundCreated            EventType = "refund.created"
-	EventTypeRefundUpdated            EventType = "refund.updated"
-	EventTypeRepresentativeCreated    EventType = "representative.created"
-	EventTypeRepresentativeDeleted    EventType = "representative.deleted"
-	EventTypeRepresentativeUpdated    EventType = "representative.updated"
-	EventTypeSweepCreated             EventType = "sweep.created"
-	EventTypeSweepUpdated             EventType = "sweep.updated"
-	EventTypeTestPing                 EventType = "event.test"
-	EventTypeTicketCreated            EventType = "ticket.created"
-	EventTypeTicketUpdated            EventType = "ticket.updated"
-	EventTypeTicketMessageAdded       EventType = "ticket.messageAdded"
-	EventTypeTransferCreated          EventType = "transfer.created"
-	EventTypeTransferUpdated          EventType = "transfer.updated"
-	EventTypeWalletCreated            EventType = "wallet.created"
-	EventTypeWalletUpdated            EventType = "wallet.updated"
-	EventTypeWalletTransactionUpdated EventType = "walletTransaction.updated"
+	EventTypeAccountCreated                 EventType = "account.created"
+	EventTypeAccountDisconnected            EventType = "account.disconnected"
+	EventTypeAccountUpdated                 EventType = "account.updated"
+	EventTypeAuthorizationExpiring          EventType = "authorization.expiring"
+	EventTypeBalanceUpdated                 EventType = "balance.updated"
+	EventTypeBankAccountCreated             EventType = "bankAccount.created"
+	EventTypeBankAccountDeleted             EventType = "bankAccount.deleted"
+	EventTypeBankAccountUpdated             EventType = "bankAccount.updated"
+	EventTypeBillingStatementCreated        EventType = "billingStatement.created"
+	EventTypeCancellationCreated            EventType = "cancellation.created"
+	EventTypeCancellationUpdated            EventType = "cancellation.updated"
+	EventTypeCardAutoUpdated                EventType = "card.autoUpdated"
+	EventTypeCapabilityRequested            EventType = "capability.requested"
+	EventTypeCapabilityUpdated              EventType = "capability.updated"
+	EventTypeDisputeCreated                 EventType = "dispute.created"
+	EventTypeDisputeUpdated                 EventType = "dispute.updated"
+	EventTypeInvoiceCreated                 EventType = "invoice.created"
+	EventTypeInvoiceUpdated                 EventType = "invoice.updated"
+	EventTypeIssuedCardCreated              EventType = "issuedCard.created"
+	EventTypeIssuedCardUpdated              EventType = "issuedCard.updated"
+	EventTypeIssuedCardAuthorizationCreated EventType = "issuedCardAuthorization.created"
+	EventTypeIssuedCardAuthorizationUpdated EventType = "issuedCardAuthorization.updated"
+	EventTypeIssuedCardTransactionCreated   EventType = "issuedCardTransaction.created"
+	EventTypeNetworkIDUpdated               EventType = "networkID.updated"
+	EventTypePaymentMethodDisabled          EventType = "paymentMethod.disabled"
+	EventTypePaymentMethodEnabled           EventType = "paymentMethod.enabled"
+	EventTypeRefundCreated                  EventType = "refund.created"
+	EventTypeRefundUpdated                  EventType = "refund.updated"
+	EventTypeRepresentativeCreated          EventType = "representative.created"
+	EventTypeRepresentativeDeleted          EventType = "representative.deleted"
+	EventTypeRepresentativeUpdated          EventType = "representative.updated"
+	EventTypeResolutionLinkCreated          EventType = "resolutionLink.created"
+	EventTypeResolutionLinkUpdated          EventType = "resolutionLink.updated"
+	EventTypeScheduleCreated                EventType = "schedule.created"
+	EventTypeScheduleUpdated                EventType = "schedule.updated"
+	EventTypeScheduleOccurrenceUpdated      EventType = "schedule.occurrenceUpdated"
+	EventTypeSweepCreated                   EventType = "sweep.created"
+	EventTypeSweepUpdated                   EventType = "sweep.updated"
+	EventTypeTestPing                       EventType = "event.test"
+	EventTypeTicketCreated                  EventType = "ticket.created"
+	EventTypeTicketUpdated                  EventType = "ticket.updated"
+	EventTypeTicketMessageAdded             EventType = "ticket.messageAdded"
+	EventTypeTransferCreated                EventType = "transfer.created"
+	EventTypeTransferUpdated                EventType = "transfer.updated"
+	EventTypeUnderwritingUpdated            EventType = "underwriting.updated"
+	EventTypeWalletCreated                  EventType = "wallet.created"
+	EventTypeWalletUpdated                  EventType = "wallet.updated"
+	EventTypeWalletTransactionUpdated       EventType = "walletTransaction.updated"
 )
 
 const (
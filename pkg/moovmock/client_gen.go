@@ -0,0 +1,2279 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package moovmock
+
+import (
+	"context"
+	"io"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+// Client is a programmable fake of moov.API. Set the Func field of each method a test relies
+// on, methods without one return ErrNotStubbed. Every call is recorded, see Calls.
+type Client struct {
+	// AccountsAPI
+
+	CreateAccountFunc          func(ctx context.Context, account moov.CreateAccount) (*moov.Account, *moov.Account, error)
+	CreateAccountOperationFunc func(ctx context.Context, account moov.CreateAccount) (*moov.Operation[moov.Account], error)
+	GetAccountFunc             func(ctx context.Context, accountID string) (*moov.Account, error)
+	UpdateAccountFunc          func(ctx context.Context, account moov.Account) (*moov.Account, error)
+	PatchAccountFunc           func(ctx context.Context, accountID string, account moov.PatchAccount) (*moov.Account, error)
+	ListAccountsFunc           func(ctx context.Context, opts ...moov.ListAccountFilter) ([]moov.Account, error)
+	AllAccountsFunc            func(ctx context.Context, opts ...moov.ListAccountFilter) iter.Seq2[moov.Account, error]
+	DisconnectAccountFunc      func(ctx context.Context, accountID string) error
+	UploadAvatarFunc           func(ctx context.Context, accountID string, file io.Reader) error
+	DeleteAvatarFunc           func(ctx context.Context, accountID string) error
+	CreateAccountBrandingFunc  func(ctx context.Context, accountID string, colorBrand moov.Brand) (*moov.Brand, error)
+	GetAccountBrandingFunc     func(ctx context.Context, accountID string) (*moov.Brand, error)
+	UpsertAccountBrandingFunc  func(ctx context.Context, accountID string, colorBrand moov.Brand) (*moov.Brand, error)
+	ShareConnectionFunc        func(ctx context.Context, subjectAccountID string, connection moov.ShareConnectionRequest) (*moov.ShareConnectionResponse, error)
+	ListIndustriesFunc         func(ctx context.Context) (*moov.Industries, error)
+	EnrichBusinessProfileFunc  func(ctx context.Context, email string) (*moov.EnrichedBusinessProfile, error)
+
+	// ApplePayAPI
+
+	CreateApplePayDomainFunc         func(ctx context.Context, accountID string, domain moov.ApplePayDomains) (*moov.ApplePayDomainsResponse, error)
+	UpdateApplePayDomainFunc         func(ctx context.Context, accountID string, patch moov.PatchApplyPayDomains) error
+	GetApplePayDomainFunc            func(ctx context.Context, accountID string) (*moov.ApplePayDomainsResponse, error)
+	StartApplePaySessionFunc         func(ctx context.Context, accountID string, req moov.StartApplePaySession) (*string, error)
+	LinkApplePayTokenFunc            func(ctx context.Context, accountID string, req moov.LinkApplePay) (*moov.LinkedApplePayPaymentMethod, error)
+	LinkApplePayTokenWithDetailsFunc func(ctx context.Context, accountID string, req moov.LinkApplePay) (*moov.LinkApplePayTokenResponse, error)
+
+	// ApplicationsAPI
+
+	ListApplicationsFunc      func(ctx context.Context) ([]moov.Application, error)
+	CreateApplicationKeysFunc func(ctx context.Context, applicationID string, key moov.CreateApplicationKey) (*moov.ApplicationKeyWithSecret, error)
+
+	// AuthAPI
+
+	PingFunc                 func(ctx context.Context) error
+	RefreshAccessTokenFunc   func(ctx context.Context, refreshToken string) (*moov.AccessTokenResponse, error)
+	RevokeAccessTokenFunc    func(ctx context.Context, token string) error
+	PingAccessTokenFunc      func(ctx context.Context) (*moov.AccessTokenResponse, error)
+	AccountCreationTokenFunc func(ctx context.Context) (*moov.AccessTokenResponse, error)
+	AccessTokenFunc          func(ctx context.Context, scopes ...moov.ScopeBuilder) (*moov.AccessTokenResponse, error)
+
+	// BankAccountsAPI
+
+	CreateBankAccountFunc                 func(ctx context.Context, accountID string, opts ...moov.CreateBankAccountType) (*moov.BankAccount, error)
+	GetBankAccountFunc                    func(ctx context.Context, accountID string, bankAccountID string) (*moov.BankAccount, error)
+	DeleteBankAccountFunc                 func(ctx context.Context, accountID string, bankAccountID string) error
+	ListBankAccountsFunc                  func(ctx context.Context, accountID string) ([]moov.BankAccount, error)
+	MicroDepositInitiateFunc              func(ctx context.Context, accountID string, bankAccountID string) error
+	MicroDepositConfirmFunc               func(ctx context.Context, accountID string, bankAccountID string, amounts []int) error
+	InstantVerificationInitiateFunc       func(ctx context.Context, accountID string, bankAccountID string) error
+	GetInstantBankAccountVerificationFunc func(ctx context.Context, accountID string, bankAccountID string) (*moov.BankAccountVerification, error)
+	InstantVerificationCompleteFunc       func(ctx context.Context, accountID string, bankAccountID string, code string) error
+	SearchInstitutionsFunc                func(ctx context.Context, opts ...moov.ListInstitutionsFilter) (*moov.InstitutionsSearchResponse, error)
+
+	// BillingAPI
+
+	ListFeePlanAgreementsFunc        func(ctx context.Context, accountID string, filters ...moov.FeePlanAgreementListFilter) ([]moov.FeePlanAgreement, error)
+	AllFeePlanAgreementsFunc         func(ctx context.Context, accountID string, filters ...moov.FeePlanAgreementListFilter) iter.Seq2[moov.FeePlanAgreement, error]
+	ListFeePlansFunc                 func(ctx context.Context, accountID string, filters ...moov.FeePlanListFilter) ([]moov.FeePlan, error)
+	CreateFeePlanAgreementFunc       func(ctx context.Context, accountID string, request moov.FeePlanAgreementRequest) (*moov.FeePlanAgreement, error)
+	ListResidualsFunc                func(ctx context.Context, accountID string, filters ...moov.ResidualListFilter) ([]moov.Residual, error)
+	AllResidualsFunc                 func(ctx context.Context, accountID string, filters ...moov.ResidualListFilter) iter.Seq2[moov.Residual, error]
+	GetResidualFunc                  func(ctx context.Context, accountID string, residualID string) (*moov.Residual, error)
+	ListResidualFeesFunc             func(ctx context.Context, accountID string, residualID string, filters ...moov.ResidualFeeListFilter) ([]moov.IncurredFee, error)
+	AllResidualFeesFunc              func(ctx context.Context, accountID string, residualID string, filters ...moov.ResidualFeeListFilter) iter.Seq2[moov.IncurredFee, error]
+	ListPartnerPricingAgreementsFunc func(ctx context.Context, accountID string, filters ...moov.PartnerPricingAgreementListFilter) ([]moov.PartnerPricingAgreement, error)
+	AllPartnerPricingAgreementsFunc  func(ctx context.Context, accountID string, filters ...moov.PartnerPricingAgreementListFilter) iter.Seq2[moov.PartnerPricingAgreement, error]
+	GetFeesFunc                      func(ctx context.Context, accountID string, filters ...moov.FeeGetFilter) ([]moov.IncurredFee, error)
+	ListFeesFunc                     func(ctx context.Context, accountID string, request moov.FeeListRequest) ([]moov.IncurredFee, error)
+	ListFeeRevenueFunc               func(ctx context.Context, accountID string, filters ...moov.FeeRevenueFilter) ([]moov.IncurredFee, error)
+	AllFeeRevenueFunc                func(ctx context.Context, accountID string, filters ...moov.FeeRevenueFilter) iter.Seq2[moov.IncurredFee, error]
+	GetStatementFunc                 func(ctx context.Context, accountID string, statementID string) (*moov.Statement, error)
+	GetStatementPDFFunc              func(ctx context.Context, accountID string, statementID string) ([]byte, error)
+	GetStatementPDFToFunc            func(ctx context.Context, accountID string, statementID string, w io.Writer) error
+	ListStatementsFunc               func(ctx context.Context, accountID string, filters ...moov.ListStatementFilter) ([]moov.Statement, error)
+	AllStatementsFunc                func(ctx context.Context, accountID string, filters ...moov.ListStatementFilter) iter.Seq2[moov.Statement, error]
+
+	// CapabilitiesAPI
+
+	RequestCapabilitiesFunc func(ctx context.Context, accountID string, capabilities []moov.CapabilityName) ([]moov.Capability, error)
+	ListCapabilitiesFunc    func(ctx context.Context, accountID string) ([]moov.Capability, error)
+	GetCapabilityFunc       func(ctx context.Context, accountID string, capability moov.CapabilityName) (*moov.Capability, error)
+	DisableCapabilityFunc   func(ctx context.Context, accountID string, capability moov.CapabilityName) error
+
+	// CardIssuingAPI
+
+	CreateIssuedCardFunc                  func(ctx context.Context, accountID string, card moov.CreateIssuedCard) (*moov.IssuedCard, error)
+	ListIssuedCardsFunc                   func(ctx context.Context, accountID string, filters ...moov.ListIssuedCardsFilter) ([]moov.IssuedCard, error)
+	AllIssuedCardsFunc                    func(ctx context.Context, accountID string, filters ...moov.ListIssuedCardsFilter) iter.Seq2[moov.IssuedCard, error]
+	GetIssuedCardFunc                     func(ctx context.Context, accountID string, cardID string) (*moov.IssuedCard, error)
+	UpdateIssuedCardFunc                  func(ctx context.Context, accountID string, cardID string, update moov.UpdateIssuedCard) (*moov.IssuedCard, error)
+	ListIssuedCardAuthorizationsFunc      func(ctx context.Context, accountID string, filters ...moov.ListIssuedCardAuthorizationsFilter) ([]moov.IssuedCardAuthorization, error)
+	AllIssuedCardAuthorizationsFunc       func(ctx context.Context, accountID string, filters ...moov.ListIssuedCardAuthorizationsFilter) iter.Seq2[moov.IssuedCardAuthorization, error]
+	GetIssuedCardAuthorizationFunc        func(ctx context.Context, accountID string, authorizationID string) (*moov.IssuedCardAuthorization, error)
+	ListIssuedCardAuthorizationEventsFunc func(ctx context.Context, accountID string, authorizationID string, filters ...moov.ListIssuedCardAuthorizationEventsFilter) ([]moov.IssuedCardAuthorizationEvent, error)
+	AllIssuedCardAuthorizationEventsFunc  func(ctx context.Context, accountID string, authorizationID string, filters ...moov.ListIssuedCardAuthorizationEventsFilter) iter.Seq2[moov.IssuedCardAuthorizationEvent, error]
+	ListIssuedCardTransactionsFunc        func(ctx context.Context, accountID string, filters ...moov.ListIssuedCardTransactionsFilter) ([]moov.IssuedCardTransaction, error)
+	AllIssuedCardTransactionsFunc         func(ctx context.Context, accountID string, filters ...moov.ListIssuedCardTransactionsFilter) iter.Seq2[moov.IssuedCardTransaction, error]
+	GetIssuedCardTransactionFunc          func(ctx context.Context, accountID string, cardTransactionID string) (*moov.IssuedCardTransaction, error)
+
+	// CardsAPI
+
+	GetCardMetadataFunc           func(ctx context.Context, request moov.CardMetadataRequest) (*moov.CardMetadata, error)
+	CreateCardFunc                func(ctx context.Context, accountID string, card moov.CreateCard) (*moov.Card, error)
+	ListCardsFunc                 func(ctx context.Context, accountID string) ([]moov.Card, error)
+	GetCardFunc                   func(ctx context.Context, accountID string, cardID string) (*moov.Card, error)
+	UpdateCardFunc                func(ctx context.Context, accountID string, cardID string, opt1 moov.CardUpdateFilter, opts ...moov.CardUpdateFilter) (*moov.Card, error)
+	DisableCardFunc               func(ctx context.Context, accountID string, cardID string) error
+	GenerateEndToEndPublicKeyFunc func(ctx context.Context) (*jose.JSONWebKey, error)
+	TestEndToEndTokenFunc         func(ctx context.Context, token string) error
+
+	// DisputesAPI
+
+	ListDisputesFunc          func(ctx context.Context, accountID string, filters ...moov.DisputeListFilter) ([]moov.Dispute, error)
+	AllDisputesFunc           func(ctx context.Context, accountID string, filters ...moov.DisputeListFilter) iter.Seq2[moov.Dispute, error]
+	GetDisputeFunc            func(ctx context.Context, accountID string, disputeID string) (*moov.Dispute, error)
+	AcceptDisputeFunc         func(ctx context.Context, accountID string, disputeID string) (*moov.Dispute, error)
+	UploadDisputeEvidenceFunc func(ctx context.Context, accountID string, disputeID string, evidenceText moov.DisputesEvidenceText) (*moov.DisputeEvidence, error)
+	DeleteDisputeEvidenceFunc func(ctx context.Context, accountID string, disputeID string, evidenceID string) error
+	UploadEvidenceFileFunc    func(ctx context.Context, accountID string, disputeID string, evidenceType moov.EvidenceType, filename string, file io.Reader, mimeType string) (*moov.DisputeEvidenceUpload, error)
+	ListDisputeEvidenceFunc   func(ctx context.Context, accountID string, disputeID string) ([]moov.DisputeEvidence, error)
+	SubmitDisputeEvidenceFunc func(ctx context.Context, accountID string, disputeID string) (*moov.Dispute, error)
+	UpdateDisputeEvidenceFunc func(ctx context.Context, accountID string, disputeID string, evidenceID string, evidenceUpdate moov.DisputesEvidenceUpdate) (*moov.DisputeEvidence, error)
+	GetDisputeEvidenceFunc    func(ctx context.Context, accountID string, disputeID string, evidenceID string) (*moov.DisputeEvidence, error)
+
+	// FilesAPI
+
+	UploadFileFunc func(ctx context.Context, accountID string, upload moov.UploadFile) (*moov.File, error)
+	ListFilesFunc  func(ctx context.Context, accountID string) ([]moov.File, error)
+	GetFileFunc    func(ctx context.Context, accountID string, fileID string) (*moov.File, error)
+
+	// ImagesAPI
+
+	UploadImageFunc         func(ctx context.Context, accountID string, file io.Reader, metadata *moov.ImageMetadataRequest) (*moov.ImageMetadata, error)
+	ListImageMetadataFunc   func(ctx context.Context, accountID string, filters ...moov.ImageListFilter) ([]moov.ImageMetadata, error)
+	AllImageMetadataFunc    func(ctx context.Context, accountID string, filters ...moov.ImageListFilter) iter.Seq2[moov.ImageMetadata, error]
+	GetImageMetadataFunc    func(ctx context.Context, accountID string, imageID string) (*moov.ImageMetadata, error)
+	UpdateImageFunc         func(ctx context.Context, accountID string, imageID string, file io.Reader, metadata *moov.ImageMetadataRequest) (*moov.ImageMetadata, error)
+	UpdateImageMetadataFunc func(ctx context.Context, accountID string, imageID string, metadata moov.ImageMetadataRequest) (*moov.ImageMetadata, error)
+	DeleteImageFunc         func(ctx context.Context, accountID string, imageID string) error
+
+	// InvoicesAPI
+
+	CreateInvoiceFunc        func(ctx context.Context, accountID string, invoice moov.CreateInvoice) (*moov.Invoice, error)
+	GetInvoiceFunc           func(ctx context.Context, accountID string, invoiceID string) (*moov.Invoice, error)
+	UpdateInvoiceFunc        func(ctx context.Context, accountID string, invoiceID string, invoice moov.UpdateInvoice) (*moov.Invoice, error)
+	CreateInvoicePaymentFunc func(ctx context.Context, accountID string, invoiceID string, payment moov.CreateInvoicePayment) (*moov.InvoicePayment, error)
+	ListInvoicesFunc         func(ctx context.Context, accountID string, filters ...moov.ListInvoiceFilter) ([]moov.Invoice, error)
+	AllInvoicesFunc          func(ctx context.Context, accountID string, filters ...moov.ListInvoiceFilter) iter.Seq2[moov.Invoice, error]
+	DeleteInvoiceFunc        func(ctx context.Context, accountID string, invoiceID string) error
+	ListInvoicePaymentsFunc  func(ctx context.Context, accountID string, invoiceID string) ([]moov.InvoicePayment, error)
+
+	// PaymentMethodsAPI
+
+	ListPaymentMethodsFunc func(ctx context.Context, accountID string, opts ...moov.PaymentMethodListFilter) ([]moov.PaymentMethod, error)
+	GetPaymentMethodFunc   func(ctx context.Context, accountID string, paymentMethodID string) (*moov.PaymentMethod, error)
+
+	// ProductsAPI
+
+	CreateProductFunc  func(ctx context.Context, accountID string, product moov.ProductRequest) (*moov.Product, error)
+	ListProductsFunc   func(ctx context.Context, accountID string, filters ...moov.ProductListFilter) ([]moov.Product, error)
+	AllProductsFunc    func(ctx context.Context, accountID string, filters ...moov.ProductListFilter) iter.Seq2[moov.Product, error]
+	GetProductFunc     func(ctx context.Context, accountID string, productID string) (*moov.Product, error)
+	UpdateProductFunc  func(ctx context.Context, accountID string, productID string, product moov.ProductRequest) (*moov.Product, error)
+	DisableProductFunc func(ctx context.Context, accountID string, productID string) error
+
+	// ReceiptsAPI
+
+	CreateReceiptFunc  func(ctx context.Context, receipts ...moov.CreateReceipt) ([]moov.Receipt, error)
+	CreateReceiptsFunc func(ctx context.Context, receipts []moov.CreateReceipt, options ...moov.CreateReceiptArgs) ([]moov.Receipt, error)
+	ListReceiptsFunc   func(ctx context.Context, filters ...moov.ListReceiptsFilter) ([]moov.Receipt, error)
+	DeleteReceiptFunc  func(ctx context.Context, receiptID string) error
+
+	// RepresentativesAPI
+
+	CreateRepresentativeFunc func(ctx context.Context, accountID string, representative moov.CreateRepresentative) (*moov.Representative, error)
+	ListRepresentativesFunc  func(ctx context.Context, accountID string) ([]moov.Representative, error)
+	GetRepresentativeFunc    func(ctx context.Context, accountID string, representativeID string) (*moov.Representative, error)
+	UpdateRepresentativeFunc func(ctx context.Context, representativeAccountID string, representativeID string, representative moov.UpdateRepresentative) (*moov.Representative, error)
+	DeleteRepresentativeFunc func(ctx context.Context, accountID string, representativeAccountID string) error
+
+	// ResolutionLinksAPI
+
+	CreateResolutionLinkFunc func(ctx context.Context, accountID string, resolutionLink moov.CreateResolutionLinkRequest) (*moov.ResolutionLinkResponse, error)
+	GetResolutionLinkFunc    func(ctx context.Context, accountID string, resolutionLinkCode string) (*moov.ResolutionLinkResponse, error)
+	ListResolutionLinksFunc  func(ctx context.Context, accountID string) ([]moov.ResolutionLinkResponse, error)
+	DeleteResolutionLinkFunc func(ctx context.Context, accountID string, resolutionLinkCode string) error
+
+	// SchedulesAPI
+
+	CreateScheduleFunc func(ctx context.Context, accountID string, schedule moov.CreateSchedule) (*moov.Schedule, error)
+	GetScheduleFunc    func(ctx context.Context, accountID string, scheduleID string) (*moov.Schedule, error)
+	UpdateScheduleFunc func(ctx context.Context, accountID string, scheduleID string, schedule moov.UpdateSchedule) (*moov.Schedule, error)
+	CancelScheduleFunc func(ctx context.Context, accountID string, scheduleID string) error
+
+	// SupportAPI
+
+	CreateTicketFunc       func(ctx context.Context, accountID string, create moov.CreateTicket) (*moov.Ticket, error)
+	ListTicketsFunc        func(ctx context.Context, accountID string, filters ...moov.ListTicketFilter) (*moov.ListTicket, error)
+	AllTicketsFunc         func(ctx context.Context, accountID string, filters ...moov.ListTicketFilter) iter.Seq2[moov.Ticket, error]
+	ListTicketMessagesFunc func(ctx context.Context, accountID string, ticketID string) ([]moov.TicketMessage, error)
+	GetTicketFunc          func(ctx context.Context, accountID string, ticketID string) (*moov.Ticket, error)
+	UpdateTicketFunc       func(ctx context.Context, accountID string, ticketID string, update moov.UpdateTicket) (*moov.Ticket, error)
+
+	// SweepsAPI
+
+	ListSweepConfigsFunc  func(ctx context.Context, accountID string) ([]moov.SweepConfig, error)
+	GetSweepConfigFunc    func(ctx context.Context, accountID string, sweepConfigID string) (*moov.SweepConfig, error)
+	CreateSweepConfigFunc func(ctx context.Context, create moov.CreateSweepConfig) (*moov.SweepConfig, error)
+	UpdateSweepConfigFunc func(ctx context.Context, update moov.UpdateSweepConfig) (*moov.SweepConfig, error)
+	ListSweepsFunc        func(ctx context.Context, accountID string, walletID string, filters ...moov.ListSweepsFilter) ([]moov.Sweep, error)
+	AllSweepsFunc         func(ctx context.Context, accountID string, walletID string, filters ...moov.ListSweepsFilter) iter.Seq2[moov.Sweep, error]
+	GetSweepFunc          func(ctx context.Context, accountID string, walletID string, sweepID string) (*moov.Sweep, error)
+
+	// TerminalApplicationsAPI
+
+	LinkAccountTerminalApplicationFunc             func(ctx context.Context, accountID string, terminalApplicationID string) (*moov.AccountTerminalApplication, error)
+	GetAccountTerminalApplicationFunc              func(ctx context.Context, accountID string, terminalApplicationID string) (*moov.AccountTerminalApplication, error)
+	ListAccountTerminalApplicationsFunc            func(ctx context.Context, accountID string) ([]moov.AccountTerminalApplication, error)
+	GetAccountTerminalApplicationConfigurationFunc func(ctx context.Context, accountID string, terminalApplicationID string) (*moov.AccountTerminalApplicationConfiguration, error)
+	CreateTerminalApplicationFunc                  func(ctx context.Context, terminalApplication moov.TerminalApplicationRequest) (*moov.TerminalApplication, error)
+	GetTerminalApplicationFunc                     func(ctx context.Context, terminalApplicationID string) (*moov.TerminalApplication, error)
+	ListTerminalApplicationsFunc                   func(ctx context.Context) ([]moov.TerminalApplication, error)
+	DeleteTerminalApplicationFunc                  func(ctx context.Context, terminalApplicationID string) error
+	CreateTerminalApplicationVersionFunc           func(ctx context.Context, terminalApplicationID string, version string) (*moov.TerminalApplicationVersion, error)
+
+	// TransfersAPI
+
+	GetTransferRiskOutcomesFunc func(ctx context.Context, transferID string) (*moov.TransferRiskOutcomes, error)
+	CreateTransferFunc          func(ctx context.Context, partnerAccountID string, transfer moov.CreateTransfer, options ...moov.CreateTransferArgs) moov.CreateTransferBuilder
+	ListTransfersFunc           func(ctx context.Context, accountID string, filters ...moov.ListTransferFilter) ([]moov.Transfer, error)
+	AllTransfersFunc            func(ctx context.Context, accountID string, filters ...moov.ListTransferFilter) iter.Seq2[moov.Transfer, error]
+	GetTransferFunc             func(ctx context.Context, accountID string, transferID string) (*moov.Transfer, error)
+	PatchTransferFunc           func(ctx context.Context, accountID string, transferID string, patches ...moov.TransferPatcher) (*moov.Transfer, error)
+	RefundTransferFunc          func(ctx context.Context, partnerAccountID string, transferID string, refund moov.CreateRefund, options ...moov.CreateRefundArgs) (*moov.Refund, *moov.RefundStarted, error)
+	RefundTransferOperationFunc func(ctx context.Context, partnerAccountID string, transferID string, refund moov.CreateRefund, options ...moov.CreateRefundArgs) (*moov.Operation[moov.Refund], error)
+	ListRefundsFunc             func(ctx context.Context, accountID string, transferID string) ([]moov.Refund, error)
+	GetRefundFunc               func(ctx context.Context, accountID string, transferID string, refundID string) (*moov.Refund, error)
+	ReverseTransferFunc         func(ctx context.Context, partnerAccountID string, transferID string, refund moov.CreateReversal, options ...moov.CreateReversalArgs) (*moov.CreatedReversal, error)
+	CancelTransferFunc          func(ctx context.Context, accountID string, transferID string) (*moov.Cancellation, error)
+	GetCancellationFunc         func(ctx context.Context, accountID string, transferID string, cancellationID string) (*moov.Cancellation, error)
+	ListCancellationsFunc       func(ctx context.Context, accountID string, transferID string) ([]moov.Cancellation, error)
+	TransferOptionsFunc         func(ctx context.Context, partnerAccountID string, payload moov.CreateTransferOptions) (*moov.TransferOptions, error)
+	CreateTransferConfigFunc    func(ctx context.Context, accountID string, config moov.UpsertTransferConfig) (*moov.TransferConfig, error)
+	GetTransferConfigFunc       func(ctx context.Context, accountID string) (*moov.TransferConfig, error)
+	UpdateTransferConfigFunc    func(ctx context.Context, accountID string, config moov.UpsertTransferConfig) (*moov.TransferConfig, error)
+
+	// UnderwritingAPI
+
+	UpsertUnderwritingFunc func(ctx context.Context, accountID string, underwriting moov.UpdateUnderwriting) (*moov.Underwriting, error)
+	GetUnderwritingFunc    func(ctx context.Context, accountID string) (*moov.Underwriting, error)
+
+	// WalletsAPI
+
+	ListWalletsFunc            func(ctx context.Context, accountID string, filters ...moov.ListWalletFilter) ([]moov.Wallet, error)
+	AllWalletsFunc             func(ctx context.Context, accountID string, filters ...moov.ListWalletFilter) iter.Seq2[moov.Wallet, error]
+	GetWalletFunc              func(ctx context.Context, accountID string, walletID string) (*moov.Wallet, error)
+	CreateWalletFunc           func(ctx context.Context, accountID string, create moov.CreateWallet) (*moov.Wallet, error)
+	UpdateWalletFunc           func(ctx context.Context, accountID string, walletID string, update moov.UpdateWallet) (*moov.Wallet, error)
+	ListWalletTransactionsFunc func(ctx context.Context, accountID string, walletID string, opts ...moov.ListTransactionFilter) ([]moov.WalletTransaction, error)
+	AllWalletTransactionsFunc  func(ctx context.Context, accountID string, walletID string, opts ...moov.ListTransactionFilter) iter.Seq2[moov.WalletTransaction, error]
+	GetWalletTransactionFunc   func(ctx context.Context, accountID string, walletID string, transactionID string) (*moov.WalletTransaction, error)
+
+	// WebhooksAPI
+
+	CreateWebhookFunc            func(ctx context.Context, webhook moov.CreateWebhook) (*moov.Webhook, error)
+	ListWebhooksFunc             func(ctx context.Context) ([]moov.Webhook, error)
+	GetWebhookFunc               func(ctx context.Context, webhookID string) (*moov.Webhook, error)
+	UpdateWebhookFunc            func(ctx context.Context, webhookID string, webhook moov.UpdateWebhook) (*moov.Webhook, error)
+	DeleteWebhookFunc            func(ctx context.Context, webhookID string) error
+	PingWebhookFunc              func(ctx context.Context, webhookID string) (*moov.WebhookPing, error)
+	GetWebhookSecretFunc         func(ctx context.Context, webhookID string) (*moov.WebhookSecret, error)
+	ListWebhookEventTypesFunc    func(ctx context.Context) ([]moov.WebhookEventType, error)
+	NewWebhookSecretRotationFunc func(ctx context.Context, webhookID string, grace time.Duration) (*moov.WebhookSecretRotation, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ moov.API = (*Client)(nil)
+
+// CreateAccount records the call and returns the results of CreateAccountFunc.
+func (c *Client) CreateAccount(ctx context.Context, account moov.CreateAccount) (*moov.Account, *moov.Account, error) {
+	c.record("CreateAccount", account)
+	if c.CreateAccountFunc == nil {
+		err := notStubbed("CreateAccount")
+		return nil, nil, err
+	}
+	return c.CreateAccountFunc(ctx, account)
+}
+
+// CreateAccountOperation records the call and returns the results of CreateAccountOperationFunc.
+func (c *Client) CreateAccountOperation(ctx context.Context, account moov.CreateAccount) (*moov.Operation[moov.Account], error) {
+	c.record("CreateAccountOperation", account)
+	if c.CreateAccountOperationFunc == nil {
+		err := notStubbed("CreateAccountOperation")
+		return nil, err
+	}
+	return c.CreateAccountOperationFunc(ctx, account)
+}
+
+// GetAccount records the call and returns the results of GetAccountFunc.
+func (c *Client) GetAccount(ctx context.Context, accountID string) (*moov.Account, error) {
+	c.record("GetAccount", accountID)
+	if c.GetAccountFunc == nil {
+		err := notStubbed("GetAccount")
+		return nil, err
+	}
+	return c.GetAccountFunc(ctx, accountID)
+}
+
+// UpdateAccount records the call and returns the results of UpdateAccountFunc.
+func (c *Client) UpdateAccount(ctx context.Context, account moov.Account) (*moov.Account, error) {
+	c.record("UpdateAccount", account)
+	if c.UpdateAccountFunc == nil {
+		err := notStubbed("UpdateAccount")
+		return nil, err
+	}
+	return c.UpdateAccountFunc(ctx, account)
+}
+
+// PatchAccount records the call and returns the results of PatchAccountFunc.
+func (c *Client) PatchAccount(ctx context.Context, accountID string, account moov.PatchAccount) (*moov.Account, error) {
+	c.record("PatchAccount", accountID, account)
+	if c.PatchAccountFunc == nil {
+		err := notStubbed("PatchAccount")
+		return nil, err
+	}
+	return c.PatchAccountFunc(ctx, accountID, account)
+}
+
+// ListAccounts records the call and returns the results of ListAccountsFunc.
+func (c *Client) ListAccounts(ctx context.Context, opts ...moov.ListAccountFilter) ([]moov.Account, error) {
+	c.record("ListAccounts", opts)
+	if c.ListAccountsFunc == nil {
+		err := notStubbed("ListAccounts")
+		return nil, err
+	}
+	return c.ListAccountsFunc(ctx, opts...)
+}
+
+// AllAccounts records the call and returns the results of AllAccountsFunc.
+func (c *Client) AllAccounts(ctx context.Context, opts ...moov.ListAccountFilter) iter.Seq2[moov.Account, error] {
+	c.record("AllAccounts", opts)
+	if c.AllAccountsFunc == nil {
+		err := notStubbed("AllAccounts")
+		return failedSeq[moov.Account](err)
+	}
+	return c.AllAccountsFunc(ctx, opts...)
+}
+
+// DisconnectAccount records the call and returns the results of DisconnectAccountFunc.
+func (c *Client) DisconnectAccount(ctx context.Context, accountID string) error {
+	c.record("DisconnectAccount", accountID)
+	if c.DisconnectAccountFunc == nil {
+		err := notStubbed("DisconnectAccount")
+		return err
+	}
+	return c.DisconnectAccountFunc(ctx, accountID)
+}
+
+// UploadAvatar records the call and returns the results of UploadAvatarFunc.
+func (c *Client) UploadAvatar(ctx context.Context, accountID string, file io.Reader) error {
+	c.record("UploadAvatar", accountID, file)
+	if c.UploadAvatarFunc == nil {
+		err := notStubbed("UploadAvatar")
+		return err
+	}
+	return c.UploadAvatarFunc(ctx, accountID, file)
+}
+
+// DeleteAvatar records the call and returns the results of DeleteAvatarFunc.
+func (c *Client) DeleteAvatar(ctx context.Context, accountID string) error {
+	c.record("DeleteAvatar", accountID)
+	if c.DeleteAvatarFunc == nil {
+		err := notStubbed("DeleteAvatar")
+		return err
+	}
+	return c.DeleteAvatarFunc(ctx, accountID)
+}
+
+// CreateAccountBranding records the call and returns the results of CreateAccountBrandingFunc.
+func (c *Client) CreateAccountBranding(ctx context.Context, accountID string, colorBrand moov.Brand) (*moov.Brand, error) {
+	c.record("CreateAccountBranding", accountID, colorBrand)
+	if c.CreateAccountBrandingFunc == nil {
+		err := notStubbed("CreateAccountBranding")
+		return nil, err
+	}
+	return c.CreateAccountBrandingFunc(ctx, accountID, colorBrand)
+}
+
+// GetAccountBranding records the call and returns the results of GetAccountBrandingFunc.
+func (c *Client) GetAccountBranding(ctx context.Context, accountID string) (*moov.Brand, error) {
+	c.record("GetAccountBranding", accountID)
+	if c.GetAccountBrandingFunc == nil {
+		err := notStubbed("GetAccountBranding")
+		return nil, err
+	}
+	return c.GetAccountBrandingFunc(ctx, accountID)
+}
+
+// UpsertAccountBranding records the call and returns the results of UpsertAccountBrandingFunc.
+func (c *Client) UpsertAccountBranding(ctx context.Context, accountID string, colorBrand moov.Brand) (*moov.Brand, error) {
+	c.record("UpsertAccountBranding", accountID, colorBrand)
+	if c.UpsertAccountBrandingFunc == nil {
+		err := notStubbed("UpsertAccountBranding")
+		return nil, err
+	}
+	return c.UpsertAccountBrandingFunc(ctx, accountID, colorBrand)
+}
+
+// ShareConnection records the call and returns the results of ShareConnectionFunc.
+func (c *Client) ShareConnection(ctx context.Context, subjectAccountID string, connection moov.ShareConnectionRequest) (*moov.ShareConnectionResponse, error) {
+	c.record("ShareConnection", subjectAccountID, connection)
+	if c.ShareConnectionFunc == nil {
+		err := notStubbed("ShareConnection")
+		return nil, err
+	}
+	return c.ShareConnectionFunc(ctx, subjectAccountID, connection)
+}
+
+// ListIndustries records the call and returns the results of ListIndustriesFunc.
+func (c *Client) ListIndustries(ctx context.Context) (*moov.Industries, error) {
+	c.record("ListIndustries")
+	if c.ListIndustriesFunc == nil {
+		err := notStubbed("ListIndustries")
+		return nil, err
+	}
+	return c.ListIndustriesFunc(ctx)
+}
+
+// EnrichBusinessProfile records the call and returns the results of EnrichBusinessProfileFunc.
+func (c *Client) EnrichBusinessProfile(ctx context.Context, email string) (*moov.EnrichedBusinessProfile, error) {
+	c.record("EnrichBusinessProfile", email)
+	if c.EnrichBusinessProfileFunc == nil {
+		err := notStubbed("EnrichBusinessProfile")
+		return nil, err
+	}
+	return c.EnrichBusinessProfileFunc(ctx, email)
+}
+
+// CreateApplePayDomain records the call and returns the results of CreateApplePayDomainFunc.
+func (c *Client) CreateApplePayDomain(ctx context.Context, accountID string, domain moov.ApplePayDomains) (*moov.ApplePayDomainsResponse, error) {
+	c.record("CreateApplePayDomain", accountID, domain)
+	if c.CreateApplePayDomainFunc == nil {
+		err := notStubbed("CreateApplePayDomain")
+		return nil, err
+	}
+	return c.CreateApplePayDomainFunc(ctx, accountID, domain)
+}
+
+// UpdateApplePayDomain records the call and returns the results of UpdateApplePayDomainFunc.
+func (c *Client) UpdateApplePayDomain(ctx context.Context, accountID string, patch moov.PatchApplyPayDomains) error {
+	c.record("UpdateApplePayDomain", accountID, patch)
+	if c.UpdateApplePayDomainFunc == nil {
+		err := notStubbed("UpdateApplePayDomain")
+		return err
+	}
+	return c.UpdateApplePayDomainFunc(ctx, accountID, patch)
+}
+
+// GetApplePayDomain records the call and returns the results of GetApplePayDomainFunc.
+func (c *Client) GetApplePayDomain(ctx context.Context, accountID string) (*moov.ApplePayDomainsResponse, error) {
+	c.record("GetApplePayDomain", accountID)
+	if c.GetApplePayDomainFunc == nil {
+		err := notStubbed("GetApplePayDomain")
+		return nil, err
+	}
+	return c.GetApplePayDomainFunc(ctx, accountID)
+}
+
+// StartApplePaySession records the call and returns the results of StartApplePaySessionFunc.
+func (c *Client) StartApplePaySession(ctx context.Context, accountID string, req moov.StartApplePaySession) (*string, error) {
+	c.record("StartApplePaySession", accountID, req)
+	if c.StartApplePaySessionFunc == nil {
+		err := notStubbed("StartApplePaySession")
+		return nil, err
+	}
+	return c.StartApplePaySessionFunc(ctx, accountID, req)
+}
+
+// LinkApplePayToken records the call and returns the results of LinkApplePayTokenFunc.
+func (c *Client) LinkApplePayToken(ctx context.Context, accountID string, req moov.LinkApplePay) (*moov.LinkedApplePayPaymentMethod, error) {
+	c.record("LinkApplePayToken", accountID, req)
+	if c.LinkApplePayTokenFunc == nil {
+		err := notStubbed("LinkApplePayToken")
+		return nil, err
+	}
+	return c.LinkApplePayTokenFunc(ctx, accountID, req)
+}
+
+// LinkApplePayTokenWithDetails records the call and returns the results of LinkApplePayTokenWithDetailsFunc.
+func (c *Client) LinkApplePayTokenWithDetails(ctx context.Context, accountID string, req moov.LinkApplePay) (*moov.LinkApplePayTokenResponse, error) {
+	c.record("LinkApplePayTokenWithDetails", accountID, req)
+	if c.LinkApplePayTokenWithDetailsFunc == nil {
+		err := notStubbed("LinkApplePayTokenWithDetails")
+		return nil, err
+	}
+	return c.LinkApplePayTokenWithDetailsFunc(ctx, accountID, req)
+}
+
+// ListApplications records the call and returns the results of ListApplicationsFunc.
+func (c *Client) ListApplications(ctx context.Context) ([]moov.Application, error) {
+	c.record("ListApplications")
+	if c.ListApplicationsFunc == nil {
+		err := notStubbed("ListApplications")
+		return nil, err
+	}
+	return c.ListApplicationsFunc(ctx)
+}
+
+// CreateApplicationKeys records the call and returns the results of CreateApplicationKeysFunc.
+func (c *Client) CreateApplicationKeys(ctx context.Context, applicationID string, key moov.CreateApplicationKey) (*moov.ApplicationKeyWithSecret, error) {
+	c.record("CreateApplicationKeys", applicationID, key)
+	if c.CreateApplicationKeysFunc == nil {
+		err := notStubbed("CreateApplicationKeys")
+		return nil, err
+	}
+	return c.CreateApplicationKeysFunc(ctx, applicationID, key)
+}
+
+// Ping records the call and returns the results of PingFunc.
+func (c *Client) Ping(ctx context.Context) error {
+	c.record("Ping")
+	if c.PingFunc == nil {
+		err := notStubbed("Ping")
+		return err
+	}
+	return c.PingFunc(ctx)
+}
+
+// RefreshAccessToken records the call and returns the results of RefreshAccessTokenFunc.
+func (c *Client) RefreshAccessToken(ctx context.Context, refreshToken string) (*moov.AccessTokenResponse, error) {
+	c.record("RefreshAccessToken", refreshToken)
+	if c.RefreshAccessTokenFunc == nil {
+		err := notStubbed("RefreshAccessToken")
+		return nil, err
+	}
+	return c.RefreshAccessTokenFunc(ctx, refreshToken)
+}
+
+// RevokeAccessToken records the call and returns the results of RevokeAccessTokenFunc.
+func (c *Client) RevokeAccessToken(ctx context.Context, token string) error {
+	c.record("RevokeAccessToken", token)
+	if c.RevokeAccessTokenFunc == nil {
+		err := notStubbed("RevokeAccessToken")
+		return err
+	}
+	return c.RevokeAccessTokenFunc(ctx, token)
+}
+
+// PingAccessToken records the call and returns the results of PingAccessTokenFunc.
+func (c *Client) PingAccessToken(ctx context.Context) (*moov.AccessTokenResponse, error) {
+	c.record("PingAccessToken")
+	if c.PingAccessTokenFunc == nil {
+		err := notStubbed("PingAccessToken")
+		return nil, err
+	}
+	return c.PingAccessTokenFunc(ctx)
+}
+
+// AccountCreationToken records the call and returns the results of AccountCreationTokenFunc.
+func (c *Client) AccountCreationToken(ctx context.Context) (*moov.AccessTokenResponse, error) {
+	c.record("AccountCreationToken")
+	if c.AccountCreationTokenFunc == nil {
+		err := notStubbed("AccountCreationToken")
+		return nil, err
+	}
+	return c.AccountCreationTokenFunc(ctx)
+}
+
+// AccessToken records the call and returns the results of AccessTokenFunc.
+func (c *Client) AccessToken(ctx context.Context, scopes ...moov.ScopeBuilder) (*moov.AccessTokenResponse, error) {
+	c.record("AccessToken", scopes)
+	if c.AccessTokenFunc == nil {
+		err := notStubbed("AccessToken")
+		return nil, err
+	}
+	return c.AccessTokenFunc(ctx, scopes...)
+}
+
+// CreateBankAccount records the call and returns the results of CreateBankAccountFunc.
+func (c *Client) CreateBankAccount(ctx context.Context, accountID string, opts ...moov.CreateBankAccountType) (*moov.BankAccount, error) {
+	c.record("CreateBankAccount", accountID, opts)
+	if c.CreateBankAccountFunc == nil {
+		err := notStubbed("CreateBankAccount")
+		return nil, err
+	}
+	return c.CreateBankAccountFunc(ctx, accountID, opts...)
+}
+
+// GetBankAccount records the call and returns the results of GetBankAccountFunc.
+func (c *Client) GetBankAccount(ctx context.Context, accountID string, bankAccountID string) (*moov.BankAccount, error) {
+	c.record("GetBankAccount", accountID, bankAccountID)
+	if c.GetBankAccountFunc == nil {
+		err := notStubbed("GetBankAccount")
+		return nil, err
+	}
+	return c.GetBankAccountFunc(ctx, accountID, bankAccountID)
+}
+
+// DeleteBankAccount records the call and returns the results of DeleteBankAccountFunc.
+func (c *Client) DeleteBankAccount(ctx context.Context, accountID string, bankAccountID string) error {
+	c.record("DeleteBankAccount", accountID, bankAccountID)
+	if c.DeleteBankAccountFunc == nil {
+		err := notStubbed("DeleteBankAccount")
+		return err
+	}
+	return c.DeleteBankAccountFunc(ctx, accountID, bankAccountID)
+}
+
+// ListBankAccounts records the call and returns the results of ListBankAccountsFunc.
+func (c *Client) ListBankAccounts(ctx context.Context, accountID string) ([]moov.BankAccount, error) {
+	c.record("ListBankAccounts", accountID)
+	if c.ListBankAccountsFunc == nil {
+		err := notStubbed("ListBankAccounts")
+		return nil, err
+	}
+	return c.ListBankAccountsFunc(ctx, accountID)
+}
+
+// MicroDepositInitiate records the call and returns the results of MicroDepositInitiateFunc.
+func (c *Client) MicroDepositInitiate(ctx context.Context, accountID string, bankAccountID string) error {
+	c.record("MicroDepositInitiate", accountID, bankAccountID)
+	if c.MicroDepositInitiateFunc == nil {
+		err := notStubbed("MicroDepositInitiate")
+		return err
+	}
+	return c.MicroDepositInitiateFunc(ctx, accountID, bankAccountID)
+}
+
+// MicroDepositConfirm records the call and returns the results of MicroDepositConfirmFunc.
+func (c *Client) MicroDepositConfirm(ctx context.Context, accountID string, bankAccountID string, amounts []int) error {
+	c.record("MicroDepositConfirm", accountID, bankAccountID, amounts)
+	if c.MicroDepositConfirmFunc == nil {
+		err := notStubbed("MicroDepositConfirm")
+		return err
+	}
+	return c.MicroDepositConfirmFunc(ctx, accountID, bankAccountID, amounts)
+}
+
+// InstantVerificationInitiate records the call and returns the results of InstantVerificationInitiateFunc.
+func (c *Client) InstantVerificationInitiate(ctx context.Context, accountID string, bankAccountID string) error {
+	c.record("InstantVerificationInitiate", accountID, bankAccountID)
+	if c.InstantVerificationInitiateFunc == nil {
+		err := notStubbed("InstantVerificationInitiate")
+		return err
+	}
+	return c.InstantVerificationInitiateFunc(ctx, accountID, bankAccountID)
+}
+
+// GetInstantBankAccountVerification records the call and returns the results of GetInstantBankAccountVerificationFunc.
+func (c *Client) GetInstantBankAccountVerification(ctx context.Context, accountID string, bankAccountID string) (*moov.BankAccountVerification, error) {
+	c.record("GetInstantBankAccountVerification", accountID, bankAccountID)
+	if c.GetInstantBankAccountVerificationFunc == nil {
+		err := notStubbed("GetInstantBankAccountVerification")
+		return nil, err
+	}
+	return c.GetInstantBankAccountVerificationFunc(ctx, accountID, bankAccountID)
+}
+
+// InstantVerificationComplete records the call and returns the results of InstantVerificationCompleteFunc.
+func (c *Client) InstantVerificationComplete(ctx context.Context, accountID string, bankAccountID string, code string) error {
+	c.record("InstantVerificationComplete", accountID, bankAccountID, code)
+	if c.InstantVerificationCompleteFunc == nil {
+		err := notStubbed("InstantVerificationComplete")
+		return err
+	}
+	return c.InstantVerificationCompleteFunc(ctx, accountID, bankAccountID, code)
+}
+
+// SearchInstitutions records the call and returns the results of SearchInstitutionsFunc.
+func (c *Client) SearchInstitutions(ctx context.Context, opts ...moov.ListInstitutionsFilter) (*moov.InstitutionsSearchResponse, error) {
+	c.record("SearchInstitutions", opts)
+	if c.SearchInstitutionsFunc == nil {
+		err := notStubbed("SearchInstitutions")
+		return nil, err
+	}
+	return c.SearchInstitutionsFunc(ctx, opts...)
+}
+
+// ListFeePlanAgreements records the call and returns the results of ListFeePlanAgreementsFunc.
+func (c *Client) ListFeePlanAgreements(ctx context.Context, accountID string, filters ...moov.FeePlanAgreementListFilter) ([]moov.FeePlanAgreement, error) {
+	c.record("ListFeePlanAgreements", accountID, filters)
+	if c.ListFeePlanAgreementsFunc == nil {
+		err := notStubbed("ListFeePlanAgreements")
+		return nil, err
+	}
+	return c.ListFeePlanAgreementsFunc(ctx, accountID, filters...)
+}
+
+// AllFeePlanAgreements records the call and returns the results of AllFeePlanAgreementsFunc.
+func (c *Client) AllFeePlanAgreements(ctx context.Context, accountID string, filters ...moov.FeePlanAgreementListFilter) iter.Seq2[moov.FeePlanAgreement, error] {
+	c.record("AllFeePlanAgreements", accountID, filters)
+	if c.AllFeePlanAgreementsFunc == nil {
+		err := notStubbed("AllFeePlanAgreements")
+		return failedSeq[moov.FeePlanAgreement](err)
+	}
+	return c.AllFeePlanAgreementsFunc(ctx, accountID, filters...)
+}
+
+// ListFeePlans records the call and returns the results of ListFeePlansFunc.
+func (c *Client) ListFeePlans(ctx context.Context, accountID string, filters ...moov.FeePlanListFilter) ([]moov.FeePlan, error) {
+	c.record("ListFeePlans", accountID, filters)
+	if c.ListFeePlansFunc == nil {
+		err := notStubbed("ListFeePlans")
+		return nil, err
+	}
+	return c.ListFeePlansFunc(ctx, accountID, filters...)
+}
+
+// CreateFeePlanAgreement records the call and returns the results of CreateFeePlanAgreementFunc.
+func (c *Client) CreateFeePlanAgreement(ctx context.Context, accountID string, request moov.FeePlanAgreementRequest) (*moov.FeePlanAgreement, error) {
+	c.record("CreateFeePlanAgreement", accountID, request)
+	if c.CreateFeePlanAgreementFunc == nil {
+		err := notStubbed("CreateFeePlanAgreement")
+		return nil, err
+	}
+	return c.CreateFeePlanAgreementFunc(ctx, accountID, request)
+}
+
+// ListResiduals records the call and returns the results of ListResidualsFunc.
+func (c *Client) ListResiduals(ctx context.Context, accountID string, filters ...moov.ResidualListFilter) ([]moov.Residual, error) {
+	c.record("ListResiduals", accountID, filters)
+	if c.ListResidualsFunc == nil {
+		err := notStubbed("ListResiduals")
+		return nil, err
+	}
+	return c.ListResidualsFunc(ctx, accountID, filters...)
+}
+
+// AllResiduals records the call and returns the results of AllResidualsFunc.
+func (c *Client) AllResiduals(ctx context.Context, accountID string, filters ...moov.ResidualListFilter) iter.Seq2[moov.Residual, error] {
+	c.record("AllResiduals", accountID, filters)
+	if c.AllResidualsFunc == nil {
+		err := notStubbed("AllResiduals")
+		return failedSeq[moov.Residual](err)
+	}
+	return c.AllResidualsFunc(ctx, accountID, filters...)
+}
+
+// GetResidual records the call and returns the results of GetResidualFunc.
+func (c *Client) GetResidual(ctx context.Context, accountID string, residualID string) (*moov.Residual, error) {
+	c.record("GetResidual", accountID, residualID)
+	if c.GetResidualFunc == nil {
+		err := notStubbed("GetResidual")
+		return nil, err
+	}
+	return c.GetResidualFunc(ctx, accountID, residualID)
+}
+
+// ListResidualFees records the call and returns the results of ListResidualFeesFunc.
+func (c *Client) ListResidualFees(ctx context.Context, accountID string, residualID string, filters ...moov.ResidualFeeListFilter) ([]moov.IncurredFee, error) {
+	c.record("ListResidualFees", accountID, residualID, filters)
+	if c.ListResidualFeesFunc == nil {
+		err := notStubbed("ListResidualFees")
+		return nil, err
+	}
+	return c.ListResidualFeesFunc(ctx, accountID, residualID, filters...)
+}
+
+// AllResidualFees records the call and returns the results of AllResidualFeesFunc.
+func (c *Client) AllResidualFees(ctx context.Context, accountID string, residualID string, filters ...moov.ResidualFeeListFilter) iter.Seq2[moov.IncurredFee, error] {
+	c.record("AllResidualFees", accountID, residualID, filters)
+	if c.AllResidualFeesFunc == nil {
+		err := notStubbed("AllResidualFees")
+		return failedSeq[moov.IncurredFee](err)
+	}
+	return c.AllResidualFeesFunc(ctx, accountID, residualID, filters...)
+}
+
+// ListPartnerPricingAgreements records the call and returns the results of ListPartnerPricingAgreementsFunc.
+func (c *Client) ListPartnerPricingAgreements(ctx context.Context, accountID string, filters ...moov.PartnerPricingAgreementListFilter) ([]moov.PartnerPricingAgreement, error) {
+	c.record("ListPartnerPricingAgreements", accountID, filters)
+	if c.ListPartnerPricingAgreementsFunc == nil {
+		err := notStubbed("ListPartnerPricingAgreements")
+		return nil, err
+	}
+	return c.ListPartnerPricingAgreementsFunc(ctx, accountID, filters...)
+}
+
+// AllPartnerPricingAgreements records the call and returns the results of AllPartnerPricingAgreementsFunc.
+func (c *Client) AllPartnerPricingAgreements(ctx context.Context, accountID string, filters ...moov.PartnerPricingAgreementListFilter) iter.Seq2[moov.PartnerPricingAgreement, error] {
+	c.record("AllPartnerPricingAgreements", accountID, filters)
+	if c.AllPartnerPricingAgreementsFunc == nil {
+		err := notStubbed("AllPartnerPricingAgreements")
+		return failedSeq[moov.PartnerPricingAgreement](err)
+	}
+	return c.AllPartnerPricingAgreementsFunc(ctx, accountID, filters...)
+}
+
+// GetFees records the call and returns the results of GetFeesFunc.
+func (c *Client) GetFees(ctx context.Context, accountID string, filters ...moov.FeeGetFilter) ([]moov.IncurredFee, error) {
+	c.record("GetFees", accountID, filters)
+	if c.GetFeesFunc == nil {
+		err := notStubbed("GetFees")
+		return nil, err
+	}
+	return c.GetFeesFunc(ctx, accountID, filters...)
+}
+
+// ListFees records the call and returns the results of ListFeesFunc.
+func (c *Client) ListFees(ctx context.Context, accountID string, request moov.FeeListRequest) ([]moov.IncurredFee, error) {
+	c.record("ListFees", accountID, request)
+	if c.ListFeesFunc == nil {
+		err := notStubbed("ListFees")
+		return nil, err
+	}
+	return c.ListFeesFunc(ctx, accountID, request)
+}
+
+// ListFeeRevenue records the call and returns the results of ListFeeRevenueFunc.
+func (c *Client) ListFeeRevenue(ctx context.Context, accountID string, filters ...moov.FeeRevenueFilter) ([]moov.IncurredFee, error) {
+	c.record("ListFeeRevenue", accountID, filters)
+	if c.ListFeeRevenueFunc == nil {
+		err := notStubbed("ListFeeRevenue")
+		return nil, err
+	}
+	return c.ListFeeRevenueFunc(ctx, accountID, filters...)
+}
+
+// AllFeeRevenue records the call and returns the results of AllFeeRevenueFunc.
+func (c *Client) AllFeeRevenue(ctx context.Context, accountID string, filters ...moov.FeeRevenueFilter) iter.Seq2[moov.IncurredFee, error] {
+	c.record("AllFeeRevenue", accountID, filters)
+	if c.AllFeeRevenueFunc == nil {
+		err := notStubbed("AllFeeRevenue")
+		return failedSeq[moov.IncurredFee](err)
+	}
+	return c.AllFeeRevenueFunc(ctx, accountID, filters...)
+}
+
+// GetStatement records the call and returns the results of GetStatementFunc.
+func (c *Client) GetStatement(ctx context.Context, accountID string, statementID string) (*moov.Statement, error) {
+	c.record("GetStatement", accountID, statementID)
+	if c.GetStatementFunc == nil {
+		err := notStubbed("GetStatement")
+		return nil, err
+	}
+	return c.GetStatementFunc(ctx, accountID, statementID)
+}
+
+// GetStatementPDF records the call and returns the results of GetStatementPDFFunc.
+func (c *Client) GetStatementPDF(ctx context.Context, accountID string, statementID string) ([]byte, error) {
+	c.record("GetStatementPDF", accountID, statementID)
+	if c.GetStatementPDFFunc == nil {
+		err := notStubbed("GetStatementPDF")
+		return nil, err
+	}
+	return c.GetStatementPDFFunc(ctx, accountID, statementID)
+}
+
+// GetStatementPDFTo records the call and returns the results of GetStatementPDFToFunc.
+func (c *Client) GetStatementPDFTo(ctx context.Context, accountID string, statementID string, w io.Writer) error {
+	c.record("GetStatementPDFTo", accountID, statementID, w)
+	if c.GetStatementPDFToFunc == nil {
+		err := notStubbed("GetStatementPDFTo")
+		return err
+	}
+	return c.GetStatementPDFToFunc(ctx, accountID, statementID, w)
+}
+
+// ListStatements records the call and returns the results of ListStatementsFunc.
+func (c *Client) ListStatements(ctx context.Context, accountID string, filters ...moov.ListStatementFilter) ([]moov.Statement, error) {
+	c.record("ListStatements", accountID, filters)
+	if c.ListStatementsFunc == nil {
+		err := notStubbed("ListStatements")
+		return nil, err
+	}
+	return c.ListStatementsFunc(ctx, accountID, filters...)
+}
+
+// AllStatements records the call and returns the results of AllStatementsFunc.
+func (c *Client) AllStatements(ctx context.Context, accountID string, filters ...moov.ListStatementFilter) iter.Seq2[moov.Statement, error] {
+	c.record("AllStatements", accountID, filters)
+	if c.AllStatementsFunc == nil {
+		err := notStubbed("AllStatements")
+		return failedSeq[moov.Statement](err)
+	}
+	return c.AllStatementsFunc(ctx, accountID, filters...)
+}
+
+// RequestCapabilities records the call and returns the results of RequestCapabilitiesFunc.
+func (c *Client) RequestCapabilities(ctx context.Context, accountID string, capabilities []moov.CapabilityName) ([]moov.Capability, error) {
+	c.record("RequestCapabilities", accountID, capabilities)
+	if c.RequestCapabilitiesFunc == nil {
+		err := notStubbed("RequestCapabilities")
+		return nil, err
+	}
+	return c.RequestCapabilitiesFunc(ctx, accountID, capabilities)
+}
+
+// ListCapabilities records the call and returns the results of ListCapabilitiesFunc.
+func (c *Client) ListCapabilities(ctx context.Context, accountID string) ([]moov.Capability, error) {
+	c.record("ListCapabilities", accountID)
+	if c.ListCapabilitiesFunc == nil {
+		err := notStubbed("ListCapabilities")
+		return nil, err
+	}
+	return c.ListCapabilitiesFunc(ctx, accountID)
+}
+
+// GetCapability records the call and returns the results of GetCapabilityFunc.
+func (c *Client) GetCapability(ctx context.Context, accountID string, capability moov.CapabilityName) (*moov.Capability, error) {
+	c.record("GetCapability", accountID, capability)
+	if c.GetCapabilityFunc == nil {
+		err := notStubbed("GetCapability")
+		return nil, err
+	}
+	return c.GetCapabilityFunc(ctx, accountID, capability)
+}
+
+// DisableCapability records the call and returns the results of DisableCapabilityFunc.
+func (c *Client) DisableCapability(ctx context.Context, accountID string, capability moov.CapabilityName) error {
+	c.record("DisableCapability", accountID, capability)
+	if c.DisableCapabilityFunc == nil {
+		err := notStubbed("DisableCapability")
+		return err
+	}
+	return c.DisableCapabilityFunc(ctx, accountID, capability)
+}
+
+// CreateIssuedCard records the call and returns the results of CreateIssuedCardFunc.
+func (c *Client) CreateIssuedCard(ctx context.Context, accountID string, card moov.CreateIssuedCard) (*moov.IssuedCard, error) {
+	c.record("CreateIssuedCard", accountID, card)
+	if c.CreateIssuedCardFunc == nil {
+		err := notStubbed("CreateIssuedCard")
+		return nil, err
+	}
+	return c.CreateIssuedCardFunc(ctx, accountID, card)
+}
+
+// ListIssuedCards records the call and returns the results of ListIssuedCardsFunc.
+func (c *Client) ListIssuedCards(ctx context.Context, accountID string, filters ...moov.ListIssuedCardsFilter) ([]moov.IssuedCard, error) {
+	c.record("ListIssuedCards", accountID, filters)
+	if c.ListIssuedCardsFunc == nil {
+		err := notStubbed("ListIssuedCards")
+		return nil, err
+	}
+	return c.ListIssuedCardsFunc(ctx, accountID, filters...)
+}
+
+// AllIssuedCards records the call and returns the results of AllIssuedCardsFunc.
+func (c *Client) AllIssuedCards(ctx context.Context, accountID string, filters ...moov.ListIssuedCardsFilter) iter.Seq2[moov.IssuedCard, error] {
+	c.record("AllIssuedCards", accountID, filters)
+	if c.AllIssuedCardsFunc == nil {
+		err := notStubbed("AllIssuedCards")
+		return failedSeq[moov.IssuedCard](err)
+	}
+	return c.AllIssuedCardsFunc(ctx, accountID, filters...)
+}
+
+// GetIssuedCard records the call and returns the results of GetIssuedCardFunc.
+func (c *Client) GetIssuedCard(ctx context.Context, accountID string, cardID string) (*moov.IssuedCard, error) {
+	c.record("GetIssuedCard", accountID, cardID)
+	if c.GetIssuedCardFunc == nil {
+		err := notStubbed("GetIssuedCard")
+		return nil, err
+	}
+	return c.GetIssuedCardFunc(ctx, accountID, cardID)
+}
+
+// UpdateIssuedCard records the call and returns the results of UpdateIssuedCardFunc.
+func (c *Client) UpdateIssuedCard(ctx context.Context, accountID string, cardID string, update moov.UpdateIssuedCard) (*moov.IssuedCard, error) {
+	c.record("UpdateIssuedCard", accountID, cardID, update)
+	if c.UpdateIssuedCardFunc == nil {
+		err := notStubbed("UpdateIssuedCard")
+		return nil, err
+	}
+	return c.UpdateIssuedCardFunc(ctx, accountID, cardID, update)
+}
+
+// ListIssuedCardAuthorizations records the call and returns the results of ListIssuedCardAuthorizationsFunc.
+func (c *Client) ListIssuedCardAuthorizations(ctx context.Context, accountID string, filters ...moov.ListIssuedCardAuthorizationsFilter) ([]moov.IssuedCardAuthorization, error) {
+	c.record("ListIssuedCardAuthorizations", accountID, filters)
+	if c.ListIssuedCardAuthorizationsFunc == nil {
+		err := notStubbed("ListIssuedCardAuthorizations")
+		return nil, err
+	}
+	return c.ListIssuedCardAuthorizationsFunc(ctx, accountID, filters...)
+}
+
+// AllIssuedCardAuthorizations records the call and returns the results of AllIssuedCardAuthorizationsFunc.
+func (c *Client) AllIssuedCardAuthorizations(ctx context.Context, accountID string, filters ...moov.ListIssuedCardAuthorizationsFilter) iter.Seq2[moov.IssuedCardAuthorization, error] {
+	c.record("AllIssuedCardAuthorizations", accountID, filters)
+	if c.AllIssuedCardAuthorizationsFunc == nil {
+		err := notStubbed("AllIssuedCardAuthorizations")
+		return failedSeq[moov.IssuedCardAuthorization](err)
+	}
+	return c.AllIssuedCardAuthorizationsFunc(ctx, accountID, filters...)
+}
+
+// GetIssuedCardAuthorization records the call and returns the results of GetIssuedCardAuthorizationFunc.
+func (c *Client) GetIssuedCardAuthorization(ctx context.Context, accountID string, authorizationID string) (*moov.IssuedCardAuthorization, error) {
+	c.record("GetIssuedCardAuthorization", accountID, authorizationID)
+	if c.GetIssuedCardAuthorizationFunc == nil {
+		err := notStubbed("GetIssuedCardAuthorization")
+		return nil, err
+	}
+	return c.GetIssuedCardAuthorizationFunc(ctx, accountID, authorizationID)
+}
+
+// ListIssuedCardAuthorizationEvents records the call and returns the results of ListIssuedCardAuthorizationEventsFunc.
+func (c *Client) ListIssuedCardAuthorizationEvents(ctx context.Context, accountID string, authorizationID string, filters ...moov.ListIssuedCardAuthorizationEventsFilter) ([]moov.IssuedCardAuthorizationEvent, error) {
+	c.record("ListIssuedCardAuthorizationEvents", accountID, authorizationID, filters)
+	if c.ListIssuedCardAuthorizationEventsFunc == nil {
+		err := notStubbed("ListIssuedCardAuthorizationEvents")
+		return nil, err
+	}
+	return c.ListIssuedCardAuthorizationEventsFunc(ctx, accountID, authorizationID, filters...)
+}
+
+// AllIssuedCardAuthorizationEvents records the call and returns the results of AllIssuedCardAuthorizationEventsFunc.
+func (c *Client) AllIssuedCardAuthorizationEvents(ctx context.Context, accountID string, authorizationID string, filters ...moov.ListIssuedCardAuthorizationEventsFilter) iter.Seq2[moov.IssuedCardAuthorizationEvent, error] {
+	c.record("AllIssuedCardAuthorizationEvents", accountID, authorizationID, filters)
+	if c.AllIssuedCardAuthorizationEventsFunc == nil {
+		err := notStubbed("AllIssuedCardAuthorizationEvents")
+		return failedSeq[moov.IssuedCardAuthorizationEvent](err)
+	}
+	return c.AllIssuedCardAuthorizationEventsFunc(ctx, accountID, authorizationID, filters...)
+}
+
+// ListIssuedCardTransactions records the call and returns the results of ListIssuedCardTransactionsFunc.
+func (c *Client) ListIssuedCardTransactions(ctx context.Context, accountID string, filters ...moov.ListIssuedCardTransactionsFilter) ([]moov.IssuedCardTransaction, error) {
+	c.record("ListIssuedCardTransactions", accountID, filters)
+	if c.ListIssuedCardTransactionsFunc == nil {
+		err := notStubbed("ListIssuedCardTransactions")
+		return nil, err
+	}
+	return c.ListIssuedCardTransactionsFunc(ctx, accountID, filters...)
+}
+
+// AllIssuedCardTransactions records the call and returns the results of AllIssuedCardTransactionsFunc.
+func (c *Client) AllIssuedCardTransactions(ctx context.Context, accountID string, filters ...moov.ListIssuedCardTransactionsFilter) iter.Seq2[moov.IssuedCardTransaction, error] {
+	c.record("AllIssuedCardTransactions", accountID, filters)
+	if c.AllIssuedCardTransactionsFunc == nil {
+		err := notStubbed("AllIssuedCardTransactions")
+		return failedSeq[moov.IssuedCardTransaction](err)
+	}
+	return c.AllIssuedCardTransactionsFunc(ctx, accountID, filters...)
+}
+
+// GetIssuedCardTransaction records the call and returns the results of GetIssuedCardTransactionFunc.
+func (c *Client) GetIssuedCardTransaction(ctx context.Context, accountID string, cardTransactionID string) (*moov.IssuedCardTransaction, error) {
+	c.record("GetIssuedCardTransaction", accountID, cardTransactionID)
+	if c.GetIssuedCardTransactionFunc == nil {
+		err := notStubbed("GetIssuedCardTransaction")
+		return nil, err
+	}
+	return c.GetIssuedCardTransactionFunc(ctx, accountID, cardTransactionID)
+}
+
+// GetCardMetadata records the call and returns the results of GetCardMetadataFunc.
+func (c *Client) GetCardMetadata(ctx context.Context, request moov.CardMetadataRequest) (*moov.CardMetadata, error) {
+	c.record("GetCardMetadata", request)
+	if c.GetCardMetadataFunc == nil {
+		err := notStubbed("GetCardMetadata")
+		return nil, err
+	}
+	return c.GetCardMetadataFunc(ctx, request)
+}
+
+// CreateCard records the call and returns the results of CreateCardFunc.
+func (c *Client) CreateCard(ctx context.Context, accountID string, card moov.CreateCard) (*moov.Card, error) {
+	c.record("CreateCard", accountID, card)
+	if c.CreateCardFunc == nil {
+		err := notStubbed("CreateCard")
+		return nil, err
+	}
+	return c.CreateCardFunc(ctx, accountID, card)
+}
+
+// ListCards records the call and returns the results of ListCardsFunc.
+func (c *Client) ListCards(ctx context.Context, accountID string) ([]moov.Card, error) {
+	c.record("ListCards", accountID)
+	if c.ListCardsFunc == nil {
+		err := notStubbed("ListCards")
+		return nil, err
+	}
+	return c.ListCardsFunc(ctx, accountID)
+}
+
+// GetCard records the call and returns the results of GetCardFunc.
+func (c *Client) GetCard(ctx context.Context, accountID string, cardID string) (*moov.Card, error) {
+	c.record("GetCard", accountID, cardID)
+	if c.GetCardFunc == nil {
+		err := notStubbed("GetCard")
+		return nil, err
+	}
+	return c.GetCardFunc(ctx, accountID, cardID)
+}
+
+// UpdateCard records the call and returns the results of UpdateCardFunc.
+func (c *Client) UpdateCard(ctx context.Context, accountID string, cardID string, opt1 moov.CardUpdateFilter, opts ...moov.CardUpdateFilter) (*moov.Card, error) {
+	c.record("UpdateCard", accountID, cardID, opt1, opts)
+	if c.UpdateCardFunc == nil {
+		err := notStubbed("UpdateCard")
+		return nil, err
+	}
+	return c.UpdateCardFunc(ctx, accountID, cardID, opt1, opts...)
+}
+
+// DisableCard records the call and returns the results of DisableCardFunc.
+func (c *Client) DisableCard(ctx context.Context, accountID string, cardID string) error {
+	c.record("DisableCard", accountID, cardID)
+	if c.DisableCardFunc == nil {
+		err := notStubbed("DisableCard")
+		return err
+	}
+	return c.DisableCardFunc(ctx, accountID, cardID)
+}
+
+// GenerateEndToEndPublicKey records the call and returns the results of GenerateEndToEndPublicKeyFunc.
+func (c *Client) GenerateEndToEndPublicKey(ctx context.Context) (*jose.JSONWebKey, error) {
+	c.record("GenerateEndToEndPublicKey")
+	if c.GenerateEndToEndPublicKeyFunc == nil {
+		err := notStubbed("GenerateEndToEndPublicKey")
+		return nil, err
+	}
+	return c.GenerateEndToEndPublicKeyFunc(ctx)
+}
+
+// TestEndToEndToken records the call and returns the results of TestEndToEndTokenFunc.
+func (c *Client) TestEndToEndToken(ctx context.Context, token string) error {
+	c.record("TestEndToEndToken", token)
+	if c.TestEndToEndTokenFunc == nil {
+		err := notStubbed("TestEndToEndToken")
+		return err
+	}
+	return c.TestEndToEndTokenFunc(ctx, token)
+}
+
+// ListDisputes records the call and returns the results of ListDisputesFunc.
+func (c *Client) ListDisputes(ctx context.Context, accountID string, filters ...moov.DisputeListFilter) ([]moov.Dispute, error) {
+	c.record("ListDisputes", accountID, filters)
+	if c.ListDisputesFunc == nil {
+		err := notStubbed("ListDisputes")
+		return nil, err
+	}
+	return c.ListDisputesFunc(ctx, accountID, filters...)
+}
+
+// AllDisputes records the call and returns the results of AllDisputesFunc.
+func (c *Client) AllDisputes(ctx context.Context, accountID string, filters ...moov.DisputeListFilter) iter.Seq2[moov.Dispute, error] {
+	c.record("AllDisputes", accountID, filters)
+	if c.AllDisputesFunc == nil {
+		err := notStubbed("AllDisputes")
+		return failedSeq[moov.Dispute](err)
+	}
+	return c.AllDisputesFunc(ctx, accountID, filters...)
+}
+
+// GetDispute records the call and returns the results of GetDisputeFunc.
+func (c *Client) GetDispute(ctx context.Context, accountID string, disputeID string) (*moov.Dispute, error) {
+	c.record("GetDispute", accountID, disputeID)
+	if c.GetDisputeFunc == nil {
+		err := notStubbed("GetDispute")
+		return nil, err
+	}
+	return c.GetDisputeFunc(ctx, accountID, disputeID)
+}
+
+// AcceptDispute records the call and returns the results of AcceptDisputeFunc.
+func (c *Client) AcceptDispute(ctx context.Context, accountID string, disputeID string) (*moov.Dispute, error) {
+	c.record("AcceptDispute", accountID, disputeID)
+	if c.AcceptDisputeFunc == nil {
+		err := notStubbed("AcceptDispute")
+		return nil, err
+	}
+	return c.AcceptDisputeFunc(ctx, accountID, disputeID)
+}
+
+// UploadDisputeEvidence records the call and returns the results of UploadDisputeEvidenceFunc.
+func (c *Client) UploadDisputeEvidence(ctx context.Context, accountID string, disputeID string, evidenceText moov.DisputesEvidenceText) (*moov.DisputeEvidence, error) {
+	c.record("UploadDisputeEvidence", accountID, disputeID, evidenceText)
+	if c.UploadDisputeEvidenceFunc == nil {
+		err := notStubbed("UploadDisputeEvidence")
+		return nil, err
+	}
+	return c.UploadDisputeEvidenceFunc(ctx, accountID, disputeID, evidenceText)
+}
+
+// DeleteDisputeEvidence records the call and returns the results of DeleteDisputeEvidenceFunc.
+func (c *Client) DeleteDisputeEvidence(ctx context.Context, accountID string, disputeID string, evidenceID string) error {
+	c.record("DeleteDisputeEvidence", accountID, disputeID, evidenceID)
+	if c.DeleteDisputeEvidenceFunc == nil {
+		err := notStubbed("DeleteDisputeEvidence")
+		return err
+	}
+	return c.DeleteDisputeEvidenceFunc(ctx, accountID, disputeID, evidenceID)
+}
+
+// UploadEvidenceFile records the call and returns the results of UploadEvidenceFileFunc.
+func (c *Client) UploadEvidenceFile(ctx context.Context, accountID string, disputeID string, evidenceType moov.EvidenceType, filename string, file io.Reader, mimeType string) (*moov.DisputeEvidenceUpload, error) {
+	c.record("UploadEvidenceFile", accountID, disputeID, evidenceType, filename, file, mimeType)
+	if c.UploadEvidenceFileFunc == nil {
+		err := notStubbed("UploadEvidenceFile")
+		return nil, err
+	}
+	return c.UploadEvidenceFileFunc(ctx, accountID, disputeID, evidenceType, filename, file, mimeType)
+}
+
+// ListDisputeEvidence records the call and returns the results of ListDisputeEvidenceFunc.
+func (c *Client) ListDisputeEvidence(ctx context.Context, accountID string, disputeID string) ([]moov.DisputeEvidence, error) {
+	c.record("ListDisputeEvidence", accountID, disputeID)
+	if c.ListDisputeEvidenceFunc == nil {
+		err := notStubbed("ListDisputeEvidence")
+		return nil, err
+	}
+	return c.ListDisputeEvidenceFunc(ctx, accountID, disputeID)
+}
+
+// SubmitDisputeEvidence records the call and returns the results of SubmitDisputeEvidenceFunc.
+func (c *Client) SubmitDisputeEvidence(ctx context.Context, accountID string, disputeID string) (*moov.Dispute, error) {
+	c.record("SubmitDisputeEvidence", accountID, disputeID)
+	if c.SubmitDisputeEvidenceFunc == nil {
+		err := notStubbed("SubmitDisputeEvidence")
+		return nil, err
+	}
+	return c.SubmitDisputeEvidenceFunc(ctx, accountID, disputeID)
+}
+
+// UpdateDisputeEvidence records the call and returns the results of UpdateDisputeEvidenceFunc.
+func (c *Client) UpdateDisputeEvidence(ctx context.Context, accountID string, disputeID string, evidenceID string, evidenceUpdate moov.DisputesEvidenceUpdate) (*moov.DisputeEvidence, error) {
+	c.record("UpdateDisputeEvidence", accountID, disputeID, evidenceID, evidenceUpdate)
+	if c.UpdateDisputeEvidenceFunc == nil {
+		err := notStubbed("UpdateDisputeEvidence")
+		return nil, err
+	}
+	return c.UpdateDisputeEvidenceFunc(ctx, accountID, disputeID, evidenceID, evidenceUpdate)
+}
+
+// GetDisputeEvidence records the call and returns the results of GetDisputeEvidenceFunc.
+func (c *Client) GetDisputeEvidence(ctx context.Context, accountID string, disputeID string, evidenceID string) (*moov.DisputeEvidence, error) {
+	c.record("GetDisputeEvidence", accountID, disputeID, evidenceID)
+	if c.GetDisputeEvidenceFunc == nil {
+		err := notStubbed("GetDisputeEvidence")
+		return nil, err
+	}
+	return c.GetDisputeEvidenceFunc(ctx, accountID, disputeID, evidenceID)
+}
+
+// UploadFile records the call and returns the results of UploadFileFunc.
+func (c *Client) UploadFile(ctx context.Context, accountID string, upload moov.UploadFile) (*moov.File, error) {
+	c.record("UploadFile", accountID, upload)
+	if c.UploadFileFunc == nil {
+		err := notStubbed("UploadFile")
+		return nil, err
+	}
+	return c.UploadFileFunc(ctx, accountID, upload)
+}
+
+// ListFiles records the call and returns the results of ListFilesFunc.
+func (c *Client) ListFiles(ctx context.Context, accountID string) ([]moov.File, error) {
+	c.record("ListFiles", accountID)
+	if c.ListFilesFunc == nil {
+		err := notStubbed("ListFiles")
+		return nil, err
+	}
+	return c.ListFilesFunc(ctx, accountID)
+}
+
+// GetFile records the call and returns the results of GetFileFunc.
+func (c *Client) GetFile(ctx context.Context, accountID string, fileID string) (*moov.File, error) {
+	c.record("GetFile", accountID, fileID)
+	if c.GetFileFunc == nil {
+		err := notStubbed("GetFile")
+		return nil, err
+	}
+	return c.GetFileFunc(ctx, accountID, fileID)
+}
+
+// UploadImage records the call and returns the results of UploadImageFunc.
+func (c *Client) UploadImage(ctx context.Context, accountID string, file io.Reader, metadata *moov.ImageMetadataRequest) (*moov.ImageMetadata, error) {
+	c.record("UploadImage", accountID, file, metadata)
+	if c.UploadImageFunc == nil {
+		err := notStubbed("UploadImage")
+		return nil, err
+	}
+	return c.UploadImageFunc(ctx, accountID, file, metadata)
+}
+
+// ListImageMetadata records the call and returns the results of ListImageMetadataFunc.
+func (c *Client) ListImageMetadata(ctx context.Context, accountID string, filters ...moov.ImageListFilter) ([]moov.ImageMetadata, error) {
+	c.record("ListImageMetadata", accountID, filters)
+	if c.ListImageMetadataFunc == nil {
+		err := notStubbed("ListImageMetadata")
+		return nil, err
+	}
+	return c.ListImageMetadataFunc(ctx, accountID, filters...)
+}
+
+// AllImageMetadata records the call and returns the results of AllImageMetadataFunc.
+func (c *Client) AllImageMetadata(ctx context.Context, accountID string, filters ...moov.ImageListFilter) iter.Seq2[moov.ImageMetadata, error] {
+	c.record("AllImageMetadata", accountID, filters)
+	if c.AllImageMetadataFunc == nil {
+		err := notStubbed("AllImageMetadata")
+		return failedSeq[moov.ImageMetadata](err)
+	}
+	return c.AllImageMetadataFunc(ctx, accountID, filters...)
+}
+
+// GetImageMetadata records the call and returns the results of GetImageMetadataFunc.
+func (c *Client) GetImageMetadata(ctx context.Context, accountID string, imageID string) (*moov.ImageMetadata, error) {
+	c.record("GetImageMetadata", accountID, imageID)
+	if c.GetImageMetadataFunc == nil {
+		err := notStubbed("GetImageMetadata")
+		return nil, err
+	}
+	return c.GetImageMetadataFunc(ctx, accountID, imageID)
+}
+
+// UpdateImage records the call and returns the results of UpdateImageFunc.
+func (c *Client) UpdateImage(ctx context.Context, accountID string, imageID string, file io.Reader, metadata *moov.ImageMetadataRequest) (*moov.ImageMetadata, error) {
+	c.record("UpdateImage", accountID, imageID, file, metadata)
+	if c.UpdateImageFunc == nil {
+		err := notStubbed("UpdateImage")
+		return nil, err
+	}
+	return c.UpdateImageFunc(ctx, accountID, imageID, file, metadata)
+}
+
+// UpdateImageMetadata records the call and returns the results of UpdateImageMetadataFunc.
+func (c *Client) UpdateImageMetadata(ctx context.Context, accountID string, imageID string, metadata moov.ImageMetadataRequest) (*moov.ImageMetadata, error) {
+	c.record("UpdateImageMetadata", accountID, imageID, metadata)
+	if c.UpdateImageMetadataFunc == nil {
+		err := notStubbed("UpdateImageMetadata")
+		return nil, err
+	}
+	return c.UpdateImageMetadataFunc(ctx, accountID, imageID, metadata)
+}
+
+// DeleteImage records the call and returns the results of DeleteImageFunc.
+func (c *Client) DeleteImage(ctx context.Context, accountID string, imageID string) error {
+	c.record("DeleteImage", accountID, imageID)
+	if c.DeleteImageFunc == nil {
+		err := notStubbed("DeleteImage")
+		return err
+	}
+	return c.DeleteImageFunc(ctx, accountID, imageID)
+}
+
+// CreateInvoice records the call and returns the results of CreateInvoiceFunc.
+func (c *Client) CreateInvoice(ctx context.Context, accountID string, invoice moov.CreateInvoice) (*moov.Invoice, error) {
+	c.record("CreateInvoice", accountID, invoice)
+	if c.CreateInvoiceFunc == nil {
+		err := notStubbed("CreateInvoice")
+		return nil, err
+	}
+	return c.CreateInvoiceFunc(ctx, accountID, invoice)
+}
+
+// GetInvoice records the call and returns the results of GetInvoiceFunc.
+func (c *Client) GetInvoice(ctx context.Context, accountID string, invoiceID string) (*moov.Invoice, error) {
+	c.record("GetInvoice", accountID, invoiceID)
+	if c.GetInvoiceFunc == nil {
+		err := notStubbed("GetInvoice")
+		return nil, err
+	}
+	return c.GetInvoiceFunc(ctx, accountID, invoiceID)
+}
+
+// UpdateInvoice records the call and returns the results of UpdateInvoiceFunc.
+func (c *Client) UpdateInvoice(ctx context.Context, accountID string, invoiceID string, invoice moov.UpdateInvoice) (*moov.Invoice, error) {
+	c.record("UpdateInvoice", accountID, invoiceID, invoice)
+	if c.UpdateInvoiceFunc == nil {
+		err := notStubbed("UpdateInvoice")
+		return nil, err
+	}
+	return c.UpdateInvoiceFunc(ctx, accountID, invoiceID, invoice)
+}
+
+// CreateInvoicePayment records the call and returns the results of CreateInvoicePaymentFunc.
+func (c *Client) CreateInvoicePayment(ctx context.Context, accountID string, invoiceID string, payment moov.CreateInvoicePayment) (*moov.InvoicePayment, error) {
+	c.record("CreateInvoicePayment", accountID, invoiceID, payment)
+	if c.CreateInvoicePaymentFunc == nil {
+		err := notStubbed("CreateInvoicePayment")
+		return nil, err
+	}
+	return c.CreateInvoicePaymentFunc(ctx, accountID, invoiceID, payment)
+}
+
+// ListInvoices records the call and returns the results of ListInvoicesFunc.
+func (c *Client) ListInvoices(ctx context.Context, accountID string, filters ...moov.ListInvoiceFilter) ([]moov.Invoice, error) {
+	c.record("ListInvoices", accountID, filters)
+	if c.ListInvoicesFunc == nil {
+		err := notStubbed("ListInvoices")
+		return nil, err
+	}
+	return c.ListInvoicesFunc(ctx, accountID, filters...)
+}
+
+// AllInvoices records the call and returns the results of AllInvoicesFunc.
+func (c *Client) AllInvoices(ctx context.Context, accountID string, filters ...moov.ListInvoiceFilter) iter.Seq2[moov.Invoice, error] {
+	c.record("AllInvoices", accountID, filters)
+	if c.AllInvoicesFunc == nil {
+		err := notStubbed("AllInvoices")
+		return failedSeq[moov.Invoice](err)
+	}
+	return c.AllInvoicesFunc(ctx, accountID, filters...)
+}
+
+// DeleteInvoice records the call and returns the results of DeleteInvoiceFunc.
+func (c *Client) DeleteInvoice(ctx context.Context, accountID string, invoiceID string) error {
+	c.record("DeleteInvoice", accountID, invoiceID)
+	if c.DeleteInvoiceFunc == nil {
+		err := notStubbed("DeleteInvoice")
+		return err
+	}
+	return c.DeleteInvoiceFunc(ctx, accountID, invoiceID)
+}
+
+// ListInvoicePayments records the call and returns the results of ListInvoicePaymentsFunc.
+func (c *Client) ListInvoicePayments(ctx context.Context, accountID string, invoiceID string) ([]moov.InvoicePayment, error) {
+	c.record("ListInvoicePayments", accountID, invoiceID)
+	if c.ListInvoicePaymentsFunc == nil {
+		err := notStubbed("ListInvoicePayments")
+		return nil, err
+	}
+	return c.ListInvoicePaymentsFunc(ctx, accountID, invoiceID)
+}
+
+// ListPaymentMethods records the call and returns the results of ListPaymentMethodsFunc.
+func (c *Client) ListPaymentMethods(ctx context.Context, accountID string, opts ...moov.PaymentMethodListFilter) ([]moov.PaymentMethod, error) {
+	c.record("ListPaymentMethods", accountID, opts)
+	if c.ListPaymentMethodsFunc == nil {
+		err := notStubbed("ListPaymentMethods")
+		return nil, err
+	}
+	return c.ListPaymentMethodsFunc(ctx, accountID, opts...)
+}
+
+// GetPaymentMethod records the call and returns the results of GetPaymentMethodFunc.
+func (c *Client) GetPaymentMethod(ctx context.Context, accountID string, paymentMethodID string) (*moov.PaymentMethod, error) {
+	c.record("GetPaymentMethod", accountID, paymentMethodID)
+	if c.GetPaymentMethodFunc == nil {
+		err := notStubbed("GetPaymentMethod")
+		return nil, err
+	}
+	return c.GetPaymentMethodFunc(ctx, accountID, paymentMethodID)
+}
+
+// CreateProduct records the call and returns the results of CreateProductFunc.
+func (c *Client) CreateProduct(ctx context.Context, accountID string, product moov.ProductRequest) (*moov.Product, error) {
+	c.record("CreateProduct", accountID, product)
+	if c.CreateProductFunc == nil {
+		err := notStubbed("CreateProduct")
+		return nil, err
+	}
+	return c.CreateProductFunc(ctx, accountID, product)
+}
+
+// ListProducts records the call and returns the results of ListProductsFunc.
+func (c *Client) ListProducts(ctx context.Context, accountID string, filters ...moov.ProductListFilter) ([]moov.Product, error) {
+	c.record("ListProducts", accountID, filters)
+	if c.ListProductsFunc == nil {
+		err := notStubbed("ListProducts")
+		return nil, err
+	}
+	return c.ListProductsFunc(ctx, accountID, filters...)
+}
+
+// AllProducts records the call and returns the results of AllProductsFunc.
+func (c *Client) AllProducts(ctx context.Context, accountID string, filters ...moov.ProductListFilter) iter.Seq2[moov.Product, error] {
+	c.record("AllProducts", accountID, filters)
+	if c.AllProductsFunc == nil {
+		err := notStubbed("AllProducts")
+		return failedSeq[moov.Product](err)
+	}
+	return c.AllProductsFunc(ctx, accountID, filters...)
+}
+
+// GetProduct records the call and returns the results of GetProductFunc.
+func (c *Client) GetProduct(ctx context.Context, accountID string, productID string) (*moov.Product, error) {
+	c.record("GetProduct", accountID, productID)
+	if c.GetProductFunc == nil {
+		err := notStubbed("GetProduct")
+		return nil, err
+	}
+	return c.GetProductFunc(ctx, accountID, productID)
+}
+
+// UpdateProduct records the call and returns the results of UpdateProductFunc.
+func (c *Client) UpdateProduct(ctx context.Context, accountID string, productID string, product moov.ProductRequest) (*moov.Product, error) {
+	c.record("UpdateProduct", accountID, productID, product)
+	if c.UpdateProductFunc == nil {
+		err := notStubbed("UpdateProduct")
+		return nil, err
+	}
+	return c.UpdateProductFunc(ctx, accountID, productID, product)
+}
+
+// DisableProduct records the call and returns the results of DisableProductFunc.
+func (c *Client) DisableProduct(ctx context.Context, accountID string, productID string) error {
+	c.record("DisableProduct", accountID, productID)
+	if c.DisableProductFunc == nil {
+		err := notStubbed("DisableProduct")
+		return err
+	}
+	return c.DisableProductFunc(ctx, accountID, productID)
+}
+
+// CreateReceipt records the call and returns the results of CreateReceiptFunc.
+func (c *Client) CreateReceipt(ctx context.Context, receipts ...moov.CreateReceipt) ([]moov.Receipt, error) {
+	c.record("CreateReceipt", receipts)
+	if c.CreateReceiptFunc == nil {
+		err := notStubbed("CreateReceipt")
+		return nil, err
+	}
+	return c.CreateReceiptFunc(ctx, receipts...)
+}
+
+// CreateReceipts records the call and returns the results of CreateReceiptsFunc.
+func (c *Client) CreateReceipts(ctx context.Context, receipts []moov.CreateReceipt, options ...moov.CreateReceiptArgs) ([]moov.Receipt, error) {
+	c.record("CreateReceipts", receipts, options)
+	if c.CreateReceiptsFunc == nil {
+		err := notStubbed("CreateReceipts")
+		return nil, err
+	}
+	return c.CreateReceiptsFunc(ctx, receipts, options...)
+}
+
+// ListReceipts records the call and returns the results of ListReceiptsFunc.
+func (c *Client) ListReceipts(ctx context.Context, filters ...moov.ListReceiptsFilter) ([]moov.Receipt, error) {
+	c.record("ListReceipts", filters)
+	if c.ListReceiptsFunc == nil {
+		err := notStubbed("ListReceipts")
+		return nil, err
+	}
+	return c.ListReceiptsFunc(ctx, filters...)
+}
+
+// DeleteReceipt records the call and returns the results of DeleteReceiptFunc.
+func (c *Client) DeleteReceipt(ctx context.Context, receiptID string) error {
+	c.record("DeleteReceipt", receiptID)
+	if c.DeleteReceiptFunc == nil {
+		err := notStubbed("DeleteReceipt")
+		return err
+	}
+	return c.DeleteReceiptFunc(ctx, receiptID)
+}
+
+// CreateRepresentative records the call and returns the results of CreateRepresentativeFunc.
+func (c *Client) CreateRepresentative(ctx context.Context, accountID string, representative moov.CreateRepresentative) (*moov.Representative, error) {
+	c.record("CreateRepresentative", accountID, representative)
+	if c.CreateRepresentativeFunc == nil {
+		err := notStubbed("CreateRepresentative")
+		return nil, err
+	}
+	return c.CreateRepresentativeFunc(ctx, accountID, representative)
+}
+
+// ListRepresentatives records the call and returns the results of ListRepresentativesFunc.
+func (c *Client) ListRepresentatives(ctx context.Context, accountID string) ([]moov.Representative, error) {
+	c.record("ListRepresentatives", accountID)
+	if c.ListRepresentativesFunc == nil {
+		err := notStubbed("ListRepresentatives")
+		return nil, err
+	}
+	return c.ListRepresentativesFunc(ctx, accountID)
+}
+
+// GetRepresentative records the call and returns the results of GetRepresentativeFunc.
+func (c *Client) GetRepresentative(ctx context.Context, accountID string, representativeID string) (*moov.Representative, error) {
+	c.record("GetRepresentative", accountID, representativeID)
+	if c.GetRepresentativeFunc == nil {
+		err := notStubbed("GetRepresentative")
+		return nil, err
+	}
+	return c.GetRepresentativeFunc(ctx, accountID, representativeID)
+}
+
+// UpdateRepresentative records the call and returns the results of UpdateRepresentativeFunc.
+func (c *Client) UpdateRepresentative(ctx context.Context, representativeAccountID string, representativeID string, representative moov.UpdateRepresentative) (*moov.Representative, error) {
+	c.record("UpdateRepresentative", representativeAccountID, representativeID, representative)
+	if c.UpdateRepresentativeFunc == nil {
+		err := notStubbed("UpdateRepresentative")
+		return nil, err
+	}
+	return c.UpdateRepresentativeFunc(ctx, representativeAccountID, representativeID, representative)
+}
+
+// DeleteRepresentative records the call and returns the results of DeleteRepresentativeFunc.
+func (c *Client) DeleteRepresentative(ctx context.Context, accountID string, representativeAccountID string) error {
+	c.record("DeleteRepresentative", accountID, representativeAccountID)
+	if c.DeleteRepresentativeFunc == nil {
+		err := notStubbed("DeleteRepresentative")
+		return err
+	}
+	return c.DeleteRepresentativeFunc(ctx, accountID, representativeAccountID)
+}
+
+// CreateResolutionLink records the call and returns the results of CreateResolutionLinkFunc.
+func (c *Client) CreateResolutionLink(ctx context.Context, accountID string, resolutionLink moov.CreateResolutionLinkRequest) (*moov.ResolutionLinkResponse, error) {
+	c.record("CreateResolutionLink", accountID, resolutionLink)
+	if c.CreateResolutionLinkFunc == nil {
+		err := notStubbed("CreateResolutionLink")
+		return nil, err
+	}
+	return c.CreateResolutionLinkFunc(ctx, accountID, resolutionLink)
+}
+
+// GetResolutionLink records the call and returns the results of GetResolutionLinkFunc.
+func (c *Client) GetResolutionLink(ctx context.Context, accountID string, resolutionLinkCode string) (*moov.ResolutionLinkResponse, error) {
+	c.record("GetResolutionLink", accountID, resolutionLinkCode)
+	if c.GetResolutionLinkFunc == nil {
+		err := notStubbed("GetResolutionLink")
+		return nil, err
+	}
+	return c.GetResolutionLinkFunc(ctx, accountID, resolutionLinkCode)
+}
+
+// ListResolutionLinks records the call and returns the results of ListResolutionLinksFunc.
+func (c *Client) ListResolutionLinks(ctx context.Context, accountID string) ([]moov.ResolutionLinkResponse, error) {
+	c.record("ListResolutionLinks", accountID)
+	if c.ListResolutionLinksFunc == nil {
+		err := notStubbed("ListResolutionLinks")
+		return nil, err
+	}
+	return c.ListResolutionLinksFunc(ctx, accountID)
+}
+
+// DeleteResolutionLink records the call and returns the results of DeleteResolutionLinkFunc.
+func (c *Client) DeleteResolutionLink(ctx context.Context, accountID string, resolutionLinkCode string) error {
+	c.record("DeleteResolutionLink", accountID, resolutionLinkCode)
+	if c.DeleteResolutionLinkFunc == nil {
+		err := notStubbed("DeleteResolutionLink")
+		return err
+	}
+	return c.DeleteResolutionLinkFunc(ctx, accountID, resolutionLinkCode)
+}
+
+// CreateSchedule records the call and returns the results of CreateScheduleFunc.
+func (c *Client) CreateSchedule(ctx context.Context, accountID string, schedule moov.CreateSchedule) (*moov.Schedule, error) {
+	c.record("CreateSchedule", accountID, schedule)
+	if c.CreateScheduleFunc == nil {
+		err := notStubbed("CreateSchedule")
+		return nil, err
+	}
+	return c.CreateScheduleFunc(ctx, accountID, schedule)
+}
+
+// GetSchedule records the call and returns the results of GetScheduleFunc.
+func (c *Client) GetSchedule(ctx context.Context, accountID string, scheduleID string) (*moov.Schedule, error) {
+	c.record("GetSchedule", accountID, scheduleID)
+	if c.GetScheduleFunc == nil {
+		err := notStubbed("GetSchedule")
+		return nil, err
+	}
+	return c.GetScheduleFunc(ctx, accountID, scheduleID)
+}
+
+// UpdateSchedule records the call and returns the results of UpdateScheduleFunc.
+func (c *Client) UpdateSchedule(ctx context.Context, accountID string, scheduleID string, schedule moov.UpdateSchedule) (*moov.Schedule, error) {
+	c.record("UpdateSchedule", accountID, scheduleID, schedule)
+	if c.UpdateScheduleFunc == nil {
+		err := notStubbed("UpdateSchedule")
+		return nil, err
+	}
+	return c.UpdateScheduleFunc(ctx, accountID, scheduleID, schedule)
+}
+
+// CancelSchedule records the call and returns the results of CancelScheduleFunc.
+func (c *Client) CancelSchedule(ctx context.Context, accountID string, scheduleID string) error {
+	c.record("CancelSchedule", accountID, scheduleID)
+	if c.CancelScheduleFunc == nil {
+		err := notStubbed("CancelSchedule")
+		return err
+	}
+	return c.CancelScheduleFunc(ctx, accountID, scheduleID)
+}
+
+// CreateTicket records the call and returns the results of CreateTicketFunc.
+func (c *Client) CreateTicket(ctx context.Context, accountID string, create moov.CreateTicket) (*moov.Ticket, error) {
+	c.record("CreateTicket", accountID, create)
+	if c.CreateTicketFunc == nil {
+		err := notStubbed("CreateTicket")
+		return nil, err
+	}
+	return c.CreateTicketFunc(ctx, accountID, create)
+}
+
+// ListTickets records the call and returns the results of ListTicketsFunc.
+func (c *Client) ListTickets(ctx context.Context, accountID string, filters ...moov.ListTicketFilter) (*moov.ListTicket, error) {
+	c.record("ListTickets", accountID, filters)
+	if c.ListTicketsFunc == nil {
+		err := notStubbed("ListTickets")
+		return nil, err
+	}
+	return c.ListTicketsFunc(ctx, accountID, filters...)
+}
+
+// AllTickets records the call and returns the results of AllTicketsFunc.
+func (c *Client) AllTickets(ctx context.Context, accountID string, filters ...moov.ListTicketFilter) iter.Seq2[moov.Ticket, error] {
+	c.record("AllTickets", accountID, filters)
+	if c.AllTicketsFunc == nil {
+		err := notStubbed("AllTickets")
+		return failedSeq[moov.Ticket](err)
+	}
+	return c.AllTicketsFunc(ctx, accountID, filters...)
+}
+
+// ListTicketMessages records the call and returns the results of ListTicketMessagesFunc.
+func (c *Client) ListTicketMessages(ctx context.Context, accountID string, ticketID string) ([]moov.TicketMessage, error) {
+	c.record("ListTicketMessages", accountID, ticketID)
+	if c.ListTicketMessagesFunc == nil {
+		err := notStubbed("ListTicketMessages")
+		return nil, err
+	}
+	return c.ListTicketMessagesFunc(ctx, accountID, ticketID)
+}
+
+// GetTicket records the call and returns the results of GetTicketFunc.
+func (c *Client) GetTicket(ctx context.Context, accountID string, ticketID string) (*moov.Ticket, error) {
+	c.record("GetTicket", accountID, ticketID)
+	if c.GetTicketFunc == nil {
+		err := notStubbed("GetTicket")
+		return nil, err
+	}
+	return c.GetTicketFunc(ctx, accountID, ticketID)
+}
+
+// UpdateTicket records the call and returns the results of UpdateTicketFunc.
+func (c *Client) UpdateTicket(ctx context.Context, accountID string, ticketID string, update moov.UpdateTicket) (*moov.Ticket, error) {
+	c.record("UpdateTicket", accountID, ticketID, update)
+	if c.UpdateTicketFunc == nil {
+		err := notStubbed("UpdateTicket")
+		return nil, err
+	}
+	return c.UpdateTicketFunc(ctx, accountID, ticketID, update)
+}
+
+// ListSweepConfigs records the call and returns the results of ListSweepConfigsFunc.
+func (c *Client) ListSweepConfigs(ctx context.Context, accountID string) ([]moov.SweepConfig, error) {
+	c.record("ListSweepConfigs", accountID)
+	if c.ListSweepConfigsFunc == nil {
+		err := notStubbed("ListSweepConfigs")
+		return nil, err
+	}
+	return c.ListSweepConfigsFunc(ctx, accountID)
+}
+
+// GetSweepConfig records the call and returns the results of GetSweepConfigFunc.
+func (c *Client) GetSweepConfig(ctx context.Context, accountID string, sweepConfigID string) (*moov.SweepConfig, error) {
+	c.record("GetSweepConfig", accountID, sweepConfigID)
+	if c.GetSweepConfigFunc == nil {
+		err := notStubbed("GetSweepConfig")
+		return nil, err
+	}
+	return c.GetSweepConfigFunc(ctx, accountID, sweepConfigID)
+}
+
+// CreateSweepConfig records the call and returns the results of CreateSweepConfigFunc.
+func (c *Client) CreateSweepConfig(ctx context.Context, create moov.CreateSweepConfig) (*moov.SweepConfig, error) {
+	c.record("CreateSweepConfig", create)
+	if c.CreateSweepConfigFunc == nil {
+		err := notStubbed("CreateSweepConfig")
+		return nil, err
+	}
+	return c.CreateSweepConfigFunc(ctx, create)
+}
+
+// UpdateSweepConfig records the call and returns the results of UpdateSweepConfigFunc.
+func (c *Client) UpdateSweepConfig(ctx context.Context, update moov.UpdateSweepConfig) (*moov.SweepConfig, error) {
+	c.record("UpdateSweepConfig", update)
+	if c.UpdateSweepConfigFunc == nil {
+		err := notStubbed("UpdateSweepConfig")
+		return nil, err
+	}
+	return c.UpdateSweepConfigFunc(ctx, update)
+}
+
+// ListSweeps records the call and returns the results of ListSweepsFunc.
+func (c *Client) ListSweeps(ctx context.Context, accountID string, walletID string, filters ...moov.ListSweepsFilter) ([]moov.Sweep, error) {
+	c.record("ListSweeps", accountID, walletID, filters)
+	if c.ListSweepsFunc == nil {
+		err := notStubbed("ListSweeps")
+		return nil, err
+	}
+	return c.ListSweepsFunc(ctx, accountID, walletID, filters...)
+}
+
+// AllSweeps records the call and returns the results of AllSweepsFunc.
+func (c *Client) AllSweeps(ctx context.Context, accountID string, walletID string, filters ...moov.ListSweepsFilter) iter.Seq2[moov.Sweep, error] {
+	c.record("AllSweeps", accountID, walletID, filters)
+	if c.AllSweepsFunc == nil {
+		err := notStubbed("AllSweeps")
+		return failedSeq[moov.Sweep](err)
+	}
+	return c.AllSweepsFunc(ctx, accountID, walletID, filters...)
+}
+
+// GetSweep records the call and returns the results of GetSweepFunc.
+func (c *Client) GetSweep(ctx context.Context, accountID string, walletID string, sweepID string) (*moov.Sweep, error) {
+	c.record("GetSweep", accountID, walletID, sweepID)
+	if c.GetSweepFunc == nil {
+		err := notStubbed("GetSweep")
+		return nil, err
+	}
+	return c.GetSweepFunc(ctx, accountID, walletID, sweepID)
+}
+
+// LinkAccountTerminalApplication records the call and returns the results of LinkAccountTerminalApplicationFunc.
+func (c *Client) LinkAccountTerminalApplication(ctx context.Context, accountID string, terminalApplicationID string) (*moov.AccountTerminalApplication, error) {
+	c.record("LinkAccountTerminalApplication", accountID, terminalApplicationID)
+	if c.LinkAccountTerminalApplicationFunc == nil {
+		err := notStubbed("LinkAccountTerminalApplication")
+		return nil, err
+	}
+	return c.LinkAccountTerminalApplicationFunc(ctx, accountID, terminalApplicationID)
+}
+
+// GetAccountTerminalApplication records the call and returns the results of GetAccountTerminalApplicationFunc.
+func (c *Client) GetAccountTerminalApplication(ctx context.Context, accountID string, terminalApplicationID string) (*moov.AccountTerminalApplication, error) {
+	c.record("GetAccountTerminalApplication", accountID, terminalApplicationID)
+	if c.GetAccountTerminalApplicationFunc == nil {
+		err := notStubbed("GetAccountTerminalApplication")
+		return nil, err
+	}
+	return c.GetAccountTerminalApplicationFunc(ctx, accountID, terminalApplicationID)
+}
+
+// ListAccountTerminalApplications records the call and returns the results of ListAccountTerminalApplicationsFunc.
+func (c *Client) ListAccountTerminalApplications(ctx context.Context, accountID string) ([]moov.AccountTerminalApplication, error) {
+	c.record("ListAccountTerminalApplications", accountID)
+	if c.ListAccountTerminalApplicationsFunc == nil {
+		err := notStubbed("ListAccountTerminalApplications")
+		return nil, err
+	}
+	return c.ListAccountTerminalApplicationsFunc(ctx, accountID)
+}
+
+// GetAccountTerminalApplicationConfiguration records the call and returns the results of GetAccountTerminalApplicationConfigurationFunc.
+func (c *Client) GetAccountTerminalApplicationConfiguration(ctx context.Context, accountID string, terminalApplicationID string) (*moov.AccountTerminalApplicationConfiguration, error) {
+	c.record("GetAccountTerminalApplicationConfiguration", accountID, terminalApplicationID)
+	if c.GetAccountTerminalApplicationConfigurationFunc == nil {
+		err := notStubbed("GetAccountTerminalApplicationConfiguration")
+		return nil, err
+	}
+	return c.GetAccountTerminalApplicationConfigurationFunc(ctx, accountID, terminalApplicationID)
+}
+
+// CreateTerminalApplication records the call and returns the results of CreateTerminalApplicationFunc.
+func (c *Client) CreateTerminalApplication(ctx context.Context, terminalApplication moov.TerminalApplicationRequest) (*moov.TerminalApplication, error) {
+	c.record("CreateTerminalApplication", terminalApplication)
+	if c.CreateTerminalApplicationFunc == nil {
+		err := notStubbed("CreateTerminalApplication")
+		return nil, err
+	}
+	return c.CreateTerminalApplicationFunc(ctx, terminalApplication)
+}
+
+// GetTerminalApplication records the call and returns the results of GetTerminalApplicationFunc.
+func (c *Client) GetTerminalApplication(ctx context.Context, terminalApplicationID string) (*moov.TerminalApplication, error) {
+	c.record("GetTerminalApplication", terminalApplicationID)
+	if c.GetTerminalApplicationFunc == nil {
+		err := notStubbed("GetTerminalApplication")
+		return nil, err
+	}
+	return c.GetTerminalApplicationFunc(ctx, terminalApplicationID)
+}
+
+// ListTerminalApplications records the call and returns the results of ListTerminalApplicationsFunc.
+func (c *Client) ListTerminalApplications(ctx context.Context) ([]moov.TerminalApplication, error) {
+	c.record("ListTerminalApplications")
+	if c.ListTerminalApplicationsFunc == nil {
+		err := notStubbed("ListTerminalApplications")
+		return nil, err
+	}
+	return c.ListTerminalApplicationsFunc(ctx)
+}
+
+// DeleteTerminalApplication records the call and returns the results of DeleteTerminalApplicationFunc.
+func (c *Client) DeleteTerminalApplication(ctx context.Context, terminalApplicationID string) error {
+	c.record("DeleteTerminalApplication", terminalApplicationID)
+	if c.DeleteTerminalApplicationFunc == nil {
+		err := notStubbed("DeleteTerminalApplication")
+		return err
+	}
+	return c.DeleteTerminalApplicationFunc(ctx, terminalApplicationID)
+}
+
+// CreateTerminalApplicationVersion records the call and returns the results of CreateTerminalApplicationVersionFunc.
+func (c *Client) CreateTerminalApplicationVersion(ctx context.Context, terminalApplicationID string, version string) (*moov.TerminalApplicationVersion, error) {
+	c.record("CreateTerminalApplicationVersion", terminalApplicationID, version)
+	if c.CreateTerminalApplicationVersionFunc == nil {
+		err := notStubbed("CreateTerminalApplicationVersion")
+		return nil, err
+	}
+	return c.CreateTerminalApplicationVersionFunc(ctx, terminalApplicationID, version)
+}
+
+// GetTransferRiskOutcomes records the call and returns the results of GetTransferRiskOutcomesFunc.
+func (c *Client) GetTransferRiskOutcomes(ctx context.Context, transferID string) (*moov.TransferRiskOutcomes, error) {
+	c.record("GetTransferRiskOutcomes", transferID)
+	if c.GetTransferRiskOutcomesFunc == nil {
+		err := notStubbed("GetTransferRiskOutcomes")
+		return nil, err
+	}
+	return c.GetTransferRiskOutcomesFunc(ctx, transferID)
+}
+
+// CreateTransfer records the call and returns the results of CreateTransferFunc.
+func (c *Client) CreateTransfer(ctx context.Context, partnerAccountID string, transfer moov.CreateTransfer, options ...moov.CreateTransferArgs) moov.CreateTransferBuilder {
+	c.record("CreateTransfer", partnerAccountID, transfer, options)
+	if c.CreateTransferFunc == nil {
+		err := notStubbed("CreateTransfer")
+		return CreateTransferResult(nil, nil, err)
+	}
+	return c.CreateTransferFunc(ctx, partnerAccountID, transfer, options...)
+}
+
+// ListTransfers records the call and returns the results of ListTransfersFunc.
+func (c *Client) ListTransfers(ctx context.Context, accountID string, filters ...moov.ListTransferFilter) ([]moov.Transfer, error) {
+	c.record("ListTransfers", accountID, filters)
+	if c.ListTransfersFunc == nil {
+		err := notStubbed("ListTransfers")
+		return nil, err
+	}
+	return c.ListTransfersFunc(ctx, accountID, filters...)
+}
+
+// AllTransfers records the call and returns the results of AllTransfersFunc.
+func (c *Client) AllTransfers(ctx context.Context, accountID string, filters ...moov.ListTransferFilter) iter.Seq2[moov.Transfer, error] {
+	c.record("AllTransfers", accountID, filters)
+	if c.AllTransfersFunc == nil {
+		err := notStubbed("AllTransfers")
+		return failedSeq[moov.Transfer](err)
+	}
+	return c.AllTransfersFunc(ctx, accountID, filters...)
+}
+
+// GetTransfer records the call and returns the results of GetTransferFunc.
+func (c *Client) GetTransfer(ctx context.Context, accountID string, transferID string) (*moov.Transfer, error) {
+	c.record("GetTransfer", accountID, transferID)
+	if c.GetTransferFunc == nil {
+		err := notStubbed("GetTransfer")
+		return nil, err
+	}
+	return c.GetTransferFunc(ctx, accountID, transferID)
+}
+
+// PatchTransfer records the call and returns the results of PatchTransferFunc.
+func (c *Client) PatchTransfer(ctx context.Context, accountID string, transferID string, patches ...moov.TransferPatcher) (*moov.Transfer, error) {
+	c.record("PatchTransfer", accountID, transferID, patches)
+	if c.PatchTransferFunc == nil {
+		err := notStubbed("PatchTransfer")
+		return nil, err
+	}
+	return c.PatchTransferFunc(ctx, accountID, transferID, patches...)
+}
+
+// RefundTransfer records the call and returns the results of RefundTransferFunc.
+func (c *Client) RefundTransfer(ctx context.Context, partnerAccountID string, transferID string, refund moov.CreateRefund, options ...moov.CreateRefundArgs) (*moov.Refund, *moov.RefundStarted, error) {
+	c.record("RefundTransfer", partnerAccountID, transferID, refund, options)
+	if c.RefundTransferFunc == nil {
+		err := notStubbed("RefundTransfer")
+		return nil, nil, err
+	}
+	return c.RefundTransferFunc(ctx, partnerAccountID, transferID, refund, options...)
+}
+
+// RefundTransferOperation records the call and returns the results of RefundTransferOperationFunc.
+func (c *Client) RefundTransferOperation(ctx context.Context, partnerAccountID string, transferID string, refund moov.CreateRefund, options ...moov.CreateRefundArgs) (*moov.Operation[moov.Refund], error) {
+	c.record("RefundTransferOperation", partnerAccountID, transferID, refund, options)
+	if c.RefundTransferOperationFunc == nil {
+		err := notStubbed("RefundTransferOperation")
+		return nil, err
+	}
+	return c.RefundTransferOperationFunc(ctx, partnerAccountID, transferID, refund, options...)
+}
+
+// ListRefunds records the call and returns the results of ListRefundsFunc.
+func (c *Client) ListRefunds(ctx context.Context, accountID string, transferID string) ([]moov.Refund, error) {
+	c.record("ListRefunds", accountID, transferID)
+	if c.ListRefundsFunc == nil {
+		err := notStubbed("ListRefunds")
+		return nil, err
+	}
+	return c.ListRefundsFunc(ctx, accountID, transferID)
+}
+
+// GetRefund records the call and returns the results of GetRefundFunc.
+func (c *Client) GetRefund(ctx context.Context, accountID string, transferID string, refundID string) (*moov.Refund, error) {
+	c.record("GetRefund", accountID, transferID, refundID)
+	if c.GetRefundFunc == nil {
+		err := notStubbed("GetRefund")
+		return nil, err
+	}
+	return c.GetRefundFunc(ctx, accountID, transferID, refundID)
+}
+
+// ReverseTransfer records the call and returns the results of ReverseTransferFunc.
+func (c *Client) ReverseTransfer(ctx context.Context, partnerAccountID string, transferID string, refund moov.CreateReversal, options ...moov.CreateReversalArgs) (*moov.CreatedReversal, error) {
+	c.record("ReverseTransfer", partnerAccountID, transferID, refund, options)
+	if c.ReverseTransferFunc == nil {
+		err := notStubbed("ReverseTransfer")
+		return nil, err
+	}
+	return c.ReverseTransferFunc(ctx, partnerAccountID, transferID, refund, options...)
+}
+
+// CancelTransfer records the call and returns the results of CancelTransferFunc.
+func (c *Client) CancelTransfer(ctx context.Context, accountID string, transferID string) (*moov.Cancellation, error) {
+	c.record("CancelTransfer", accountID, transferID)
+	if c.CancelTransferFunc == nil {
+		err := notStubbed("CancelTransfer")
+		return nil, err
+	}
+	return c.CancelTransferFunc(ctx, accountID, transferID)
+}
+
+// GetCancellation records the call and returns the results of GetCancellationFunc.
+func (c *Client) GetCancellation(ctx context.Context, accountID string, transferID string, cancellationID string) (*moov.Cancellation, error) {
+	c.record("GetCancellation", accountID, transferID, cancellationID)
+	if c.GetCancellationFunc == nil {
+		err := notStubbed("GetCancellation")
+		return nil, err
+	}
+	return c.GetCancellationFunc(ctx, accountID, transferID, cancellationID)
+}
+
+// ListCancellations records the call and returns the results of ListCancellationsFunc.
+func (c *Client) ListCancellations(ctx context.Context, accountID string, transferID string) ([]moov.Cancellation, error) {
+	c.record("ListCancellations", accountID, transferID)
+	if c.ListCancellationsFunc == nil {
+		err := notStubbed("ListCancellations")
+		return nil, err
+	}
+	return c.ListCancellationsFunc(ctx, accountID, transferID)
+}
+
+// TransferOptions records the call and returns the results of TransferOptionsFunc.
+func (c *Client) TransferOptions(ctx context.Context, partnerAccountID string, payload moov.CreateTransferOptions) (*moov.TransferOptions, error) {
+	c.record("TransferOptions", partnerAccountID, payload)
+	if c.TransferOptionsFunc == nil {
+		err := notStubbed("TransferOptions")
+		return nil, err
+	}
+	return c.TransferOptionsFunc(ctx, partnerAccountID, payload)
+}
+
+// CreateTransferConfig records the call and returns the results of CreateTransferConfigFunc.
+func (c *Client) CreateTransferConfig(ctx context.Context, accountID string, config moov.UpsertTransferConfig) (*moov.TransferConfig, error) {
+	c.record("CreateTransferConfig", accountID, config)
+	if c.CreateTransferConfigFunc == nil {
+		err := notStubbed("CreateTransferConfig")
+		return nil, err
+	}
+	return c.CreateTransferConfigFunc(ctx, accountID, config)
+}
+
+// GetTransferConfig records the call and returns the results of GetTransferConfigFunc.
+func (c *Client) GetTransferConfig(ctx context.Context, accountID string) (*moov.TransferConfig, error) {
+	c.record("GetTransferConfig", accountID)
+	if c.GetTransferConfigFunc == nil {
+		err := notStubbed("GetTransferConfig")
+		return nil, err
+	}
+	return c.GetTransferConfigFunc(ctx, accountID)
+}
+
+// UpdateTransferConfig records the call and returns the results of UpdateTransferConfigFunc.
+func (c *Client) UpdateTransferConfig(ctx context.Context, accountID string, config moov.UpsertTransferConfig) (*moov.TransferConfig, error) {
+	c.record("UpdateTransferConfig", accountID, config)
+	if c.UpdateTransferConfigFunc == nil {
+		err := notStubbed("UpdateTransferConfig")
+		return nil, err
+	}
+	return c.UpdateTransferConfigFunc(ctx, accountID, config)
+}
+
+// UpsertUnderwriting records the call and returns the results of UpsertUnderwritingFunc.
+func (c *Client) UpsertUnderwriting(ctx context.Context, accountID string, underwriting moov.UpdateUnderwriting) (*moov.Underwriting, error) {
+	c.record("UpsertUnderwriting", accountID, underwriting)
+	if c.UpsertUnderwritingFunc == nil {
+		err := notStubbed("UpsertUnderwriting")
+		return nil, err
+	}
+	return c.UpsertUnderwritingFunc(ctx, accountID, underwriting)
+}
+
+// GetUnderwriting records the call and returns the results of GetUnderwritingFunc.
+func (c *Client) GetUnderwriting(ctx context.Context, accountID string) (*moov.Underwriting, error) {
+	c.record("GetUnderwriting", accountID)
+	if c.GetUnderwritingFunc == nil {
+		err := notStubbed("GetUnderwriting")
+		return nil, err
+	}
+	return c.GetUnderwritingFunc(ctx, accountID)
+}
+
+// ListWallets records the call and returns the results of ListWalletsFunc.
+func (c *Client) ListWallets(ctx context.Context, accountID string, filters ...moov.ListWalletFilter) ([]moov.Wallet, error) {
+	c.record("ListWallets", accountID, filters)
+	if c.ListWalletsFunc == nil {
+		err := notStubbed("ListWallets")
+		return nil, err
+	}
+	return c.ListWalletsFunc(ctx, accountID, filters...)
+}
+
+// AllWallets records the call and returns the results of AllWalletsFunc.
+func (c *Client) AllWallets(ctx context.Context, accountID string, filters ...moov.ListWalletFilter) iter.Seq2[moov.Wallet, error] {
+	c.record("AllWallets", accountID, filters)
+	if c.AllWalletsFunc == nil {
+		err := notStubbed("AllWallets")
+		return failedSeq[moov.Wallet](err)
+	}
+	return c.AllWalletsFunc(ctx, accountID, filters...)
+}
+
+// GetWallet records the call and returns the results of GetWalletFunc.
+func (c *Client) GetWallet(ctx context.Context, accountID string, walletID string) (*moov.Wallet, error) {
+	c.record("GetWallet", accountID, walletID)
+	if c.GetWalletFunc == nil {
+		err := notStubbed("GetWallet")
+		return nil, err
+	}
+	return c.GetWalletFunc(ctx, accountID, walletID)
+}
+
+// CreateWallet records the call and returns the results of CreateWalletFunc.
+func (c *Client) CreateWallet(ctx context.Context, accountID string, create moov.CreateWallet) (*moov.Wallet, error) {
+	c.record("CreateWallet", accountID, create)
+	if c.CreateWalletFunc == nil {
+		err := notStubbed("CreateWallet")
+		return nil, err
+	}
+	return c.CreateWalletFunc(ctx, accountID, create)
+}
+
+// UpdateWallet records the call and returns the results of UpdateWalletFunc.
+func (c *Client) UpdateWallet(ctx context.Context, accountID string, walletID string, update moov.UpdateWallet) (*moov.Wallet, error) {
+	c.record("UpdateWallet", accountID, walletID, update)
+	if c.UpdateWalletFunc == nil {
+		err := notStubbed("UpdateWallet")
+		return nil, err
+	}
+	return c.UpdateWalletFunc(ctx, accountID, walletID, update)
+}
+
+// ListWalletTransactions records the call and returns the results of ListWalletTransactionsFunc.
+func (c *Client) ListWalletTransactions(ctx context.Context, accountID string, walletID string, opts ...moov.ListTransactionFilter) ([]moov.WalletTransaction, error) {
+	c.record("ListWalletTransactions", accountID, walletID, opts)
+	if c.ListWalletTransactionsFunc == nil {
+		err := notStubbed("ListWalletTransactions")
+		return nil, err
+	}
+	return c.ListWalletTransactionsFunc(ctx, accountID, walletID, opts...)
+}
+
+// AllWalletTransactions records the call and returns the results of AllWalletTransactionsFunc.
+func (c *Client) AllWalletTransactions(ctx context.Context, accountID string, walletID string, opts ...moov.ListTransactionFilter) iter.Seq2[moov.WalletTransaction, error] {
+	c.record("AllWalletTransactions", accountID, walletID, opts)
+	if c.AllWalletTransactionsFunc == nil {
+		err := notStubbed("AllWalletTransactions")
+		return failedSeq[moov.WalletTransaction](err)
+	}
+	return c.AllWalletTransactionsFunc(ctx, accountID, walletID, opts...)
+}
+
+// GetWalletTransaction records the call and returns the results of GetWalletTransactionFunc.
+func (c *Client) GetWalletTransaction(ctx context.Context, accountID string, walletID string, transactionID string) (*moov.WalletTransaction, error) {
+	c.record("GetWalletTransaction", accountID, walletID, transactionID)
+	if c.GetWalletTransactionFunc == nil {
+		err := notStubbed("GetWalletTransaction")
+		return nil, err
+	}
+	return c.GetWalletTransactionFunc(ctx, accountID, walletID, transactionID)
+}
+
+// CreateWebhook records the call and returns the results of CreateWebhookFunc.
+func (c *Client) CreateWebhook(ctx context.Context, webhook moov.CreateWebhook) (*moov.Webhook, error) {
+	c.record("CreateWebhook", webhook)
+	if c.CreateWebhookFunc == nil {
+		err := notStubbed("CreateWebhook")
+		return nil, err
+	}
+	return c.CreateWebhookFunc(ctx, webhook)
+}
+
+// ListWebhooks records the call and returns the results of ListWebhooksFunc.
+func (c *Client) ListWebhooks(ctx context.Context) ([]moov.Webhook, error) {
+	c.record("ListWebhooks")
+	if c.ListWebhooksFunc == nil {
+		err := notStubbed("ListWebhooks")
+		return nil, err
+	}
+	return c.ListWebhooksFunc(ctx)
+}
+
+// GetWebhook records the call and returns the results of GetWebhookFunc.
+func (c *Client) GetWebhook(ctx context.Context, webhookID string) (*moov.Webhook, error) {
+	c.record("GetWebhook", webhookID)
+	if c.GetWebhookFunc == nil {
+		err := notStubbed("GetWebhook")
+		return nil, err
+	}
+	return c.GetWebhookFunc(ctx, webhookID)
+}
+
+// UpdateWebhook records the call and returns the results of UpdateWebhookFunc.
+func (c *Client) UpdateWebhook(ctx context.Context, webhookID string, webhook moov.UpdateWebhook) (*moov.Webhook, error) {
+	c.record("UpdateWebhook", webhookID, webhook)
+	if c.UpdateWebhookFunc == nil {
+		err := notStubbed("UpdateWebhook")
+		return nil, err
+	}
+	return c.UpdateWebhookFunc(ctx, webhookID, webhook)
+}
+
+// DeleteWebhook records the call and returns the results of DeleteWebhookFunc.
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
+	c.record("DeleteWebhook", webhookID)
+	if c.DeleteWebhookFunc == nil {
+		err := notStubbed("DeleteWebhook")
+		return err
+	}
+	return c.DeleteWebhookFunc(ctx, webhookID)
+}
+
+// PingWebhook records the call and returns the results of PingWebhookFunc.
+func (c *Client) PingWebhook(ctx context.Context, webhookID string) (*moov.WebhookPing, error) {
+	c.record("PingWebhook", webhookID)
+	if c.PingWebhookFunc == nil {
+		err := notStubbed("PingWebhook")
+		return nil, err
+	}
+	return c.PingWebhookFunc(ctx, webhookID)
+}
+
+// GetWebhookSecret records the call and returns the results of GetWebhookSecretFunc.
+func (c *Client) GetWebhookSecret(ctx context.Context, webhookID string) (*moov.WebhookSecret, error) {
+	c.record("GetWebhookSecret", webhookID)
+	if c.GetWebhookSecretFunc == nil {
+		err := notStubbed("GetWebhookSecret")
+		return nil, err
+	}
+	return c.GetWebhookSecretFunc(ctx, webhookID)
+}
+
+// ListWebhookEventTypes records the call and returns the results of ListWebhookEventTypesFunc.
+func (c *Client) ListWebhookEventTypes(ctx context.Context) ([]moov.WebhookEventType, error) {
+	c.record("ListWebhookEventTypes")
+	if c.ListWebhookEventTypesFunc == nil {
+		err := notStubbed("ListWebhookEventTypes")
+		return nil, err
+	}
+	return c.ListWebhookEventTypesFunc(ctx)
+}
+
+// NewWebhookSecretRotation records the call and returns the results of NewWebhookSecretRotationFunc.
+func (c *Client) NewWebhookSecretRotation(ctx context.Context, webhookID string, grace time.Duration) (*moov.WebhookSecretRotation, error) {
+	c.record("NewWebhookSecretRotation", webhookID, grace)
+	if c.NewWebhookSecretRotationFunc == nil {
+		err := notStubbed("NewWebhookSecretRotation")
+		return nil, err
+	}
+	return c.NewWebhookSecretRotationFunc(ctx, webhookID, grace)
+}
@@ -0,0 +1,159 @@
+// Package moovmock provides Client, a fake of moov.API for testing code that calls Moov.
+//
+// Stub only the calls a test relies on and inspect what was called afterwards:
+//
+//	mc := &moovmock.Client{}
+//	mc.GetTransferFunc = func(ctx context.Context, accountID, transferID string) (*moov.Transfer, error) {
+//		return nil, moovmock.Error(http.StatusNotFound, "transfer not found")
+//	}
+//
+//	svc := NewService(mc) // accepts a moov.TransfersAPI
+//	...
+//	calls := mc.CallsTo("GetTransfer")
+package moovmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"slices"
+
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+// ErrNotStubbed is returned by Client methods whose Func field isn't set.
+var ErrNotStubbed = errors.New("moovmock: method not stubbed")
+
+// Call is a recorded call to the fake. Args holds the arguments after the context, with
+// variadic arguments as a single slice.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Calls returns every call made to the fake so far, in order.
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.calls)
+}
+
+// CallsTo returns the calls made to the given method, e.g. "CreateTransfer".
+func (c *Client) CallsTo(method string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := []Call{}
+	for _, call := range c.calls {
+		if call.Method == method {
+			out = append(out, call)
+		}
+	}
+	return out
+}
+
+// Reset forgets the recorded calls. Stubs are kept.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = nil
+}
+
+func (c *Client) record(method string, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+}
+
+// Error returns the *moov.Error a Client call fails with when Moov responds with the given
+// HTTP status code, so errors.Is matches sentinels like moov.ErrNotFound as it would for a
+// real response. Fill in Fields for field level validation errors.
+func Error(httpCode int, message string) *moov.Error {
+	return &moov.Error{
+		Status:   statusOf(httpCode),
+		HTTPCode: httpCode,
+		Message:  message,
+	}
+}
+
+func statusOf(httpCode int) moov.CallStatus {
+	switch httpCode {
+	case http.StatusBadRequest:
+		return moov.StatusBadRequest
+	case http.StatusConflict:
+		return moov.StatusStateConflict
+	case http.StatusUnprocessableEntity:
+		return moov.StatusFailedValidation
+	case http.StatusNotFound:
+		return moov.StatusNotFound
+	case http.StatusUnauthorized:
+		return moov.StatusUnauthenticated
+	case http.StatusForbidden:
+		return moov.StatusUnauthorized
+	case http.StatusTooManyRequests:
+		return moov.StatusRateLimited
+	default:
+		return moov.StatusServerError
+	}
+}
+
+func notStubbed(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotStubbed, method)
+}
+
+// failedSeq is an iterator yielding only err.
+func failedSeq[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
+
+// CreateTransferResult returns a moov.CreateTransferBuilder for CreateTransferFunc stubs. Started
+// and WaitForRailResponse return the given values instead of calling Moov, and Operation is done
+// once a transfer is given.
+func CreateTransferResult(transfer *moov.Transfer, started *moov.TransferStarted, err error) moov.CreateTransferBuilder {
+	return createTransferResult{transfer: transfer, started: started, err: err}
+}
+
+type createTransferResult struct {
+	transfer *moov.Transfer
+	started  *moov.TransferStarted
+	err      error
+}
+
+func (r createTransferResult) Started() (*moov.TransferStarted, error) {
+	switch {
+	case r.err != nil:
+		return nil, r.err
+	case r.started == nil && r.transfer != nil:
+		return &moov.TransferStarted{TransferID: r.transfer.TransferID, CreatedOn: r.transfer.CreatedOn}, nil
+	}
+	return r.started, nil
+}
+
+func (r createTransferResult) WaitForRailResponse() (*moov.Transfer, *moov.TransferStarted, error) {
+	switch {
+	case r.err != nil:
+		return nil, nil, r.err
+	case r.transfer != nil:
+		return r.transfer, nil, nil
+	}
+	return nil, r.started, nil
+}
+
+func (r createTransferResult) Operation() (*moov.Operation[moov.Transfer], error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	done := func(*moov.Transfer) bool { return true }
+	return moov.NewOperation(r.transfer, done, func(context.Context) (*moov.Transfer, error) {
+		return nil, fmt.Errorf("%w: the CreateTransferResult has no transfer to poll", ErrNotStubbed)
+	}), nil
+}
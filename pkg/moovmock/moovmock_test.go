@@ -0,0 +1,81 @@
+package moovmock
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns stubbed results and records calls", func(t *testing.T) {
+		mc := &Client{
+			GetTransferFunc: func(ctx context.Context, accountID, transferID string) (*moov.Transfer, error) {
+				return &moov.Transfer{TransferID: transferID}, nil
+			},
+		}
+
+		var api moov.TransfersAPI = mc
+		transfer, err := api.GetTransfer(ctx, "accountID", "transferID")
+		require.NoError(t, err)
+		require.Equal(t, "transferID", transfer.TransferID)
+
+		_, err = api.ListTransfers(ctx, "accountID", moov.WithTransferCount(5))
+		require.ErrorIs(t, err, ErrNotStubbed)
+
+		require.Len(t, mc.Calls(), 2)
+		require.Equal(t, []Call{{Method: "GetTransfer", Args: []any{"accountID", "transferID"}}}, mc.CallsTo("GetTransfer"))
+
+		mc.Reset()
+		require.Empty(t, mc.Calls())
+	})
+
+	t.Run("typed errors", func(t *testing.T) {
+		mc := &Client{
+			GetWalletFunc: func(ctx context.Context, accountID, walletID string) (*moov.Wallet, error) {
+				return nil, Error(http.StatusNotFound, "wallet not found")
+			},
+		}
+
+		_, err := mc.GetWallet(ctx, "accountID", "walletID")
+		require.ErrorIs(t, err, moov.ErrNotFound)
+
+		var moovErr *moov.Error
+		require.ErrorAs(t, err, &moovErr)
+		require.Equal(t, "wallet not found", moovErr.Message)
+	})
+
+	t.Run("create transfer", func(t *testing.T) {
+		mc := &Client{
+			CreateTransferFunc: func(ctx context.Context, partnerAccountID string, transfer moov.CreateTransfer, options ...moov.CreateTransferArgs) moov.CreateTransferBuilder {
+				return CreateTransferResult(&moov.Transfer{TransferID: "transferID", Status: moov.TransferStatus_Pending}, nil, nil)
+			},
+		}
+
+		started, err := mc.CreateTransfer(ctx, "accountID", moov.CreateTransfer{}).Started()
+		require.NoError(t, err)
+		require.Equal(t, "transferID", started.TransferID)
+
+		transfer, _, err := mc.CreateTransfer(ctx, "accountID", moov.CreateTransfer{}).WaitForRailResponse()
+		require.NoError(t, err)
+		require.Equal(t, moov.TransferStatus_Pending, transfer.Status)
+
+		op, err := mc.CreateTransfer(ctx, "accountID", moov.CreateTransfer{}).Operation()
+		require.NoError(t, err)
+		require.True(t, op.Done())
+
+		_, err = (&Client{}).CreateTransfer(ctx, "accountID", moov.CreateTransfer{}).Started()
+		require.ErrorIs(t, err, ErrNotStubbed)
+	})
+
+	t.Run("iterators", func(t *testing.T) {
+		for _, err := range (&Client{}).AllAccounts(ctx) {
+			require.ErrorIs(t, err, ErrNotStubbed)
+		}
+	})
+}
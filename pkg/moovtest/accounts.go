@@ -0,0 +1,347 @@
+package moovtest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+type account struct {
+	moov.Account
+	capabilities *store[*moov.Capability]
+}
+
+func (s *Server) accountRoutes() {
+	s.mux.HandleFunc("POST /accounts", s.createAccount)
+	s.mux.HandleFunc("GET /accounts", s.listAccounts)
+	s.mux.HandleFunc("GET /accounts/{accountID}", s.getAccount)
+	s.mux.HandleFunc("PATCH /accounts/{accountID}", s.patchAccount)
+	s.mux.HandleFunc("DELETE /accounts/{accountID}", s.disconnectAccount)
+
+	s.mux.HandleFunc("POST /accounts/{accountID}/capabilities", s.requestCapabilities)
+	s.mux.HandleFunc("GET /accounts/{accountID}/capabilities", s.listCapabilities)
+	s.mux.HandleFunc("GET /accounts/{accountID}/capabilities/{capability}", s.getCapability)
+	s.mux.HandleFunc("DELETE /accounts/{accountID}/capabilities/{capability}", s.disableCapability)
+}
+
+// Accounts are created with a default wallet so they can send and receive funds right away.
+func (s *Server) createAccount(w http.ResponseWriter, r *http.Request) {
+	var create moov.CreateAccount
+	if !decode(w, r, &create) {
+		return
+	}
+
+	a := &account{
+		Account: moov.Account{
+			Mode:        moov.MODE_SANDBOX,
+			AccountID:   uuid.NewString(),
+			AccountType: create.Type,
+			Metadata:    create.Metadata,
+			ForeignID:   create.ForeignID,
+			Settings:    create.AccountSettings,
+		},
+		capabilities: newStore[*moov.Capability](),
+	}
+
+	switch {
+	case create.Type == moov.AccountType_Individual && create.Profile.Individual != nil:
+		p := create.Profile.Individual
+		a.Profile.Individual = &moov.Individual{
+			Name:                 p.Name,
+			Phone:                p.Phone,
+			Email:                p.Email,
+			Address:              p.Address,
+			BirthDateProvided:    p.BirthDate != nil,
+			GovernmentIDProvided: p.GovernmentID != nil,
+		}
+	case create.Type == moov.AccountType_Business && create.Profile.Business != nil:
+		p := create.Profile.Business
+		a.Profile.Business = &moov.Business{
+			LegalBusinessName: p.Name,
+			DoingBusinessAs:   p.DBA,
+			BusinessType:      p.Type,
+			Address:           p.Address,
+			Phone:             p.Phone,
+			Email:             p.Email,
+			Website:           p.Website,
+			Description:       p.Description,
+			TaxIDProvided:     p.TaxID != nil,
+			Industry:          p.Industry,
+		}
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "profile must match accountType %q", create.Type)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a.CreatedOn, a.UpdatedOn = s.now, s.now
+	a.DisplayName = displayName(a.Profile)
+	s.accounts.put(a.AccountID, a)
+	s.emit(moov.EventTypeAccountCreated, mhooks.AccountCreated{AccountID: a.AccountID, ForeignID: a.ForeignID})
+
+	s.addWallet(a.AccountID, "Default", moov.WalletType_Default)
+	s.enableCapabilities(a, create.RequestedCapabilities)
+
+	writeJSON(w, http.StatusOK, a.view())
+}
+
+func (s *Server) listAccounts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accounts := s.accounts.list(func(a *account) bool {
+		switch {
+		case a.DisconnectedOn != nil && q.Get("includeDisconnected") != "true":
+			return false
+		case q.Has("foreignID") && a.ForeignID != q.Get("foreignID"):
+			return false
+		case q.Has("type") && string(a.AccountType) != q.Get("type"):
+			return false
+		case q.Has("name") && !strings.Contains(strings.ToLower(a.DisplayName), strings.ToLower(q.Get("name"))):
+			return false
+		}
+		return true
+	})
+
+	out := []moov.Account{}
+	for _, a := range page(r, accounts) {
+		out = append(out, a.view())
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, ok := s.lookupAccount(w, r); ok {
+		writeJSON(w, http.StatusOK, a.view())
+	}
+}
+
+func (s *Server) patchAccount(w http.ResponseWriter, r *http.Request) {
+	var patch moov.PatchAccount
+	if !decode(w, r, &patch) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	if patch.ForeignID != "" {
+		a.ForeignID = patch.ForeignID
+	}
+	if patch.Metadata != nil {
+		a.Metadata = patch.Metadata
+	}
+	if patch.AccountSettings != nil {
+		a.Settings = patch.AccountSettings
+	}
+	if p, i := patch.Profile.Individual, a.Profile.Individual; p != nil && i != nil {
+		if p.Name.FirstName != "" || p.Name.LastName != "" {
+			i.Name = p.Name
+		}
+		if p.Email != "" {
+			i.Email = p.Email
+		}
+		if p.Phone != nil {
+			i.Phone = p.Phone
+		}
+		if p.Address != nil {
+			i.Address = p.Address
+		}
+		i.BirthDateProvided = i.BirthDateProvided || p.BirthDate != nil
+		i.GovernmentIDProvided = i.GovernmentIDProvided || p.GovernmentID != nil
+	}
+	if p, b := patch.Profile.Business, a.Profile.Business; p != nil && b != nil {
+		if p.Name != "" {
+			b.LegalBusinessName = p.Name
+		}
+		if p.DBA != "" {
+			b.DoingBusinessAs = p.DBA
+		}
+		if p.Email != "" {
+			b.Email = p.Email
+		}
+		if p.Phone != nil {
+			b.Phone = p.Phone
+		}
+		if p.Address != nil {
+			b.Address = p.Address
+		}
+		b.TaxIDProvided = b.TaxIDProvided || p.TaxID != nil
+	}
+
+	a.DisplayName = displayName(a.Profile)
+	a.UpdatedOn = s.now
+	s.emit(moov.EventTypeAccountUpdated, mhooks.AccountUpdated{AccountID: a.AccountID, ForeignID: a.ForeignID})
+
+	writeJSON(w, http.StatusOK, a.view())
+}
+
+func (s *Server) disconnectAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	now := s.now
+	a.DisconnectedOn = &now
+	s.emit(moov.EventTypeAccountDisconnected, mhooks.AccountDisconnected{AccountID: a.AccountID, ForeignID: a.ForeignID})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Capabilities are enabled as soon as they're requested, as if every requirement was met.
+func (s *Server) requestCapabilities(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Capabilities []moov.CapabilityName `json:"capabilities"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+	s.enableCapabilities(a, req.Capabilities)
+
+	out := []moov.Capability{}
+	for _, c := range a.capabilities.list(all) {
+		out = append(out, *c)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) listCapabilities(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	out := []moov.Capability{}
+	for _, c := range a.capabilities.list(all) {
+		out = append(out, *c)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getCapability(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	c, ok := a.capabilities.get(r.PathValue("capability"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "capability not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) disableCapability(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	c, ok := a.capabilities.get(r.PathValue("capability"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "capability not found")
+		return
+	}
+
+	now := s.now
+	c.Status, c.UpdatedOn, c.DisabledOn = moov.CapabilityStatus_Disabled, now, &now
+	a.syncCapabilities()
+	s.emit(moov.EventTypeCapabilityUpdated, mhooks.CapabilityUpdated{Capability: c.Capability, AccountID: a.AccountID, ForeignID: a.ForeignID, Status: c.Status})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// enableCapabilities must be called with the lock held.
+func (s *Server) enableCapabilities(a *account, names []moov.CapabilityName) {
+	for _, name := range names {
+		c, ok := a.capabilities.get(string(name))
+		if ok && c.Status == moov.CapabilityStatus_Enabled {
+			continue
+		}
+		if !ok {
+			c = &moov.Capability{Capability: name, AccountID: a.AccountID, CreatedOn: s.now}
+			a.capabilities.put(string(name), c)
+		}
+		c.Status, c.UpdatedOn, c.DisabledOn = moov.CapabilityStatus_Enabled, s.now, nil
+
+		s.emit(moov.EventTypeCapabilityRequested, mhooks.CapabilityRequested{Capability: name, AccountID: a.AccountID, ForeignID: a.ForeignID})
+		s.emit(moov.EventTypeCapabilityUpdated, mhooks.CapabilityUpdated{Capability: name, AccountID: a.AccountID, ForeignID: a.ForeignID, Status: c.Status})
+	}
+	a.syncCapabilities()
+}
+
+func (a *account) syncCapabilities() {
+	a.Capabilities = nil
+	for _, c := range a.capabilities.list(all) {
+		a.Capabilities = append(a.Capabilities, moov.AccountCapability{Capability: c.Capability, Status: c.Status})
+	}
+}
+
+func (a *account) view() moov.Account {
+	return a.Account
+}
+
+// lookupAccount finds the account in the path, responding with 404 if it doesn't exist or
+// was disconnected. Must be called with the lock held.
+func (s *Server) lookupAccount(w http.ResponseWriter, r *http.Request) (*account, bool) {
+	a, ok := s.accounts.get(r.PathValue("accountID"))
+	if !ok || a.DisconnectedOn != nil {
+		writeError(w, http.StatusNotFound, "account not found")
+		return nil, false
+	}
+	return a, true
+}
+
+func displayName(p moov.Profile) string {
+	switch {
+	case p.Individual != nil:
+		return strings.TrimSpace(p.Individual.Name.FirstName + " " + p.Individual.Name.LastName)
+	case p.Business != nil && p.Business.DoingBusinessAs != "":
+		return p.Business.DoingBusinessAs
+	case p.Business != nil:
+		return p.Business.LegalBusinessName
+	}
+	return ""
+}
+
+func all[T any](T) bool {
+	return true
+}
@@ -0,0 +1,81 @@
+package moovtest
+
+import (
+	"net/http"
+
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+type failures struct {
+	// Responses forced on the next requests, in order.
+	statuses []int
+
+	achReturns   map[string]moov.AchReturnCode
+	cardDeclines map[string]moov.CardFailureCode
+}
+
+// RateLimit responds to the next n requests with 429 Too Many Requests and a Retry-After of 0.
+func (s *Server) RateLimit(n int) {
+	s.FailNext(http.StatusTooManyRequests, n)
+}
+
+// FailNext responds to the next n requests with status and an error body, before they reach
+// any endpoint.
+func (s *Server) FailNext(status int, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for range n {
+		s.failures.statuses = append(s.failures.statuses, status)
+	}
+}
+
+// ReturnACH makes the next ACH transfer debiting or crediting the bank account get returned
+// with code when it settles. The transfer fails and any wallet it debited is restored.
+func (s *Server) ReturnACH(bankAccountID string, code moov.AchReturnCode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures.achReturns == nil {
+		s.failures.achReturns = map[string]moov.AchReturnCode{}
+	}
+	s.failures.achReturns[bankAccountID] = code
+}
+
+// DeclineCard makes the next transfer pulling from the card get declined with code. The
+// transfer fails as soon as it's created.
+func (s *Server) DeclineCard(cardID string, code moov.CardFailureCode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures.cardDeclines == nil {
+		s.failures.cardDeclines = map[string]moov.CardFailureCode{}
+	}
+	s.failures.cardDeclines[cardID] = code
+}
+
+func (s *Server) injectedFailure() (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.failures.statuses) == 0 {
+		return 0, false
+	}
+	status := s.failures.statuses[0]
+	s.failures.statuses = s.failures.statuses[1:]
+	return status, true
+}
+
+// takeACHReturn consumes the return injected for a bank account. Must be called with the lock held.
+func (s *Server) takeACHReturn(bankAccountID string) (moov.AchReturnCode, bool) {
+	code, ok := s.failures.achReturns[bankAccountID]
+	delete(s.failures.achReturns, bankAccountID)
+	return code, ok
+}
+
+// takeCardDecline consumes the decline injected for a card. Must be called with the lock held.
+func (s *Server) takeCardDecline(cardID string) (moov.CardFailureCode, bool) {
+	code, ok := s.failures.cardDeclines[cardID]
+	delete(s.failures.cardDeclines, cardID)
+	return code, ok
+}
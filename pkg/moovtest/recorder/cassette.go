@@ -0,0 +1,240 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const cassetteVersion = 1
+
+// Cassette is the file format interactions are recorded in.
+type Cassette struct {
+	Version      int            `json:"version"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	RecordedAt time.Time     `json:"recordedAt"`
+	Duration   time.Duration `json:"duration"`
+	Request    Request       `json:"request"`
+	Response   Response      `json:"response"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	Scheme string      `json:"scheme"`
+	Host   string      `json:"host"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body"`
+}
+
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body"`
+}
+
+// Body holds text as is and anything else, like PDFs or images, base64 encoded.
+type Body struct {
+	Text   string `json:"text,omitempty"`
+	Base64 string `json:"base64,omitempty"`
+}
+
+func newBody(b []byte) Body {
+	if utf8.Valid(b) {
+		return Body{Text: string(b)}
+	}
+	return Body{Base64: base64.StdEncoding.EncodeToString(b)}
+}
+
+func (b Body) Bytes() []byte {
+	if b.Base64 != "" {
+		decoded, _ := base64.StdEncoding.DecodeString(b.Base64)
+		return decoded
+	}
+	return []byte(b.Text)
+}
+
+func (r Response) toHTTP(req *http.Request) *http.Response {
+	body := r.Body.Bytes()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func loadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cassette := &Cassette{}
+	if err := json.Unmarshal(data, cassette); err != nil {
+		return nil, err
+	}
+	if cassette.Version != cassetteVersion {
+		return nil, fmt.Errorf("unsupported cassette version %d", cassette.Version)
+	}
+	return cassette, nil
+}
+
+func saveCassette(path string, cassette *Cassette) error {
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+const scrubbed = "[SCRUBBED]"
+
+// Body fields scrubbed by default, matched case-insensitively. Everything below a matching
+// field is scrubbed while keeping its shape so replayed responses still decode.
+var defaultScrubFields = []string{
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"secret",
+	"secretKey",
+	"token",
+	"accountNumber",
+	"cardNumber",
+	"cardCvv",
+	"cvv",
+	"expiration",
+	"ssn",
+	"itin",
+	"taxID",
+	"governmentID",
+	"birthDate",
+	"email",
+	"phone",
+}
+
+var defaultScrubHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+type scrubber struct {
+	fields  []string
+	headers []string
+}
+
+func newScrubber() scrubber {
+	return scrubber{
+		fields:  slices.Clone(defaultScrubFields),
+		headers: slices.Clone(defaultScrubHeaders),
+	}
+}
+
+func (s scrubber) request(req *http.Request, body []byte) Request {
+	return Request{
+		Method: req.Method,
+		Scheme: req.URL.Scheme,
+		Host:   req.URL.Host,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+		Header: s.header(req.Header),
+		Body:   Body{Text: s.body(req.Header.Get("Content-Type"), body)},
+	}
+}
+
+func (s scrubber) response(resp *http.Response, body []byte) Response {
+	r := Response{
+		StatusCode: resp.StatusCode,
+		Header:     s.header(resp.Header),
+		Body:       newBody(body),
+	}
+	if isJSON(resp.Header.Get("Content-Type")) {
+		r.Body = Body{Text: s.body("application/json", body)}
+	}
+	// Scrubbing can change the length of the body, it's set from the replayed body instead.
+	r.Header.Del("Content-Length")
+	return r
+}
+
+func (s scrubber) header(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range s.headers {
+		if _, ok := out[http.CanonicalHeaderKey(name)]; ok {
+			out.Set(name, scrubbed)
+		}
+	}
+	return out
+}
+
+// body normalizes a request or response body for matching and storage. JSON is re-encoded with
+// sorted keys and its sensitive fields scrubbed. Multipart bodies are left out as their
+// boundaries change on every request.
+func (s scrubber) body(contentType string, body []byte) string {
+	switch {
+	case len(body) == 0, strings.HasPrefix(contentType, "multipart/"):
+		return ""
+	case !isJSON(contentType) && !json.Valid(body):
+		return newBody(body).Text
+	}
+
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return string(body)
+	}
+	normalized, err := json.Marshal(s.value(doc, false))
+	if err != nil {
+		return string(body)
+	}
+	return string(normalized)
+}
+
+func (s scrubber) value(v any, scrub bool) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			match := slices.ContainsFunc(s.fields, func(f string) bool { return strings.EqualFold(f, k) })
+			t[k] = s.value(child, scrub || match)
+		}
+	case []any:
+		for i, child := range t {
+			t[i] = s.value(child, scrub)
+		}
+	case string:
+		if scrub {
+			return scrubbed
+		}
+	case float64:
+		if scrub {
+			return 0
+		}
+	}
+	return v
+}
+
+func isJSON(contentType string) bool {
+	return strings.Contains(strings.ToLower(contentType), "json")
+}
@@ -0,0 +1,162 @@
+package recorder
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	moovgo "github.com/moovfinancial/moov-go"
+)
+
+// HAR 1.2 types, see http://www.softwareishard.com/blog/har-12-spec/
+type harLog struct {
+	Log harContent `json:"log"`
+}
+
+type harContent struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContentBody `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContentBody struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// WriteHAR writes the interactions in the cassette as a HAR file, which can be opened in
+// browser developer tools and other HTTP inspection tools.
+func (r *Recorder) WriteHAR(w io.Writer) error {
+	har := harLog{Log: harContent{
+		Version: "1.2",
+		Creator: harCreator{Name: "moov-go", Version: moovgo.Version()},
+		Entries: []harEntry{},
+	}}
+
+	for _, i := range r.Interactions() {
+		har.Log.Entries = append(har.Log.Entries, i.harEntry())
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(har)
+}
+
+func (i *Interaction) harEntry() harEntry {
+	u := url.URL{Scheme: i.Request.Scheme, Host: i.Request.Host, Path: i.Request.Path, RawQuery: i.Request.Query}
+	ms := float64(i.Duration) / float64(time.Millisecond)
+
+	entry := harEntry{
+		StartedDateTime: i.RecordedAt.Format(time.RFC3339Nano),
+		Time:            ms,
+		Request: harRequest{
+			Method:      i.Request.Method,
+			URL:         u.String(),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harNameValues(i.Request.Header),
+			QueryString: harNameValues(u.Query()),
+			HeadersSize: -1,
+			BodySize:    len(i.Request.Body.Text),
+		},
+		Response: harResponse{
+			Status:      i.Response.StatusCode,
+			StatusText:  http.StatusText(i.Response.StatusCode),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harNameValues(i.Response.Header),
+			Content: harContentBody{
+				Size:     len(i.Response.Body.Bytes()),
+				MimeType: i.Response.Header.Get("Content-Type"),
+				Text:     i.Response.Body.Text,
+			},
+			HeadersSize: -1,
+			BodySize:    len(i.Response.Body.Bytes()),
+		},
+		Timings: harTimings{Wait: ms},
+	}
+
+	if i.Request.Body.Text != "" {
+		entry.Request.PostData = &harPostData{
+			MimeType: i.Request.Header.Get("Content-Type"),
+			Text:     i.Request.Body.Text,
+		}
+	}
+	if i.Response.Body.Base64 != "" {
+		entry.Response.Content.Text = i.Response.Body.Base64
+		entry.Response.Content.Encoding = "base64"
+	}
+
+	return entry
+}
+
+// harNameValues lists the values of headers or query params sorted by name.
+func harNameValues(m map[string][]string) []harNameValue {
+	out := []harNameValue{}
+	for _, name := range slices.Sorted(maps.Keys(m)) {
+		for _, v := range m[name] {
+			out = append(out, harNameValue{Name: name, Value: v})
+		}
+	}
+	return out
+}
@@ -0,0 +1,252 @@
+// Package recorder records HTTP interactions with Moov into cassette files and replays them
+// offline, so tests run without sandbox credentials once a cassette has been recorded.
+//
+//	rec := recorder.ForTest(t, "testdata/create_transfer.json")
+//	mc, err := moov.NewClient(moov.WithHttpClient(rec.Client()))
+//
+// Authorization headers, cookies and sensitive body fields are scrubbed before anything is written.
+package recorder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ErrNoInteraction is returned when replaying a request that isn't in the cassette.
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+type Mode int
+
+const (
+	// ModeReplayOrRecord replays recorded interactions and records requests that aren't in the cassette yet.
+	ModeReplayOrRecord Mode = iota
+	// ModeReplay only replays recorded interactions and never calls the network.
+	ModeReplay
+	// ModeRecord calls the network for every request and replaces the cassette.
+	ModeRecord
+)
+
+// Matcher reports if a recorded request matches the request being made. The body of the
+// request is normalized and scrubbed the same way recorded bodies are.
+type Matcher func(req *http.Request, body string, recorded Request) bool
+
+type Option func(r *Recorder)
+
+// WithMode sets how the recorder uses the cassette, ModeReplayOrRecord by default.
+func WithMode(mode Mode) Option {
+	return func(r *Recorder) {
+		r.mode = mode
+	}
+}
+
+// WithTransport sets the RoundTripper used to record interactions, http.DefaultTransport by default.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// WithMatcher replaces the default matching on method, path, query and normalized body.
+func WithMatcher(m Matcher) Option {
+	return func(r *Recorder) {
+		r.matcher = m
+	}
+}
+
+// WithScrubFields scrubs the values of more JSON body fields in addition to the defaults.
+func WithScrubFields(fields ...string) Option {
+	return func(r *Recorder) {
+		r.scrubber.fields = append(r.scrubber.fields, fields...)
+	}
+}
+
+// WithScrubHeaders scrubs more headers in addition to Authorization and cookies.
+func WithScrubHeaders(headers ...string) Option {
+	return func(r *Recorder) {
+		r.scrubber.headers = append(r.scrubber.headers, headers...)
+	}
+}
+
+// Recorder is an http.RoundTripper recording to and replaying from a cassette file.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	matcher   Matcher
+	scrubber  scrubber
+	now       func() time.Time
+
+	mu       sync.Mutex
+	cassette *Cassette
+	replayed []bool
+	modified bool
+}
+
+// New creates a recorder for the cassette at path. The cassette is loaded unless the mode is
+// ModeRecord, and it's required to exist for ModeReplay.
+func New(path string, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		transport: http.DefaultTransport,
+		matcher:   defaultMatcher,
+		scrubber:  newScrubber(),
+		now:       time.Now,
+		cassette:  &Cassette{Version: cassetteVersion},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode != ModeRecord {
+		cassette, err := loadCassette(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist) && r.mode == ModeReplayOrRecord:
+		case err != nil:
+			return nil, fmt.Errorf("loading cassette: %w", err)
+		default:
+			r.cassette = cassette
+		}
+	}
+	r.replayed = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+// ForTest creates a recorder for a test and saves any new interactions when the test completes.
+func ForTest(t testing.TB, path string, opts ...Option) *Recorder {
+	t.Helper()
+
+	r, err := New(path, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := r.Save(); err != nil {
+			t.Error(err)
+		}
+	})
+	return r
+}
+
+// Client returns an http.Client using the recorder, to be given to moov.WithHttpClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the interactions in the cassette.
+func (r *Recorder) Interactions() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*Interaction(nil), r.cassette.Interactions...)
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	normalized := r.scrubber.body(req.Header.Get("Content-Type"), body)
+
+	if r.mode != ModeRecord {
+		if resp, ok := r.replay(req, normalized); ok {
+			return resp, nil
+		}
+		if r.mode == ModeReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+		}
+	}
+
+	return r.record(req, body)
+}
+
+// replay returns the response of the first matching interaction that wasn't replayed yet, so
+// the same request made twice gets the responses in the order they were recorded.
+func (r *Recorder) replay(req *http.Request, body string) (*http.Response, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || !r.matcher(req, body, interaction.Request) {
+			continue
+		}
+		r.replayed[i] = true
+
+		return interaction.Response.toHTTP(req), true
+	}
+	return nil, false
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	started := r.now()
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		RecordedAt: started.UTC(),
+		Duration:   r.now().Sub(started),
+		Request:    r.scrubber.request(req, body),
+		Response:   r.scrubber.response(resp, respBody),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.replayed = append(r.replayed, true)
+	r.modified = true
+
+	return resp, nil
+}
+
+// Save writes the cassette if any interactions were recorded.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.modified {
+		return nil
+	}
+	if err := saveCassette(r.path, r.cassette); err != nil {
+		return fmt.Errorf("saving cassette: %w", err)
+	}
+	r.modified = false
+	return nil
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func defaultMatcher(req *http.Request, body string, recorded Request) bool {
+	return req.Method == recorded.Method &&
+		req.URL.Path == recorded.Path &&
+		req.URL.Query().Encode() == recorded.Query &&
+		body == recorded.Body.Text
+}
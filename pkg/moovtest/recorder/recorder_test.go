@@ -0,0 +1,173 @@
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+func newServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Path {
+		case "/accounts/a1":
+			var patch moov.PatchAccount
+			if r.Method == http.MethodPatch {
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(moov.Account{
+				AccountID:   "a1",
+				DisplayName: "Jules Jackson",
+				Profile: moov.Profile{Individual: &moov.Individual{
+					Name:  moov.Name{FirstName: "Jules", LastName: "Jackson"},
+					Email: "jules@example.com",
+				}},
+			})
+		case "/accounts/a1/statements/s1":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte{0x25, 0x50, 0x44, 0x46, 0xff, 0xfe})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newMoovClient(t *testing.T, host string, rec *Recorder) *moov.Client {
+	mc, err := moov.NewClient(
+		moov.WithCredentials(moov.Credentials{PublicKey: "pk", SecretKey: "super-secret", Host: host}),
+		moov.WithMoovURLScheme("http"),
+		moov.WithHttpClient(rec.Client()),
+	)
+	require.NoError(t, err)
+	return mc
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	srv := newServer(t, &calls)
+	host := strings.TrimPrefix(srv.URL, "http://")
+	path := filepath.Join(t.TempDir(), "testdata", "account.json")
+
+	patch := moov.PatchAccount{Profile: moov.PatchProfile{Individual: &moov.PatchIndividualProfile{Email: "jules@example.com"}}}
+
+	// record
+	rec, err := New(path)
+	require.NoError(t, err)
+	mc := newMoovClient(t, host, rec)
+
+	recorded, err := mc.GetAccount(ctx, "a1")
+	require.NoError(t, err)
+	_, err = mc.PatchAccount(ctx, "a1", patch)
+	require.NoError(t, err)
+	recordedPDF, err := mc.GetStatementPDF(ctx, "a1", "s1")
+	require.NoError(t, err)
+	require.NoError(t, rec.Save())
+	require.Equal(t, int32(3), calls.Load())
+
+	t.Run("scrubs secrets and PII", func(t *testing.T) {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		require.NotContains(t, string(data), "Basic ")
+		require.NotContains(t, string(data), "jules@example.com")
+		require.Contains(t, string(data), "[SCRUBBED]")
+	})
+
+	t.Run("replays offline", func(t *testing.T) {
+		rec, err := New(path, WithMode(ModeReplay))
+		require.NoError(t, err)
+		mc := newMoovClient(t, "moov.invalid", rec)
+
+		account, err := mc.GetAccount(ctx, "a1")
+		require.NoError(t, err)
+		require.Equal(t, recorded.DisplayName, account.DisplayName)
+		require.Equal(t, "[SCRUBBED]", account.Profile.Individual.Email)
+
+		_, err = mc.PatchAccount(ctx, "a1", patch)
+		require.NoError(t, err)
+
+		pdf, err := mc.GetStatementPDF(ctx, "a1", "s1")
+		require.NoError(t, err)
+		require.Equal(t, recordedPDF, pdf)
+
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("fails on unrecorded requests in replay mode", func(t *testing.T) {
+		rec, err := New(path, WithMode(ModeReplay))
+		require.NoError(t, err)
+		mc := newMoovClient(t, "moov.invalid", rec)
+
+		_, err = mc.GetAccount(ctx, "a2")
+		require.ErrorIs(t, err, ErrNoInteraction)
+
+		_, err = mc.PatchAccount(ctx, "a1", moov.PatchAccount{ForeignID: "different"})
+		require.ErrorIs(t, err, ErrNoInteraction)
+	})
+
+	t.Run("replays once per recording", func(t *testing.T) {
+		rec, err := New(path, WithMode(ModeReplay))
+		require.NoError(t, err)
+		mc := newMoovClient(t, "moov.invalid", rec)
+
+		_, err = mc.GetAccount(ctx, "a1")
+		require.NoError(t, err)
+		_, err = mc.GetAccount(ctx, "a1")
+		require.ErrorIs(t, err, ErrNoInteraction)
+	})
+
+	t.Run("exports HAR", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		require.NoError(t, rec.WriteHAR(&buf))
+
+		var har struct {
+			Log struct {
+				Version string `json:"version"`
+				Entries []struct {
+					Request struct {
+						Method string `json:"method"`
+						URL    string `json:"url"`
+					} `json:"request"`
+					Response struct {
+						Status  int `json:"status"`
+						Content struct {
+							Encoding string `json:"encoding"`
+						} `json:"content"`
+					} `json:"response"`
+				} `json:"entries"`
+			} `json:"log"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &har))
+		require.Equal(t, "1.2", har.Log.Version)
+		require.Len(t, har.Log.Entries, 3)
+		require.Equal(t, http.MethodPatch, har.Log.Entries[1].Request.Method)
+		require.Equal(t, srv.URL+"/accounts/a1", har.Log.Entries[1].Request.URL)
+		require.Equal(t, "base64", har.Log.Entries[2].Response.Content.Encoding)
+	})
+}
+
+func TestScrubber(t *testing.T) {
+	s := newScrubber()
+
+	got := s.body("application/json", []byte(`{"b":1,"a":{"ssn":"123-45-6789","birthDate":{"day":1,"month":2,"year":1990}},"phone":{"number":"8185551212"}}`))
+	require.Equal(t, `{"a":{"birthDate":{"day":0,"month":0,"year":0},"ssn":"[SCRUBBED]"},"b":1,"phone":{"number":"[SCRUBBED]"}}`, got)
+
+	h := s.header(http.Header{"Authorization": {"Bearer abc"}, "X-Request-Id": {"req-1"}})
+	require.Equal(t, "[SCRUBBED]", h.Get("Authorization"))
+	require.Equal(t, "req-1", h.Get("X-Request-Id"))
+}
@@ -0,0 +1,343 @@
+// Package moovtest runs an in-memory fake of the Moov API so code using moov.Client can be
+// tested without sandbox credentials or network access.
+//
+//	srv := moovtest.NewServer()
+//	defer srv.Close()
+//
+//	mc, err := moov.NewClient(
+//		moov.WithCredentials(srv.Credentials()),
+//		moov.WithMoovURLScheme("http"),
+//	)
+//
+// The server covers accounts, capabilities, bank accounts, cards, payment methods, wallets,
+// transfers, refunds, cancellations, sweeps and webhooks. Its state stays consistent across
+// calls, e.g. a completed wallet-to-wallet transfer moves both wallet balances. Time is faked,
+// Advance moves it forward and settles whatever became due. Failures such as ACH returns,
+// card declines and rate limiting are injected with the methods on Server.
+package moovtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+const (
+	publicKey = "moovtest-public-key"
+	secretKey = "moovtest-secret-key"
+
+	defaultSettlementDelay = 24 * time.Hour
+)
+
+type Option func(s *Server)
+
+// WithClock sets the time the fake clock starts at, the current time by default.
+func WithClock(start time.Time) Option {
+	return func(s *Server) {
+		s.now = start
+	}
+}
+
+// WithSettlementDelay sets how long ACH and card transfers stay pending before they settle, 24h by default.
+func WithSettlementDelay(d time.Duration) Option {
+	return func(s *Server) {
+		s.settlementDelay = d
+	}
+}
+
+// Server is a fake Moov API running on an httptest.Server.
+type Server struct {
+	srv           *httptest.Server
+	mux           *http.ServeMux
+	webhookClient *http.Client
+
+	mu              sync.Mutex
+	now             time.Time
+	settlementDelay time.Duration
+
+	accounts       *store[*account]
+	bankAccounts   *store[*bankAccount]
+	cards          *store[*card]
+	paymentMethods *store[*paymentMethod]
+	wallets        *store[*wallet]
+	transfers      *store[*transfer]
+	sweepConfigs   *store[*sweepConfig]
+	webhooks       *store[*webhook]
+	idempotency    map[string]idempotentCall
+
+	failures failures
+	events   []Event
+	outbox   []Event
+}
+
+// NewServer starts a fake Moov API server. Close it when the test completes.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		mux:             http.NewServeMux(),
+		webhookClient:   &http.Client{Timeout: 5 * time.Second},
+		now:             time.Now().UTC(),
+		settlementDelay: defaultSettlementDelay,
+		accounts:        newStore[*account](),
+		bankAccounts:    newStore[*bankAccount](),
+		cards:           newStore[*card](),
+		paymentMethods:  newStore[*paymentMethod](),
+		wallets:         newStore[*wallet](),
+		transfers:       newStore[*transfer](),
+		sweepConfigs:    newStore[*sweepConfig](),
+		webhooks:        newStore[*webhook](),
+		idempotency:     map[string]idempotentCall{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.routes()
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /ping", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	s.mux.HandleFunc("POST /oauth2/token", s.createToken)
+	s.mux.HandleFunc("POST /oauth2/revoke", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.accountRoutes()
+	s.sourceRoutes()
+	s.walletRoutes()
+	s.transferRoutes()
+	s.sweepRoutes()
+	s.webhookRoutes()
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// URL returns the base URL of the server, e.g. http://127.0.0.1:51234.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Host returns the host and port of the server, to be used as moov.Credentials.Host.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.srv.URL, "http://")
+}
+
+// Credentials returns credentials for a client calling the server.
+func (s *Server) Credentials() moov.Credentials {
+	return moov.Credentials{
+		PublicKey: publicKey,
+		SecretKey: secretKey,
+		Host:      s.Host(),
+	}
+}
+
+// Client creates a moov.Client calling the server. Any options are applied after the ones
+// pointing the client at the server.
+func (s *Server) Client(opts ...moov.ClientConfigurable) (*moov.Client, error) {
+	return moov.NewClient(append([]moov.ClientConfigurable{
+		moov.WithCredentials(s.Credentials()),
+		moov.WithMoovURLScheme("http"),
+	}, opts...)...)
+}
+
+// Now returns the current time of the fake clock.
+func (s *Server) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.now
+}
+
+// Advance moves the fake clock forward, settling transfers and closing sweeps that became due.
+// Webhooks for the resulting events are delivered before it returns.
+func (s *Server) Advance(d time.Duration) {
+	s.mu.Lock()
+	s.now = s.now.Add(d)
+	s.settleTransfers()
+	s.closeSweeps()
+	s.mu.Unlock()
+
+	s.deliverOutbox()
+}
+
+// Events returns every event the server raised, in order, whether or not a webhook was
+// subscribed to it.
+func (s *Server) Events() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Event(nil), s.events...)
+}
+
+// serveHTTP buffers the response of the handler so webhooks for the events it raised are
+// delivered before the client sees the response.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if status, ok := s.injectedFailure(); ok {
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		writeError(w, status, "injected failure")
+		return
+	}
+
+	if r.URL.Path != "/ping" && r.Header.Get("Authorization") == "" {
+		writeError(w, http.StatusUnauthorized, "missing credentials")
+		return
+	}
+
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, r)
+
+	s.deliverOutbox()
+
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+	w.Header().Set("X-Request-ID", uuid.NewString())
+	w.WriteHeader(rec.Code)
+	w.Write(rec.Body.Bytes())
+}
+
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Scope string `json:"scope"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, moov.AccessTokenResponse{
+		AccessToken:  "moovtest-" + uuid.NewString(),
+		RefreshToken: "moovtest-" + uuid.NewString(),
+		TokenType:    "Bearer",
+		ExpiresIn:    3600,
+		Scope:        req.Scope,
+	})
+}
+
+// emit records an event and queues it for delivery to subscribed webhooks. Must be called
+// with the lock held.
+func (s *Server) emit(eventType moov.EventType, data any) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		panic(fmt.Sprintf("moovtest: encoding %s event: %v", eventType, err))
+	}
+
+	event := Event{
+		EventID:   uuid.NewString(),
+		EventType: eventType,
+		CreatedOn: s.now,
+		Data:      raw,
+	}
+	s.events = append(s.events, event)
+	s.outbox = append(s.outbox, event)
+}
+
+// Event is an event raised by the server, in the format webhooks receive it.
+type Event struct {
+	EventID   string          `json:"eventID"`
+	EventType moov.EventType  `json:"type"`
+	CreatedOn time.Time       `json:"createdOn"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError responds with the error body the Moov API uses.
+func writeError(w http.ResponseWriter, status int, msg string, args ...any) {
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf(msg, args...)})
+}
+
+// decode reads a JSON request body into v, responding with 400 if it can't.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	buf := bytes.Buffer{}
+	if _, err := buf.ReadFrom(r.Body); err != nil {
+		writeError(w, http.StatusBadRequest, "reading body: %v", err)
+		return false
+	}
+	if buf.Len() == 0 {
+		return true
+	}
+	if err := json.Unmarshal(buf.Bytes(), v); err != nil {
+		writeError(w, http.StatusBadRequest, "decoding body: %v", err)
+		return false
+	}
+	return true
+}
+
+// page applies the skip and count query params of list endpoints.
+func page[T any](r *http.Request, items []T) []T {
+	if skip, err := strconv.Atoi(r.URL.Query().Get("skip")); err == nil && skip > 0 {
+		items = items[min(skip, len(items)):]
+	}
+	if count, err := strconv.Atoi(r.URL.Query().Get("count")); err == nil && count >= 0 {
+		items = items[:min(count, len(items))]
+	}
+	return items
+}
+
+// store keeps resources by ID in the order they were created.
+type store[T any] struct {
+	ids   []string
+	items map[string]T
+}
+
+func newStore[T any]() *store[T] {
+	return &store[T]{items: map[string]T{}}
+}
+
+func (s *store[T]) put(id string, v T) {
+	if _, ok := s.items[id]; !ok {
+		s.ids = append(s.ids, id)
+	}
+	s.items[id] = v
+}
+
+func (s *store[T]) get(id string) (T, bool) {
+	v, ok := s.items[id]
+	return v, ok
+}
+
+func (s *store[T]) delete(id string) {
+	if _, ok := s.items[id]; !ok {
+		return
+	}
+	delete(s.items, id)
+	for i, existing := range s.ids {
+		if existing == id {
+			s.ids = append(s.ids[:i], s.ids[i+1:]...)
+			break
+		}
+	}
+}
+
+// list returns the resources matching keep, in the order they were created.
+func (s *store[T]) list(keep func(T) bool) []T {
+	out := []T{}
+	for _, id := range s.ids {
+		if v := s.items[id]; keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,285 @@
+package moovtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+func TestServer_CardToWalletToWallet(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	mc := newClient(t, srv)
+	ctx := context.Background()
+
+	alice, aliceWallet := createAccount(t, mc, "Alice")
+	bob, bobWallet := createAccount(t, mc, "Bob")
+
+	card, err := mc.CreateCard(ctx, alice, moov.CreateCard{
+		CardNumber: "4111111111111111",
+		CardCvv:    "123",
+		Expiration: moov.Expiration{Month: "01", Year: "30"},
+		HolderName: "Alice",
+	})
+	require.NoError(t, err)
+	pullFromCard := paymentMethodID(t, mc, alice, card.CardID, moov.PaymentMethodType_CardPayment)
+
+	transfer, _, err := mc.CreateTransfer(ctx, alice, moov.CreateTransfer{
+		Source:      moov.CreateTransfer_Source{PaymentMethodID: pullFromCard},
+		Destination: moov.CreateTransfer_Destination{PaymentMethodID: aliceWallet},
+		Amount:      moov.Amount{Currency: "USD", Value: 10_00},
+	}).WaitForRailResponse()
+	require.NoError(t, err)
+	require.Equal(t, moov.TransferStatus_Pending, transfer.Status)
+	require.Equal(t, "0.00", balance(t, mc, alice))
+
+	srv.Advance(24 * time.Hour)
+
+	transfer, err = mc.GetTransfer(ctx, alice, transfer.TransferID)
+	require.NoError(t, err)
+	require.Equal(t, moov.TransferStatus_Completed, transfer.Status)
+	require.Equal(t, "10.00", balance(t, mc, alice))
+
+	started, err := mc.CreateTransfer(ctx, alice, moov.CreateTransfer{
+		Source:      moov.CreateTransfer_Source{PaymentMethodID: aliceWallet},
+		Destination: moov.CreateTransfer_Destination{PaymentMethodID: bobWallet},
+		Amount:      moov.Amount{Currency: "USD", Value: 2_50},
+	}).Started()
+	require.NoError(t, err)
+
+	transfer, err = mc.GetTransfer(ctx, alice, started.TransferID)
+	require.NoError(t, err)
+	require.Equal(t, moov.TransferStatus_Completed, transfer.Status)
+	require.Equal(t, "7.50", balance(t, mc, alice))
+	require.Equal(t, "2.50", balance(t, mc, bob))
+
+	_, _, err = mc.CreateTransfer(ctx, alice, moov.CreateTransfer{
+		Source:      moov.CreateTransfer_Source{PaymentMethodID: aliceWallet},
+		Destination: moov.CreateTransfer_Destination{PaymentMethodID: bobWallet},
+		Amount:      moov.Amount{Currency: "USD", Value: 100_00},
+	}).WaitForRailResponse()
+	require.NoError(t, err)
+	require.Equal(t, "7.50", balance(t, mc, alice))
+}
+
+func TestServer_DeclineCard(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	mc := newClient(t, srv)
+	ctx := context.Background()
+
+	alice, aliceWallet := createAccount(t, mc, "Alice")
+	card, err := mc.CreateCard(ctx, alice, moov.CreateCard{
+		CardNumber: "5555555555554444",
+		Expiration: moov.Expiration{Month: "01", Year: "30"},
+	})
+	require.NoError(t, err)
+
+	srv.DeclineCard(card.CardID, moov.CardFailureCode_DoNotHonor)
+
+	transfer, _, err := mc.CreateTransfer(ctx, alice, moov.CreateTransfer{
+		Source:      moov.CreateTransfer_Source{PaymentMethodID: paymentMethodID(t, mc, alice, card.CardID, moov.PaymentMethodType_CardPayment)},
+		Destination: moov.CreateTransfer_Destination{PaymentMethodID: aliceWallet},
+		Amount:      moov.Amount{Currency: "USD", Value: 10_00},
+	}).WaitForRailResponse()
+	require.NoError(t, err)
+	require.Equal(t, moov.TransferStatus_Failed, transfer.Status)
+	require.Equal(t, moov.FailureReason_Source_Payment_Error, *transfer.FailureReason)
+}
+
+func TestServer_MicroDepositsAndACHReturn(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	mc := newClient(t, srv)
+	ctx := context.Background()
+
+	alice, aliceWallet := createAccount(t, mc, "Alice")
+	bank, err := mc.CreateBankAccount(ctx, alice, moov.WithBankAccount(moov.BankAccountRequest{
+		RoutingNumber: "273976369",
+		AccountNumber: "123456789",
+		AccountType:   moov.BankAccountType_Checking,
+		HolderName:    "Alice",
+		HolderType:    moov.HolderType_Individual,
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, mc.MicroDepositInitiate(ctx, alice, bank.BankAccountID))
+	err = mc.MicroDepositConfirm(ctx, alice, bank.BankAccountID, []int{1, 2})
+	require.ErrorIs(t, err, moov.ErrMicroDepositAmountsIncorrect)
+	require.NoError(t, mc.MicroDepositConfirm(ctx, alice, bank.BankAccountID, MicroDeposits))
+
+	srv.ReturnACH(bank.BankAccountID, moov.AchReturnCode_R02)
+
+	started, err := mc.CreateTransfer(ctx, alice, moov.CreateTransfer{
+		Source:      moov.CreateTransfer_Source{PaymentMethodID: paymentMethodID(t, mc, alice, bank.BankAccountID, moov.PaymentMethodType_AchDebitFund)},
+		Destination: moov.CreateTransfer_Destination{PaymentMethodID: aliceWallet},
+		Amount:      moov.Amount{Currency: "USD", Value: 10_00},
+	}).Started()
+	require.NoError(t, err)
+
+	srv.Advance(24 * time.Hour)
+
+	transfer, err := mc.GetTransfer(ctx, alice, started.TransferID)
+	require.NoError(t, err)
+	require.Equal(t, moov.TransferStatus_Failed, transfer.Status)
+	require.Equal(t, "0.00", balance(t, mc, alice))
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	mc := newClient(t, srv)
+	srv.RateLimit(1)
+	_, err := mc.ListAccounts(ctx)
+	require.ErrorIs(t, err, moov.ErrRateLimited)
+
+	mc = newClient(t, srv, moov.WithRetryPolicy(moov.RetryPolicy{InitialBackoff: time.Millisecond}))
+	srv.RateLimit(2)
+	_, err = mc.ListAccounts(ctx)
+	require.NoError(t, err)
+}
+
+func TestServer_RefundAndCancel(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	mc := newClient(t, srv)
+	ctx := context.Background()
+
+	alice, aliceWallet := createAccount(t, mc, "Alice")
+	card, err := mc.CreateCard(ctx, alice, moov.CreateCard{
+		CardNumber: "4111111111111111",
+		Expiration: moov.Expiration{Month: "01", Year: "30"},
+	})
+	require.NoError(t, err)
+	pullFromCard := paymentMethodID(t, mc, alice, card.CardID, moov.PaymentMethodType_CardPayment)
+
+	create := moov.CreateTransfer{
+		Source:      moov.CreateTransfer_Source{PaymentMethodID: pullFromCard},
+		Destination: moov.CreateTransfer_Destination{PaymentMethodID: aliceWallet},
+		Amount:      moov.Amount{Currency: "USD", Value: 10_00},
+	}
+
+	first, err := mc.CreateTransfer(ctx, alice, create).Started()
+	require.NoError(t, err)
+	second, err := mc.CreateTransfer(ctx, alice, create).Started()
+	require.NoError(t, err)
+
+	cancellation, err := mc.CancelTransfer(ctx, alice, second.TransferID)
+	require.NoError(t, err)
+	require.NotEmpty(t, cancellation.CancellationID)
+
+	srv.Advance(24 * time.Hour)
+	require.Equal(t, "10.00", balance(t, mc, alice))
+
+	refund, _, err := mc.RefundTransfer(ctx, alice, first.TransferID, moov.CreateRefund{Amount: 4_00})
+	require.NoError(t, err)
+	require.NotNil(t, refund)
+	require.Equal(t, "6.00", balance(t, mc, alice))
+
+	_, _, err = mc.RefundTransfer(ctx, alice, first.TransferID, moov.CreateRefund{Amount: 7_00})
+	require.Error(t, err)
+}
+
+func TestServer_Webhooks(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	mc := newClient(t, srv)
+	ctx := context.Background()
+
+	var (
+		mu     sync.Mutex
+		secret string
+		events []*mhooks.Event
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		event, err := mhooks.ParseEvent(r, secret)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		events = append(events, event)
+	}))
+	defer receiver.Close()
+
+	hook, err := mc.CreateWebhook(ctx, moov.CreateWebhook{
+		URL:        receiver.URL,
+		Status:     moov.WebhookStatusEnabled,
+		EventTypes: []moov.EventType{moov.EventTypeAccountCreated},
+	})
+	require.NoError(t, err)
+
+	whsec, err := mc.GetWebhookSecret(ctx, hook.WebhookID)
+	require.NoError(t, err)
+	mu.Lock()
+	secret = whsec.Secret
+	mu.Unlock()
+
+	alice, _ := createAccount(t, mc, "Alice")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, events, 1)
+	created, err := events[0].AccountCreated()
+	require.NoError(t, err)
+	require.Equal(t, alice, created.AccountID)
+}
+
+func newClient(t *testing.T, srv *Server, opts ...moov.ClientConfigurable) *moov.Client {
+	t.Helper()
+
+	mc, err := srv.Client(opts...)
+	require.NoError(t, err)
+	return mc
+}
+
+// createAccount returns the new account's ID along with the payment method of its default wallet.
+func createAccount(t *testing.T, mc *moov.Client, name string) (string, string) {
+	t.Helper()
+
+	account, _, err := mc.CreateAccount(context.Background(), moov.CreateAccount{
+		Type: moov.AccountType_Individual,
+		Profile: moov.CreateProfile{
+			Individual: &moov.CreateIndividualProfile{
+				Name:  moov.Name{FirstName: name, LastName: "Test"},
+				Email: name + "@example.com",
+			},
+		},
+	})
+	require.NoError(t, err)
+	return account.AccountID, paymentMethodID(t, mc, account.AccountID, "", moov.PaymentMethodType_MoovWallet)
+}
+
+func paymentMethodID(t *testing.T, mc *moov.Client, accountID, sourceID string, methodType moov.PaymentMethodType) string {
+	t.Helper()
+
+	filters := []moov.PaymentMethodListFilter{moov.WithPaymentMethodType(string(methodType))}
+	if sourceID != "" {
+		filters = append(filters, moov.WithPaymentMethodSourceID(sourceID))
+	}
+	methods, err := mc.ListPaymentMethods(context.Background(), accountID, filters...)
+	require.NoError(t, err)
+	require.NotEmpty(t, methods)
+	return methods[0].PaymentMethodID
+}
+
+func balance(t *testing.T, mc *moov.Client, accountID string) string {
+	t.Helper()
+
+	wallets, err := mc.ListWallets(context.Background(), accountID)
+	require.NoError(t, err)
+	require.Len(t, wallets, 1)
+	return wallets[0].AvailableBalance.ValueDecimal
+}
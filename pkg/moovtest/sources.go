@@ -0,0 +1,483 @@
+package moovtest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+// MicroDeposits are the amounts of the micro-deposits sent to every bank account. They're
+// always 0, like in the Moov sandbox.
+var MicroDeposits = []int{0, 0}
+
+const maxMicroDepositAttempts = 3
+
+type bankAccount struct {
+	moov.BankAccount
+	accountID string
+	attempts  int
+}
+
+type card struct {
+	moov.Card
+	accountID string
+}
+
+type paymentMethod struct {
+	id         string
+	accountID  string
+	methodType moov.PaymentMethodType
+	sourceID   string
+	disabled   bool
+}
+
+func (s *Server) sourceRoutes() {
+	s.mux.HandleFunc("POST /accounts/{accountID}/bank-accounts", s.createBankAccount)
+	s.mux.HandleFunc("GET /accounts/{accountID}/bank-accounts", s.listBankAccounts)
+	s.mux.HandleFunc("GET /accounts/{accountID}/bank-accounts/{bankAccountID}", s.getBankAccount)
+	s.mux.HandleFunc("DELETE /accounts/{accountID}/bank-accounts/{bankAccountID}", s.deleteBankAccount)
+	s.mux.HandleFunc("POST /accounts/{accountID}/bank-accounts/{bankAccountID}/micro-deposits", s.initiateMicroDeposits)
+	s.mux.HandleFunc("PUT /accounts/{accountID}/bank-accounts/{bankAccountID}/micro-deposits", s.confirmMicroDeposits)
+
+	s.mux.HandleFunc("POST /accounts/{accountID}/cards", s.createCard)
+	s.mux.HandleFunc("GET /accounts/{accountID}/cards", s.listCards)
+	s.mux.HandleFunc("GET /accounts/{accountID}/cards/{cardID}", s.getCard)
+	s.mux.HandleFunc("DELETE /accounts/{accountID}/cards/{cardID}", s.disableCard)
+
+	s.mux.HandleFunc("GET /accounts/{accountID}/payment-methods", s.listPaymentMethods)
+	s.mux.HandleFunc("GET /accounts/{accountID}/payment-methods/{paymentMethodID}", s.getPaymentMethod)
+}
+
+// Bank accounts are linked unverified, only allowing ACH credits until micro-deposits are confirmed.
+func (s *Server) createBankAccount(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Account *moov.BankAccountRequest `json:"account"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Account == nil {
+		writeError(w, http.StatusUnprocessableEntity, "only linking bank accounts by account and routing number is supported")
+		return
+	}
+	if len(req.Account.AccountNumber) < 4 || len(req.Account.RoutingNumber) != 9 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid account or routing number")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	fp := fingerprint(req.Account.RoutingNumber, req.Account.AccountNumber)
+	for _, existing := range s.bankAccounts.list(all) {
+		if existing.accountID == a.AccountID && existing.Fingerprint == fp {
+			writeError(w, http.StatusConflict, "bank account already exists")
+			return
+		}
+	}
+
+	ba := &bankAccount{
+		BankAccount: moov.BankAccount{
+			BankAccountID:         uuid.NewString(),
+			Fingerprint:           fp,
+			Status:                moov.BankAccountStatus_New,
+			StatusReason:          moov.BankAccountStatusReason_BankAccountCreated,
+			HolderName:            req.Account.HolderName,
+			HolderType:            req.Account.HolderType,
+			BankName:              "MOOVTEST BANK",
+			BankAccountType:       req.Account.AccountType,
+			RoutingNumber:         req.Account.RoutingNumber,
+			LastFourAccountNumber: req.Account.AccountNumber[len(req.Account.AccountNumber)-4:],
+			UpdatedOn:             s.now,
+		},
+		accountID: a.AccountID,
+	}
+	s.bankAccounts.put(ba.BankAccountID, ba)
+	s.emit(moov.EventTypeBankAccountCreated, mhooks.BankAccountCreated{BankAccountID: ba.BankAccountID, AccountID: a.AccountID, Status: ba.Status})
+
+	s.addPaymentMethods(a.AccountID, ba.BankAccountID, moov.PaymentMethodType_AchCreditStandard, moov.PaymentMethodType_AchCreditSameDay)
+
+	writeJSON(w, http.StatusOK, s.bankAccountView(ba))
+}
+
+func (s *Server) listBankAccounts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	out := []moov.BankAccount{}
+	for _, ba := range s.bankAccounts.list(func(ba *bankAccount) bool { return ba.accountID == a.AccountID }) {
+		out = append(out, s.bankAccountView(ba))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getBankAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ba, ok := s.lookupBankAccount(w, r); ok {
+		writeJSON(w, http.StatusOK, s.bankAccountView(ba))
+	}
+}
+
+func (s *Server) deleteBankAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ba, ok := s.lookupBankAccount(w, r)
+	if !ok {
+		return
+	}
+
+	s.bankAccounts.delete(ba.BankAccountID)
+	s.disablePaymentMethods(ba.BankAccountID)
+	s.emit(moov.EventTypeBankAccountDeleted, mhooks.BankAccountDeleted{BankAccountID: ba.BankAccountID, AccountID: ba.accountID})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) initiateMicroDeposits(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ba, ok := s.lookupBankAccount(w, r)
+	if !ok {
+		return
+	}
+	if ba.Status != moov.BankAccountStatus_New {
+		writeError(w, http.StatusConflict, "bank account is %s", ba.Status)
+		return
+	}
+
+	s.updateBankAccount(ba, moov.BankAccountStatus_Pending, moov.BankAccountStatusReason_VerificationInitiated)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// confirmMicroDeposits verifies the bank account when the amounts match MicroDeposits. After
+// too many wrong attempts the verification fails for good.
+func (s *Server) confirmMicroDeposits(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Amounts []int `json:"amounts"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ba, ok := s.lookupBankAccount(w, r)
+	if !ok {
+		return
+	}
+	if ba.Status != moov.BankAccountStatus_Pending {
+		writeError(w, http.StatusConflict, "bank account is %s", ba.Status)
+		return
+	}
+
+	if !slices.Equal(req.Amounts, MicroDeposits) {
+		ba.attempts++
+		if ba.attempts >= maxMicroDepositAttempts {
+			s.updateBankAccount(ba, moov.BankAccountStatus_VerificationFailed, moov.BankAccountStatusReason_MicroDepositAttemptsExceeded)
+		}
+		writeError(w, http.StatusConflict, "micro-deposit amounts don't match")
+		return
+	}
+
+	s.updateBankAccount(ba, moov.BankAccountStatus_Verified, moov.BankAccountStatusReason_VerificationSuccessful)
+	s.addPaymentMethods(ba.accountID, ba.BankAccountID, moov.PaymentMethodType_AchDebitFund, moov.PaymentMethodType_AchDebitCollect)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// updateBankAccount must be called with the lock held.
+func (s *Server) updateBankAccount(ba *bankAccount, status moov.BankAccountStatus, reason moov.BankAccountStatusReason) {
+	ba.Status, ba.StatusReason, ba.UpdatedOn = status, reason, s.now
+	s.emit(moov.EventTypeBankAccountUpdated, mhooks.BankAccountUpdated{
+		BankAccountID: ba.BankAccountID,
+		AccountID:     ba.accountID,
+		Status:        ba.Status,
+		StatusReason:  ba.StatusReason,
+	})
+}
+
+func (s *Server) lookupBankAccount(w http.ResponseWriter, r *http.Request) (*bankAccount, bool) {
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	ba, ok := s.bankAccounts.get(r.PathValue("bankAccountID"))
+	if !ok || ba.accountID != a.AccountID {
+		writeError(w, http.StatusNotFound, "bank account not found")
+		return nil, false
+	}
+	return ba, true
+}
+
+func (s *Server) bankAccountView(ba *bankAccount) moov.BankAccount {
+	out := ba.BankAccount
+	out.PaymentMethods = []moov.BasicPaymentMethod{}
+	for _, pm := range s.sourcePaymentMethods(ba.BankAccountID) {
+		out.PaymentMethods = append(out.PaymentMethods, moov.BasicPaymentMethod{PaymentMethodID: pm.id, PaymentMethodType: pm.methodType})
+	}
+	return out
+}
+
+// Cards can be used to pull and push funds as soon as they're linked.
+func (s *Server) createCard(w http.ResponseWriter, r *http.Request) {
+	var create moov.CreateCard
+	if !decode(w, r, &create) {
+		return
+	}
+	if len(create.CardNumber) < 12 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid card number")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	fp := fingerprint(create.CardNumber)
+	for _, existing := range s.cards.list(all) {
+		if existing.accountID == a.AccountID && existing.Fingerprint == fp {
+			writeError(w, http.StatusConflict, "card already exists")
+			return
+		}
+	}
+
+	c := &card{
+		Card: moov.Card{
+			CardID:             uuid.NewString(),
+			Fingerprint:        fp,
+			Brand:              string(cardBrand(create.CardNumber)),
+			CardType:           string(moov.CardType_Dedit),
+			LastFourCardNumber: create.CardNumber[len(create.CardNumber)-4:],
+			Bin:                create.CardNumber[:6],
+			Expiration:         create.Expiration,
+			HolderName:         create.HolderName,
+			BillingAddress:     create.BillingAddress,
+			CardOnFile:         create.CardOnFile,
+			MerchantAccountID:  create.MerchantAccountID,
+			Issuer:             "MOOVTEST BANK",
+			IssuerCountry:      "US",
+		},
+		accountID: a.AccountID,
+	}
+	s.cards.put(c.CardID, c)
+
+	s.addPaymentMethods(a.AccountID, c.CardID, moov.PaymentMethodType_CardPayment, moov.PaymentMethodType_PullFromCard, moov.PaymentMethodType_PushToCard)
+
+	writeJSON(w, http.StatusOK, s.cardView(c))
+}
+
+func (s *Server) listCards(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	out := []moov.Card{}
+	for _, c := range s.cards.list(func(c *card) bool { return c.accountID == a.AccountID }) {
+		out = append(out, s.cardView(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getCard(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.lookupCard(w, r); ok {
+		writeJSON(w, http.StatusOK, s.cardView(c))
+	}
+}
+
+func (s *Server) disableCard(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.lookupCard(w, r)
+	if !ok {
+		return
+	}
+
+	s.cards.delete(c.CardID)
+	s.disablePaymentMethods(c.CardID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) lookupCard(w http.ResponseWriter, r *http.Request) (*card, bool) {
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	c, ok := s.cards.get(r.PathValue("cardID"))
+	if !ok || c.accountID != a.AccountID {
+		writeError(w, http.StatusNotFound, "card not found")
+		return nil, false
+	}
+	return c, true
+}
+
+func (s *Server) cardView(c *card) moov.Card {
+	out := c.Card
+	out.PaymentMethods = []moov.PaymentMethod{}
+	for _, pm := range s.sourcePaymentMethods(c.CardID) {
+		out.PaymentMethods = append(out.PaymentMethods, moov.PaymentMethod{PaymentMethodID: pm.id, PaymentMethodType: pm.methodType})
+	}
+	return out
+}
+
+func (s *Server) listPaymentMethods(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	out := []moov.PaymentMethod{}
+	for _, pm := range s.paymentMethods.list(func(pm *paymentMethod) bool {
+		switch {
+		case pm.accountID != a.AccountID || pm.disabled:
+			return false
+		case q.Has("sourceID") && pm.sourceID != q.Get("sourceID"):
+			return false
+		case q.Has("paymentMethodType") && string(pm.methodType) != q.Get("paymentMethodType"):
+			return false
+		}
+		return true
+	}) {
+		out = append(out, s.paymentMethodView(pm))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	pm, ok := s.paymentMethods.get(r.PathValue("paymentMethodID"))
+	if !ok || pm.accountID != a.AccountID || pm.disabled {
+		writeError(w, http.StatusNotFound, "payment method not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.paymentMethodView(pm))
+}
+
+// addPaymentMethods must be called with the lock held.
+func (s *Server) addPaymentMethods(accountID, sourceID string, types ...moov.PaymentMethodType) {
+	for _, t := range types {
+		pm := &paymentMethod{
+			id:         uuid.NewString(),
+			accountID:  accountID,
+			methodType: t,
+			sourceID:   sourceID,
+		}
+		s.paymentMethods.put(pm.id, pm)
+		s.emit(moov.EventTypePaymentMethodEnabled, mhooks.PaymentMethodEnabled{PaymentMethodID: pm.id, AccountID: accountID, SourceID: sourceID})
+	}
+}
+
+// disablePaymentMethods must be called with the lock held.
+func (s *Server) disablePaymentMethods(sourceID string) {
+	for _, pm := range s.sourcePaymentMethods(sourceID) {
+		pm.disabled = true
+		s.emit(moov.EventTypePaymentMethodDisabled, mhooks.PaymentMethodDisabled{PaymentMethodID: pm.id, AccountID: pm.accountID, SourceID: sourceID})
+	}
+}
+
+func (s *Server) sourcePaymentMethods(sourceID string) []*paymentMethod {
+	return s.paymentMethods.list(func(pm *paymentMethod) bool {
+		return pm.sourceID == sourceID && !pm.disabled
+	})
+}
+
+// paymentMethodView fills in the current state of the source of the payment method.
+func (s *Server) paymentMethodView(pm *paymentMethod) moov.PaymentMethod {
+	out := moov.PaymentMethod{PaymentMethodID: pm.id, PaymentMethodType: pm.methodType}
+
+	if ba, ok := s.bankAccounts.get(pm.sourceID); ok {
+		view := moov.BankAccountPaymentMethod(ba.BankAccount)
+		out.BankAccount = &view
+	}
+	if c, ok := s.cards.get(pm.sourceID); ok {
+		out.Card = &moov.CardPaymentMethod{
+			CardID:             c.CardID,
+			Fingerprint:        c.Fingerprint,
+			Brand:              moov.CardBrand(c.Brand),
+			CardType:           moov.CardType(c.CardType),
+			LastFourCardNumber: c.LastFourCardNumber,
+			Bin:                c.Bin,
+			Expiration:         moov.CardExpiration{Month: c.Expiration.Month, Year: c.Expiration.Year},
+			HolderName:         c.HolderName,
+			Issuer:             c.Issuer,
+			IssuerCountry:      c.IssuerCountry,
+		}
+	}
+	if wa, ok := s.wallets.get(pm.sourceID); ok {
+		out.Wallet = &moov.WalletPaymentMethod{
+			WalletID:         wa.WalletID,
+			PartnerAccountID: wa.PartnerAccountID,
+			WalletType:       wa.WalletType,
+		}
+	}
+	return out
+}
+
+func fingerprint(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func cardBrand(number string) moov.CardBrand {
+	switch number[0] {
+	case '3':
+		return moov.CardBrand_AmericanExpress
+	case '5', '2':
+		return moov.CardBrand_Mastercard
+	case '6':
+		return moov.CardBrand_Discover
+	}
+	return moov.CardBrand_Visa
+}
@@ -0,0 +1,381 @@
+package moovtest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+// Sweeps accrue for a day before the wallet balance above the minimum is pushed out, or the
+// shortfall below it pulled in.
+const sweepAccrualPeriod = 24 * time.Hour
+
+type sweepConfig struct {
+	moov.SweepConfig
+	accountID string
+	sweeps    *store[*moov.Sweep]
+}
+
+func (s *Server) sweepRoutes() {
+	s.mux.HandleFunc("POST /accounts/{accountID}/sweep-configs", s.createSweepConfig)
+	s.mux.HandleFunc("GET /accounts/{accountID}/sweep-configs", s.listSweepConfigs)
+	s.mux.HandleFunc("GET /accounts/{accountID}/sweep-configs/{sweepConfigID}", s.getSweepConfig)
+	s.mux.HandleFunc("PATCH /accounts/{accountID}/sweep-configs/{sweepConfigID}", s.updateSweepConfig)
+
+	s.mux.HandleFunc("GET /accounts/{accountID}/wallets/{walletID}/sweeps", s.listSweeps)
+	s.mux.HandleFunc("GET /accounts/{accountID}/wallets/{walletID}/sweeps/{sweepID}", s.getSweep)
+}
+
+func (s *Server) createSweepConfig(w http.ResponseWriter, r *http.Request) {
+	var create moov.CreateSweepConfig
+	if !decode(w, r, &create) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	wa, ok := s.wallets.get(create.WalletID)
+	if !ok || wa.PartnerAccountID != a.AccountID {
+		writeError(w, http.StatusUnprocessableEntity, "wallet not found")
+		return
+	}
+	for _, existing := range s.sweepConfigs.list(all) {
+		if existing.WalletID == wa.WalletID {
+			writeError(w, http.StatusConflict, "wallet already has a sweep config")
+			return
+		}
+	}
+	if !s.validSweepPaymentMethods(w, a.AccountID, create.PushPaymentMethodID, create.PullPaymentMethodID) {
+		return
+	}
+	if _, ok := parseDecimal(create.MinimumBalance); !ok {
+		writeError(w, http.StatusUnprocessableEntity, "invalid minimum balance")
+		return
+	}
+
+	sc := &sweepConfig{
+		SweepConfig: moov.SweepConfig{
+			SweepConfigID:       uuid.NewString(),
+			WalletID:            wa.WalletID,
+			Status:              create.Status,
+			PushPaymentMethod:   moov.SweepConfigPaymentMethod{PaymentMethodID: create.PushPaymentMethodID},
+			PullPaymentMethod:   moov.SweepConfigPaymentMethod{PaymentMethodID: create.PullPaymentMethodID},
+			MinimumBalance:      create.MinimumBalance,
+			StatementDescriptor: create.StatementDescriptor,
+			CreatedOn:           s.now,
+			UpdatedOn:           s.now,
+		},
+		accountID: a.AccountID,
+		sweeps:    newStore[*moov.Sweep](),
+	}
+	if sc.Status == "" {
+		sc.Status = moov.SweepConfigStatus_Enabled
+	}
+	s.sweepConfigs.put(sc.SweepConfigID, sc)
+	s.startAccrual(sc, s.now)
+
+	writeJSON(w, http.StatusOK, sc.SweepConfig)
+}
+
+func (s *Server) listSweepConfigs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	out := []moov.SweepConfig{}
+	for _, sc := range s.sweepConfigs.list(func(sc *sweepConfig) bool { return sc.accountID == a.AccountID }) {
+		out = append(out, sc.SweepConfig)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getSweepConfig(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sc, ok := s.lookupSweepConfig(w, r); ok {
+		writeJSON(w, http.StatusOK, sc.SweepConfig)
+	}
+}
+
+// updateSweepConfig cancels the accruing sweep when the config is disabled and starts a new one
+// when it's enabled again.
+func (s *Server) updateSweepConfig(w http.ResponseWriter, r *http.Request) {
+	var update moov.UpdateSweepConfig
+	if !decode(w, r, &update) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sc, ok := s.lookupSweepConfig(w, r)
+	if !ok {
+		return
+	}
+
+	push, pull := sc.PushPaymentMethod.PaymentMethodID, sc.PullPaymentMethod.PaymentMethodID
+	if update.PushPaymentMethodID != nil {
+		push = *update.PushPaymentMethodID
+	}
+	if update.PullPaymentMethodID != nil {
+		pull = *update.PullPaymentMethodID
+	}
+	if !s.validSweepPaymentMethods(w, sc.accountID, push, pull) {
+		return
+	}
+	if _, ok := parseDecimal(update.MinimumBalance); update.MinimumBalance != nil && !ok {
+		writeError(w, http.StatusUnprocessableEntity, "invalid minimum balance")
+		return
+	}
+
+	sc.PushPaymentMethod.PaymentMethodID, sc.PullPaymentMethod.PaymentMethodID = push, pull
+	if update.MinimumBalance != nil {
+		sc.MinimumBalance = update.MinimumBalance
+	}
+	if update.StatementDescriptor != nil {
+		sc.StatementDescriptor = update.StatementDescriptor
+	}
+	sc.UpdatedOn = s.now
+
+	if update.Status != nil && *update.Status != sc.Status {
+		sc.Status = *update.Status
+		switch sc.Status {
+		case moov.SweepConfigStatus_Disabled:
+			now := s.now
+			sc.DisabledOn = &now
+			if sw := sc.accruing(); sw != nil {
+				s.updateSweep(sc, sw, moov.SweepStatus_Canceled)
+			}
+		case moov.SweepConfigStatus_Enabled:
+			sc.DisabledOn = nil
+			s.startAccrual(sc, s.now)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, sc.SweepConfig)
+}
+
+func (s *Server) listSweeps(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wa, ok := s.lookupWallet(w, r)
+	if !ok {
+		return
+	}
+
+	sweeps := []*moov.Sweep{}
+	for _, sc := range s.sweepConfigs.list(func(sc *sweepConfig) bool { return sc.WalletID == wa.WalletID }) {
+		sweeps = append(sweeps, sc.sweeps.list(func(sw *moov.Sweep) bool {
+			return !q.Has("status") || string(sw.Status) == q.Get("status")
+		})...)
+	}
+
+	out := []moov.Sweep{}
+	for _, sw := range page(r, sweeps) {
+		out = append(out, *sw)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getSweep(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wa, ok := s.lookupWallet(w, r)
+	if !ok {
+		return
+	}
+
+	for _, sc := range s.sweepConfigs.list(func(sc *sweepConfig) bool { return sc.WalletID == wa.WalletID }) {
+		if sw, ok := sc.sweeps.get(r.PathValue("sweepID")); ok {
+			writeJSON(w, http.StatusOK, sw)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "sweep not found")
+}
+
+func (s *Server) lookupSweepConfig(w http.ResponseWriter, r *http.Request) (*sweepConfig, bool) {
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	sc, ok := s.sweepConfigs.get(r.PathValue("sweepConfigID"))
+	if !ok || sc.accountID != a.AccountID {
+		writeError(w, http.StatusNotFound, "sweep config not found")
+		return nil, false
+	}
+	return sc, true
+}
+
+func (s *Server) validSweepPaymentMethods(w http.ResponseWriter, accountID, push, pull string) bool {
+	if pm, ok := s.paymentMethods.get(push); !ok || pm.accountID != accountID || pm.disabled {
+		writeError(w, http.StatusUnprocessableEntity, "push payment method not found")
+		return false
+	}
+	if pm, ok := s.paymentMethods.get(pull); !ok || pm.accountID != accountID || pm.disabled {
+		writeError(w, http.StatusUnprocessableEntity, "pull payment method not found")
+		return false
+	}
+	return true
+}
+
+func (sc *sweepConfig) accruing() *moov.Sweep {
+	for _, sw := range sc.sweeps.list(all) {
+		if sw.Status == moov.SweepStatus_Accruing {
+			return sw
+		}
+	}
+	return nil
+}
+
+// startAccrual must be called with the lock held.
+func (s *Server) startAccrual(sc *sweepConfig, start time.Time) {
+	if sc.Status != moov.SweepConfigStatus_Enabled || sc.accruing() != nil {
+		return
+	}
+
+	sw := &moov.Sweep{
+		SweepID:             uuid.NewString(),
+		Status:              moov.SweepStatus_Accruing,
+		AccrualStartedOn:    start,
+		PushPaymentMethodID: sc.PushPaymentMethod.PaymentMethodID,
+		PullPaymentMethodID: sc.PullPaymentMethod.PaymentMethodID,
+		AccruedAmount:       decimal(0),
+		Currency:            "USD",
+	}
+	if sc.StatementDescriptor != nil {
+		sw.StatementDescriptor = *sc.StatementDescriptor
+	}
+	sc.sweeps.put(sw.SweepID, sw)
+	s.emit(moov.EventTypeSweepCreated, mhooks.SweepCreated{SweepID: sw.SweepID, WalletID: sc.WalletID})
+}
+
+// closeSweeps closes every accrual period that ended, moving the wallet back to its minimum
+// balance. Must be called with the lock held.
+func (s *Server) closeSweeps() {
+	for _, sc := range s.sweepConfigs.list(all) {
+		for sw := sc.accruing(); sw != nil && !sw.AccrualStartedOn.Add(sweepAccrualPeriod).After(s.now); sw = sc.accruing() {
+			s.closeSweep(sc, sw, sw.AccrualStartedOn.Add(sweepAccrualPeriod))
+		}
+	}
+}
+
+func (s *Server) closeSweep(sc *sweepConfig, sw *moov.Sweep, end time.Time) {
+	wa, _ := s.wallets.get(sc.WalletID)
+	minimum, _ := parseDecimal(sc.MinimumBalance)
+
+	accrued := int64(0)
+	for _, tx := range wa.transactions.list(func(tx *moov.WalletTransaction) bool {
+		return !tx.CreatedOn.Before(sw.AccrualStartedOn) && tx.CreatedOn.Before(end) && tx.TransactionType != moov.WalletTransactionTypeAutoSweep
+	}) {
+		accrued += int64(tx.NetAmount)
+	}
+	sw.AccrualEndedOn = &end
+	sw.AccruedAmount = decimal(accrued)
+
+	walletPM := s.paymentMethods.list(func(pm *paymentMethod) bool { return pm.sourceID == wa.WalletID })[0]
+	excess := wa.AvailableBalance.Value - minimum
+
+	var t *transfer
+	switch {
+	case excess > 0:
+		push, _ := s.paymentMethods.get(sc.PushPaymentMethod.PaymentMethodID)
+		t = s.startTransfer(sc.accountID, walletPM, push, excess, sw.SweepID)
+	case excess < 0:
+		pull, _ := s.paymentMethods.get(sc.PullPaymentMethod.PaymentMethodID)
+		t = s.startTransfer(sc.accountID, pull, walletPM, -excess, sw.SweepID)
+	}
+
+	status := moov.SweepStatus_Closed
+	if t != nil {
+		sw.TransferID, sw.TransferAmount = t.TransferID, decimal(t.Amount.Value)
+		status = sweepStatus(t.Status, status)
+	}
+	sw.ResidualBalance = decimal(wa.AvailableBalance.Value)
+	s.updateSweep(sc, sw, status)
+
+	s.startAccrual(sc, end)
+}
+
+// sweepTransferDone updates the sweep that started a transfer once the transfer completes or
+// fails. Must be called with the lock held.
+func (s *Server) sweepTransferDone(t *transfer) {
+	if t.SweepID == nil {
+		return
+	}
+
+	for _, sc := range s.sweepConfigs.list(all) {
+		if sw, ok := sc.sweeps.get(*t.SweepID); ok && sw.TransferID != "" {
+			s.updateSweep(sc, sw, sweepStatus(t.Status, sw.Status))
+			return
+		}
+	}
+}
+
+func (s *Server) updateSweep(sc *sweepConfig, sw *moov.Sweep, status moov.SweepStatus) {
+	if sw.Status == status {
+		return
+	}
+
+	sw.Status = status
+	var transferID *string
+	if sw.TransferID != "" {
+		transferID = &sw.TransferID
+	}
+	s.emit(moov.EventTypeSweepUpdated, mhooks.SweepUpdated{SweepID: sw.SweepID, WalletID: sc.WalletID, Status: status, TransferID: transferID})
+}
+
+func sweepStatus(transferStatus moov.TransferStatus, otherwise moov.SweepStatus) moov.SweepStatus {
+	switch transferStatus {
+	case moov.TransferStatus_Completed:
+		return moov.SweepStatus_Paid
+	case moov.TransferStatus_Failed:
+		return moov.SweepStatus_Failed
+	}
+	return otherwise
+}
+
+// parseDecimal parses dollars formatted like "12.04" as cents. A nil value is 0.
+func parseDecimal(v *string) (int64, bool) {
+	if v == nil || *v == "" {
+		return 0, true
+	}
+
+	dollars, cents, _ := strings.Cut(*v, ".")
+	if len(cents) > 2 {
+		return 0, false
+	}
+	cents += strings.Repeat("0", 2-len(cents))
+
+	d, err := strconv.ParseInt(dollars, 10, 64)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+	c, err := strconv.ParseInt(cents, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return d*100 + c, true
+}
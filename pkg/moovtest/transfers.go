@@ -0,0 +1,661 @@
+package moovtest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+type transfer struct {
+	moov.Transfer
+	partnerID   string
+	source      *paymentMethod
+	destination *paymentMethod
+
+	// When a pending transfer settles, zero for transfers completing right away.
+	settleOn time.Time
+	// Wallet debited when the transfer was created, restored if the transfer fails or is canceled.
+	debited *wallet
+}
+
+type idempotentCall struct {
+	body       []byte
+	transferID string
+}
+
+var (
+	sourceTypes = []moov.PaymentMethodType{
+		moov.PaymentMethodType_MoovWallet,
+		moov.PaymentMethodType_AchDebitFund,
+		moov.PaymentMethodType_AchDebitCollect,
+		moov.PaymentMethodType_CardPayment,
+		moov.PaymentMethodType_PullFromCard,
+	}
+	destinationTypes = []moov.PaymentMethodType{
+		moov.PaymentMethodType_MoovWallet,
+		moov.PaymentMethodType_AchCreditStandard,
+		moov.PaymentMethodType_AchCreditSameDay,
+		moov.PaymentMethodType_PushToCard,
+	}
+)
+
+func (s *Server) transferRoutes() {
+	s.mux.HandleFunc("POST /accounts/{accountID}/transfers", s.createTransfer)
+	s.mux.HandleFunc("GET /accounts/{accountID}/transfers", s.listTransfers)
+	s.mux.HandleFunc("GET /accounts/{accountID}/transfers/{transferID}", s.getTransfer)
+	s.mux.HandleFunc("PATCH /accounts/{accountID}/transfers/{transferID}", s.patchTransfer)
+
+	s.mux.HandleFunc("POST /accounts/{accountID}/transfers/{transferID}/refunds", s.createRefund)
+	s.mux.HandleFunc("GET /accounts/{accountID}/transfers/{transferID}/refunds", s.listRefunds)
+	s.mux.HandleFunc("GET /accounts/{accountID}/transfers/{transferID}/refunds/{refundID}", s.getRefund)
+	s.mux.HandleFunc("POST /accounts/{accountID}/transfers/{transferID}/reversals", s.reverseTransfer)
+	s.mux.HandleFunc("POST /accounts/{accountID}/transfers/{transferID}/cancellations", s.cancelTransfer)
+	s.mux.HandleFunc("GET /accounts/{accountID}/transfers/{transferID}/cancellations", s.listCancellations)
+	s.mux.HandleFunc("GET /accounts/{accountID}/transfers/{transferID}/cancellations/{cancellationID}", s.getCancellation)
+}
+
+// createTransfer starts a transfer. Wallet-to-wallet transfers complete right away, anything
+// moving funds over ACH or card networks stays pending until it settles.
+func (s *Server) createTransfer(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "reading body: %v", err)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var create moov.CreateTransfer
+	if !decode(w, r, &create) {
+		return
+	}
+	if create.Amount.Value <= 0 {
+		writeError(w, http.StatusUnprocessableEntity, "amount must be positive")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	partner, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	key := r.Header.Get("X-Idempotency-Key")
+	if call, ok := s.idempotency[partner.AccountID+"|"+key]; key != "" && ok {
+		if !bytes.Equal(call.body, body) {
+			writeError(w, http.StatusConflict, "idempotency key was already used for a different transfer")
+			return
+		}
+		t, _ := s.transfers.get(call.transferID)
+		writeTransfer(w, r, t)
+		return
+	}
+
+	source, ok := s.paymentMethods.get(create.Source.PaymentMethodID)
+	if !ok || source.disabled || !slices.Contains(sourceTypes, source.methodType) {
+		writeError(w, http.StatusUnprocessableEntity, "invalid source payment method")
+		return
+	}
+	destination, ok := s.paymentMethods.get(create.Destination.PaymentMethodID)
+	if !ok || destination.disabled || !slices.Contains(destinationTypes, destination.methodType) {
+		writeError(w, http.StatusUnprocessableEntity, "invalid destination payment method")
+		return
+	}
+	if source.methodType != moov.PaymentMethodType_MoovWallet && destination.methodType != moov.PaymentMethodType_MoovWallet {
+		writeError(w, http.StatusUnprocessableEntity, "either the source or destination must be a wallet")
+		return
+	}
+
+	t := s.startTransfer(partner.AccountID, source, destination, create.Amount.Value, "")
+	t.Description, t.Metadata, t.ForeignID = create.Description, create.Metadata, create.ForeignID
+	if key != "" {
+		s.idempotency[partner.AccountID+"|"+key] = idempotentCall{body: body, transferID: t.TransferID}
+	}
+
+	writeTransfer(w, r, t)
+}
+
+// writeTransfer responds with the whole transfer when the client waits for the rail response.
+func writeTransfer(w http.ResponseWriter, r *http.Request, t *transfer) {
+	if r.Header.Get("X-Wait-For") == "rail-response" {
+		writeJSON(w, http.StatusOK, t.Transfer)
+		return
+	}
+	writeJSON(w, http.StatusOK, moov.TransferStarted{TransferID: t.TransferID, CreatedOn: t.CreatedOn})
+}
+
+// startTransfer creates a transfer and moves the funds that can be moved right away. Transfers
+// started by a sweep pass its ID. Must be called with the lock held.
+func (s *Server) startTransfer(partnerID string, source, destination *paymentMethod, amount int64, sweepID string) *transfer {
+	now := s.now
+	t := &transfer{
+		Transfer: moov.Transfer{
+			TransferID: uuid.NewString(),
+			CreatedOn:  now,
+			Status:     moov.TransferStatus_Pending,
+			Amount:     moov.Amount{Currency: "USD", Value: amount},
+		},
+		partnerID:   partnerID,
+		source:      source,
+		destination: destination,
+	}
+	if sweepID != "" {
+		t.SweepID = &sweepID
+	}
+	t.Source = s.transferSource(source)
+	t.Destination = s.transferDestination(destination)
+	s.transfers.put(t.TransferID, t)
+	s.emit(moov.EventTypeTransferCreated, mhooks.TransferCreated{AccountID: partnerID, TransferID: t.TransferID, Status: moov.TransferStatus_Created})
+
+	switch source.methodType {
+	case moov.PaymentMethodType_MoovWallet:
+		wa, _ := s.wallets.get(source.sourceID)
+		if wa.Status != moov.WalletStatus_Active || wa.AvailableBalance.Value < amount {
+			s.failTransfer(t, moov.FailureReason_Wallet_Insufficient_Funds)
+			return t
+		}
+		txType := moov.WalletTransactionTypePayout
+		switch {
+		case sweepID != "":
+			txType = moov.WalletTransactionTypeAutoSweep
+		case destination.methodType == moov.PaymentMethodType_MoovWallet:
+			txType = moov.WalletTransactionTypeWalletTransfer
+		}
+		tx := s.post(wa, -amount, txType, moov.WalletTransactionSourceTypeTransfer, t.TransferID)
+		tx.SweepID = t.SweepID
+		t.debited = wa
+
+	case moov.PaymentMethodType_CardPayment, moov.PaymentMethodType_PullFromCard:
+		if code, ok := s.takeCardDecline(source.sourceID); ok {
+			failureCode := string(code)
+			t.Source.CardDetails = &moov.CardDetails{Status: ptr(moov.CardTransactionStatus_Failed), FailureCode: &failureCode, FailedOn: &now}
+			s.failTransfer(t, moov.FailureReason_Source_Payment_Error)
+			return t
+		}
+		t.Source.CardDetails = &moov.CardDetails{Status: ptr(moov.CardTransactionStatus_Initiated), InitiatedOn: &now}
+
+	case moov.PaymentMethodType_AchDebitFund, moov.PaymentMethodType_AchDebitCollect:
+		t.Source.AchDetails = &moov.AchDetailsSource{Status: moov.AchStatus_Initiated, TraceNumber: traceNumber(), InitiatedOn: &now}
+	}
+
+	switch destination.methodType {
+	case moov.PaymentMethodType_MoovWallet:
+		if source.methodType == moov.PaymentMethodType_MoovWallet {
+			s.completeTransfer(t)
+			return t
+		}
+	case moov.PaymentMethodType_AchCreditStandard, moov.PaymentMethodType_AchCreditSameDay:
+		t.Destination.AchDetails = &moov.AchDetails{Status: ptr(moov.AchStatus_Initiated), TraceNumber: ptr(traceNumber()), InitiatedOn: &now}
+	case moov.PaymentMethodType_PushToCard:
+		t.Destination.CardDetails = &moov.CardDetails{Status: ptr(moov.CardTransactionStatus_Initiated), InitiatedOn: &now}
+	}
+
+	t.settleOn = now.Add(s.settlementDelay)
+	s.emitTransferUpdated(t)
+	return t
+}
+
+// settleTransfers completes pending transfers that are due, or fails them when an ACH return
+// was injected. Must be called with the lock held.
+func (s *Server) settleTransfers() {
+	due := s.transfers.list(func(t *transfer) bool {
+		return t.Status == moov.TransferStatus_Pending && !t.settleOn.IsZero() && !t.settleOn.After(s.now)
+	})
+
+	for _, t := range due {
+		now := s.now
+		if t.Source.AchDetails != nil {
+			if code, ok := s.takeACHReturn(t.source.sourceID); ok {
+				t.Source.AchDetails.Status, t.Source.AchDetails.ReturnedOn = moov.AchStatus_Returned, &now
+				t.Source.AchDetails.Return = &moov.AchException{Code: string(code), Reason: achReturnReasons[code]}
+				s.returnBankAccount(t.source.sourceID, code, moov.BankAccountStatusReason_AchDebitReturn)
+				s.failTransfer(t, moov.FailureReason_Source_Payment_Error)
+				continue
+			}
+		}
+		if t.Destination.AchDetails != nil {
+			if code, ok := s.takeACHReturn(t.destination.sourceID); ok {
+				t.Destination.AchDetails.Status, t.Destination.AchDetails.ReturnedOn = ptr(moov.AchStatus_Returned), &now
+				t.Destination.AchDetails.Return = &moov.AchException{Code: string(code), Reason: achReturnReasons[code]}
+				s.returnBankAccount(t.destination.sourceID, code, moov.BankAccountStatusReason_AchCreditReturn)
+				s.failTransfer(t, moov.FailureReason_Destination_Payment_Error)
+				continue
+			}
+		}
+		s.completeTransfer(t)
+	}
+}
+
+// completeTransfer must be called with the lock held.
+func (s *Server) completeTransfer(t *transfer) {
+	now := s.now
+	t.Status, t.CompletedOn = moov.TransferStatus_Completed, &now
+
+	if d := t.Source.AchDetails; d != nil {
+		d.Status, d.CompletedOn = moov.AchStatus_Completed, &now
+	}
+	if d := t.Source.CardDetails; d != nil {
+		d.Status, d.SettledOn, d.CompletedOn = ptr(moov.CardTransactionStatus_Completed), &now, &now
+	}
+	if d := t.Destination.AchDetails; d != nil {
+		d.Status, d.CompletedOn = ptr(moov.AchStatus_Completed), &now
+	}
+	if d := t.Destination.CardDetails; d != nil {
+		d.Status, d.CompletedOn = ptr(moov.CardTransactionStatus_Completed), &now
+	}
+
+	if t.destination.methodType == moov.PaymentMethodType_MoovWallet {
+		wa, _ := s.wallets.get(t.destination.sourceID)
+		txType := moov.WalletTransactionTypeWalletTransfer
+		switch t.source.methodType {
+		case moov.PaymentMethodType_CardPayment, moov.PaymentMethodType_PullFromCard:
+			txType = moov.WalletTransactionTypeCardPayment
+		case moov.PaymentMethodType_AchDebitFund, moov.PaymentMethodType_AchDebitCollect:
+			txType = moov.WalletTransactionTypeAccountFunding
+		}
+		tx := s.post(wa, t.Amount.Value, txType, moov.WalletTransactionSourceTypeTransfer, t.TransferID)
+		tx.SweepID = t.SweepID
+	}
+
+	s.emitTransferUpdated(t)
+	s.sweepTransferDone(t)
+}
+
+// failTransfer restores the wallet debited by the transfer. Must be called with the lock held.
+func (s *Server) failTransfer(t *transfer, reason moov.FailureReason) {
+	t.Status, t.FailureReason = moov.TransferStatus_Failed, &reason
+	s.restoreDebit(t)
+	s.emitTransferUpdated(t)
+	s.sweepTransferDone(t)
+}
+
+func (s *Server) restoreDebit(t *transfer) {
+	if t.debited == nil {
+		return
+	}
+
+	txType := moov.WalletTransactionTypePayout
+	if t.Destination.AchDetails != nil {
+		txType = moov.WalletTransactionTypeAchReversal
+	}
+	s.post(t.debited, t.Amount.Value, txType, moov.WalletTransactionSourceTypeTransfer, t.TransferID)
+	t.debited = nil
+}
+
+// returnBankAccount marks the bank account as errored for returns meaning it can't be used
+// anymore. Must be called with the lock held.
+func (s *Server) returnBankAccount(bankAccountID string, code moov.AchReturnCode, reason moov.BankAccountStatusReason) {
+	ba, ok := s.bankAccounts.get(bankAccountID)
+	if !ok {
+		return
+	}
+
+	switch code {
+	case moov.AchReturnCode_R02, moov.AchReturnCode_R03, moov.AchReturnCode_R04, moov.AchReturnCode_R16:
+		ba.ExceptionDetails = &moov.ExceptionDetails{AchReturnCode: &code, Description: achReturnReasons[code]}
+		s.updateBankAccount(ba, moov.BankAccountStatus_Errored, reason)
+	}
+}
+
+func (s *Server) emitTransferUpdated(t *transfer) {
+	s.emit(moov.EventTypeTransferUpdated, mhooks.TransferUpdated{
+		AccountID:   t.partnerID,
+		TransferID:  t.TransferID,
+		Status:      mhooks.TransferUpdatedStatus(t.Status),
+		Source:      mhooks.PaymentMethodPartial{AccountID: t.source.accountID, PaymentMethodID: t.source.id},
+		Destination: mhooks.PaymentMethodPartial{AccountID: t.destination.accountID, PaymentMethodID: t.destination.id},
+	})
+}
+
+func (s *Server) transferSource(pm *paymentMethod) moov.TransferSource {
+	view := s.paymentMethodView(pm)
+	return moov.TransferSource{
+		PaymentMethodID:   pm.id,
+		PaymentMethodType: pm.methodType,
+		Account:           s.transferAccount(pm.accountID),
+		BankAccount:       view.BankAccount,
+		Wallet:            view.Wallet,
+		Card:              view.Card,
+	}
+}
+
+func (s *Server) transferDestination(pm *paymentMethod) moov.TransferDestination {
+	view := s.paymentMethodView(pm)
+	return moov.TransferDestination{
+		PaymentMethodID:   pm.id,
+		PaymentMethodType: pm.methodType,
+		Account:           s.transferAccount(pm.accountID),
+		BankAccount:       view.BankAccount,
+		Wallet:            view.Wallet,
+		Card:              view.Card,
+	}
+}
+
+func (s *Server) transferAccount(accountID string) moov.TransferAccount {
+	out := moov.TransferAccount{AccountID: accountID}
+	if a, ok := s.accounts.get(accountID); ok {
+		out.DisplayName = a.DisplayName
+		if a.Profile.Individual != nil {
+			out.Email = a.Profile.Individual.Email
+		} else if a.Profile.Business != nil {
+			out.Email = a.Profile.Business.Email
+		}
+	}
+	return out
+}
+
+// Transfers are visible to the partner that created them and the accounts on either side.
+func (t *transfer) visibleTo(accountID string) bool {
+	return t.partnerID == accountID || t.source.accountID == accountID || t.destination.accountID == accountID
+}
+
+func (s *Server) listTransfers(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	var accountIDs []string
+	if q.Get("accountIDs") != "" {
+		accountIDs = strings.Split(q.Get("accountIDs"), ",")
+	}
+
+	out := []moov.Transfer{}
+	for _, t := range page(r, s.transfers.list(func(t *transfer) bool {
+		switch {
+		case !t.visibleTo(a.AccountID):
+			return false
+		case accountIDs != nil && !slices.ContainsFunc(accountIDs, t.visibleTo):
+			return false
+		case q.Has("status") && string(t.Status) != q.Get("status"):
+			return false
+		case q.Get("refunded") == "true" && len(t.Refunds) == 0:
+			return false
+		}
+		return true
+	})) {
+		out = append(out, t.Transfer)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getTransfer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.lookupTransfer(w, r); ok {
+		writeJSON(w, http.StatusOK, t.Transfer)
+	}
+}
+
+func (s *Server) patchTransfer(w http.ResponseWriter, r *http.Request) {
+	var patch struct {
+		Metadata map[string]string `json:"metadata"`
+	}
+	if !decode(w, r, &patch) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTransfer(w, r)
+	if !ok {
+		return
+	}
+	if patch.Metadata != nil {
+		t.Metadata = patch.Metadata
+	}
+	writeJSON(w, http.StatusOK, t.Transfer)
+}
+
+// createRefund refunds completed card payments, taking the funds back out of the wallet that
+// received them.
+func (s *Server) createRefund(w http.ResponseWriter, r *http.Request) {
+	var create moov.CreateRefund
+	if !decode(w, r, &create) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTransfer(w, r)
+	if !ok {
+		return
+	}
+
+	refund, status, msg := s.refund(t, create.Amount)
+	if refund == nil {
+		writeError(w, status, "%s", msg)
+		return
+	}
+	writeJSON(w, http.StatusOK, refund)
+}
+
+// refund must be called with the lock held. It returns the status and message to respond with
+// when the transfer can't be refunded.
+func (s *Server) refund(t *transfer, amount int64) (*moov.Refund, int, string) {
+	if t.Source.CardDetails == nil {
+		return nil, http.StatusUnprocessableEntity, "only card payments can be refunded"
+	}
+	if t.Status != moov.TransferStatus_Completed {
+		return nil, http.StatusConflict, "only completed transfers can be refunded"
+	}
+
+	refunded := int64(0)
+	if t.RefundedAmount != nil {
+		refunded = t.RefundedAmount.Value
+	}
+	remaining := t.Amount.Value - refunded
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount <= 0 || amount > remaining {
+		return nil, http.StatusConflict, "refund amount exceeds the amount left to refund"
+	}
+
+	wa, _ := s.wallets.get(t.destination.sourceID)
+	if wa.AvailableBalance.Value < amount {
+		return nil, http.StatusConflict, "wallet has insufficient funds for the refund"
+	}
+
+	now := s.now
+	refund := moov.Refund{
+		RefundID:  uuid.NewString(),
+		CreatedOn: now,
+		UpdatedOn: now,
+		Status:    moov.RefundStatus_Completed,
+		Amount:    moov.Amount{Currency: "USD", Value: amount},
+		CardDetails: &moov.RefundCardDetails{
+			Status:      moov.RefundCardStatus_Completed,
+			InitiatedOn: &now,
+			CompletedOn: &now,
+		},
+	}
+	t.Refunds = append(t.Refunds, refund)
+	t.RefundedAmount = &moov.Amount{Currency: "USD", Value: refunded + amount}
+
+	s.post(wa, -amount, moov.WalletTransactionTypeRefund, moov.WalletTransactionSourceTypeTransfer, t.TransferID)
+	s.emit(moov.EventTypeRefundCreated, mhooks.RefundCreated{AccountID: t.partnerID, TransferID: t.TransferID, RefundID: refund.RefundID})
+	s.emit(moov.EventTypeRefundUpdated, mhooks.RefundUpdated{AccountID: t.partnerID, TransferID: t.TransferID, RefundID: refund.RefundID, Status: refund.Status})
+
+	return &refund, 0, ""
+}
+
+func (s *Server) listRefunds(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTransfer(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, append([]moov.Refund{}, t.Refunds...))
+}
+
+func (s *Server) getRefund(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTransfer(w, r)
+	if !ok {
+		return
+	}
+
+	i := slices.IndexFunc(t.Refunds, func(refund moov.Refund) bool { return refund.RefundID == r.PathValue("refundID") })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "refund not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, t.Refunds[i])
+}
+
+// reverseTransfer cancels pending transfers and refunds completed card payments.
+func (s *Server) reverseTransfer(w http.ResponseWriter, r *http.Request) {
+	var create moov.CreateReversal
+	if !decode(w, r, &create) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTransfer(w, r)
+	if !ok {
+		return
+	}
+
+	if t.Status == moov.TransferStatus_Pending && (create.Amount == 0 || create.Amount == t.Amount.Value) {
+		c := s.cancel(t)
+		writeJSON(w, http.StatusOK, moov.CreatedReversal{Cancellation: &moov.CreatedCancellation{Status: c.Status, CreatedOn: c.CreatedOn}})
+		return
+	}
+
+	refund, status, msg := s.refund(t, create.Amount)
+	if refund == nil {
+		writeError(w, status, "%s", msg)
+		return
+	}
+	writeJSON(w, http.StatusOK, moov.CreatedReversal{Refund: refund})
+}
+
+func (s *Server) cancelTransfer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTransfer(w, r)
+	if !ok {
+		return
+	}
+	if t.Status != moov.TransferStatus_Pending {
+		writeError(w, http.StatusConflict, "transfer is %s", t.Status)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.cancel(t))
+}
+
+// cancel must be called with the lock held.
+func (s *Server) cancel(t *transfer) moov.Cancellation {
+	now := s.now
+	c := moov.Cancellation{
+		CancellationID: uuid.NewString(),
+		Status:         moov.CancellationStatus_Completed,
+		CreatedOn:      now,
+	}
+	t.Cancellations = append(t.Cancellations, c)
+	t.Status = moov.TransferStatus_Canceled
+
+	if d := t.Source.AchDetails; d != nil {
+		d.CanceledOn = &now
+	}
+	if d := t.Source.CardDetails; d != nil {
+		d.Status, d.CanceledOn = ptr(moov.CardTransactionStatus_Canceled), &now
+	}
+	if d := t.Destination.AchDetails; d != nil {
+		d.CanceledOn = &now
+	}
+	s.restoreDebit(t)
+
+	s.emit(moov.EventTypeCancellationCreated, mhooks.CancellationCreated{CancellationID: c.CancellationID, TransferID: t.TransferID, Status: moov.CancellationStatus_Pending})
+	s.emit(moov.EventTypeCancellationUpdated, mhooks.CancellationUpdated{CancellationID: c.CancellationID, TransferID: t.TransferID, Status: c.Status})
+	s.emitTransferUpdated(t)
+	return c
+}
+
+func (s *Server) listCancellations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTransfer(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, append([]moov.Cancellation{}, t.Cancellations...))
+}
+
+func (s *Server) getCancellation(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTransfer(w, r)
+	if !ok {
+		return
+	}
+
+	i := slices.IndexFunc(t.Cancellations, func(c moov.Cancellation) bool { return c.CancellationID == r.PathValue("cancellationID") })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "cancellation not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, t.Cancellations[i])
+}
+
+func (s *Server) lookupTransfer(w http.ResponseWriter, r *http.Request) (*transfer, bool) {
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	t, ok := s.transfers.get(r.PathValue("transferID"))
+	if !ok || !t.visibleTo(a.AccountID) {
+		writeError(w, http.StatusNotFound, "transfer not found")
+		return nil, false
+	}
+	return t, true
+}
+
+var achReturnReasons = map[moov.AchReturnCode]string{
+	moov.AchReturnCode_R02: "Account closed",
+	moov.AchReturnCode_R03: "No account or unable to locate account",
+	moov.AchReturnCode_R04: "Invalid account number",
+	moov.AchReturnCode_R05: "Unauthorized debit to consumer account",
+	moov.AchReturnCode_R07: "Authorization revoked by customer",
+	moov.AchReturnCode_R08: "Payment stopped",
+	moov.AchReturnCode_R10: "Customer advises not authorized",
+	moov.AchReturnCode_R16: "Account frozen",
+	moov.AchReturnCode_R29: "Corporate customer advises not authorized",
+}
+
+func traceNumber() string {
+	return strings.ReplaceAll(uuid.NewString(), "-", "")[:15]
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,252 @@
+package moovtest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+type wallet struct {
+	moov.Wallet
+	transactions *store[*moov.WalletTransaction]
+}
+
+func (s *Server) walletRoutes() {
+	s.mux.HandleFunc("POST /accounts/{accountID}/wallets", s.createWallet)
+	s.mux.HandleFunc("GET /accounts/{accountID}/wallets", s.listWallets)
+	s.mux.HandleFunc("GET /accounts/{accountID}/wallets/{walletID}", s.getWallet)
+	s.mux.HandleFunc("PATCH /accounts/{accountID}/wallets/{walletID}", s.updateWallet)
+	s.mux.HandleFunc("GET /accounts/{accountID}/wallets/{walletID}/transactions", s.listWalletTransactions)
+	s.mux.HandleFunc("GET /accounts/{accountID}/wallets/{walletID}/transactions/{transactionID}", s.getWalletTransaction)
+}
+
+func (s *Server) createWallet(w http.ResponseWriter, r *http.Request) {
+	var create moov.CreateWallet
+	if !decode(w, r, &create) {
+		return
+	}
+	if create.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	wa := s.addWallet(a.AccountID, create.Name, moov.WalletType_General)
+	wa.Description, wa.Metadata = create.Description, create.Metadata
+
+	writeJSON(w, http.StatusOK, wa.Wallet)
+}
+
+func (s *Server) listWallets(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	out := []moov.Wallet{}
+	for _, wa := range page(r, s.wallets.list(func(wa *wallet) bool {
+		switch {
+		case wa.PartnerAccountID != a.AccountID:
+			return false
+		case q.Has("walletType") && string(wa.WalletType) != q.Get("walletType"):
+			return false
+		case q.Has("status") && string(wa.Status) != q.Get("status"):
+			return false
+		}
+		return true
+	})) {
+		out = append(out, wa.Wallet)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getWallet(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if wa, ok := s.lookupWallet(w, r); ok {
+		writeJSON(w, http.StatusOK, wa.Wallet)
+	}
+}
+
+// updateWallet only closes wallets with no balance left.
+func (s *Server) updateWallet(w http.ResponseWriter, r *http.Request) {
+	var update moov.UpdateWallet
+	if !decode(w, r, &update) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wa, ok := s.lookupWallet(w, r)
+	if !ok {
+		return
+	}
+
+	if update.Status != nil && *update.Status == moov.WalletStatus_Closed && wa.Status != moov.WalletStatus_Closed {
+		if wa.AvailableBalance.Value != 0 {
+			writeError(w, http.StatusConflict, "wallet has a balance of %s", wa.AvailableBalance.ValueDecimal)
+			return
+		}
+		now := s.now
+		wa.Status, wa.ClosedOn = moov.WalletStatus_Closed, &now
+	}
+	if update.Name != nil {
+		wa.Name = *update.Name
+	}
+	if update.Description != nil {
+		wa.Description = *update.Description
+	}
+	if update.Metadata != nil {
+		wa.Metadata = update.Metadata
+	}
+	s.emit(moov.EventTypeWalletUpdated, mhooks.WalletUpdated{AccountID: wa.PartnerAccountID, WalletID: wa.WalletID, Status: wa.Status})
+
+	writeJSON(w, http.StatusOK, wa.Wallet)
+}
+
+func (s *Server) listWalletTransactions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wa, ok := s.lookupWallet(w, r)
+	if !ok {
+		return
+	}
+
+	out := []moov.WalletTransaction{}
+	for _, tx := range page(r, wa.transactions.list(func(tx *moov.WalletTransaction) bool {
+		switch {
+		case q.Has("transactionType") && string(tx.TransactionType) != q.Get("transactionType"):
+			return false
+		case q.Has("sourceType") && string(tx.SourceType) != q.Get("sourceType"):
+			return false
+		case q.Has("sourceID") && tx.SourceID != q.Get("sourceID"):
+			return false
+		case q.Has("status") && string(tx.Status) != q.Get("status"):
+			return false
+		case q.Has("sweepID") && (tx.SweepID == nil || *tx.SweepID != q.Get("sweepID")):
+			return false
+		}
+		return true
+	})) {
+		out = append(out, *tx)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getWalletTransaction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wa, ok := s.lookupWallet(w, r)
+	if !ok {
+		return
+	}
+
+	tx, ok := wa.transactions.get(r.PathValue("transactionID"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "wallet transaction not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, tx)
+}
+
+func (s *Server) lookupWallet(w http.ResponseWriter, r *http.Request) (*wallet, bool) {
+	a, ok := s.lookupAccount(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	wa, ok := s.wallets.get(r.PathValue("walletID"))
+	if !ok || wa.PartnerAccountID != a.AccountID {
+		writeError(w, http.StatusNotFound, "wallet not found")
+		return nil, false
+	}
+	return wa, true
+}
+
+// addWallet creates a wallet along with its moov-wallet payment method. Must be called with
+// the lock held.
+func (s *Server) addWallet(accountID, name string, walletType moov.WalletType) *wallet {
+	wa := &wallet{
+		Wallet: moov.Wallet{
+			WalletID:         uuid.NewString(),
+			AvailableBalance: moov.AvailableBalance{Currency: "USD", ValueDecimal: decimal(0)},
+			PartnerAccountID: accountID,
+			Name:             name,
+			Status:           moov.WalletStatus_Active,
+			WalletType:       walletType,
+			CreatedOn:        s.now,
+		},
+		transactions: newStore[*moov.WalletTransaction](),
+	}
+	s.wallets.put(wa.WalletID, wa)
+	s.emit(moov.EventTypeWalletCreated, mhooks.WalletCreated{AccountID: accountID, WalletID: wa.WalletID})
+
+	s.addPaymentMethods(accountID, wa.WalletID, moov.PaymentMethodType_MoovWallet)
+	return wa
+}
+
+// post moves amount in or out of the wallet, recording a completed transaction with the
+// resulting balance. Must be called with the lock held.
+func (s *Server) post(wa *wallet, amount int64, txType moov.WalletTransactionType, sourceType moov.WalletTransactionSourceType, sourceID string) *moov.WalletTransaction {
+	wa.AvailableBalance.Value += amount
+	wa.AvailableBalance.ValueDecimal = decimal(wa.AvailableBalance.Value)
+
+	tx := &moov.WalletTransaction{
+		WalletID:                wa.WalletID,
+		TransactionID:           uuid.NewString(),
+		TransactionType:         txType,
+		SourceType:              sourceType,
+		SourceID:                sourceID,
+		Status:                  moov.WalletTransactionStatus_Completed,
+		CreatedOn:               s.now,
+		CompletedOn:             s.now,
+		Currency:                "USD",
+		GrossAmount:             int(amount),
+		GrossAmountDecimal:      decimal(amount),
+		NetAmount:               int(amount),
+		NetAmountDecimal:        decimal(amount),
+		AvailableBalance:        int(wa.AvailableBalance.Value),
+		AvailableBalanceDecimal: wa.AvailableBalance.ValueDecimal,
+	}
+	wa.transactions.put(tx.TransactionID, tx)
+
+	s.emit(moov.EventTypeWalletTransactionUpdated, mhooks.WalletTransactionUpdated{
+		AccountID:     wa.PartnerAccountID,
+		WalletID:      wa.WalletID,
+		TransactionID: tx.TransactionID,
+		Status:        tx.Status,
+	})
+	s.emit(moov.EventTypeBalanceUpdated, mhooks.BalanceUpdated{AccountID: wa.PartnerAccountID, WalletID: wa.WalletID})
+	return tx
+}
+
+// decimal formats cents as dollars, e.g. 1204 as "12.04".
+func decimal(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
@@ -0,0 +1,228 @@
+package moovtest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/moovfinancial/moov-go/pkg/mhooks"
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+type webhook struct {
+	moov.Webhook
+	secret string
+}
+
+func (s *Server) webhookRoutes() {
+	s.mux.HandleFunc("POST /webhooks", s.createWebhook)
+	s.mux.HandleFunc("GET /webhooks", s.listWebhooks)
+	s.mux.HandleFunc("GET /webhooks/{webhookID}", s.getWebhook)
+	s.mux.HandleFunc("PUT /webhooks/{webhookID}", s.updateWebhook)
+	s.mux.HandleFunc("DELETE /webhooks/{webhookID}", s.deleteWebhook)
+	s.mux.HandleFunc("POST /webhooks/{webhookID}/ping", s.pingWebhook)
+	s.mux.HandleFunc("GET /webhooks/{webhookID}/secret", s.getWebhookSecret)
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var create moov.CreateWebhook
+	if !decode(w, r, &create) {
+		return
+	}
+	if create.URL == "" {
+		writeError(w, http.StatusUnprocessableEntity, "url is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hook := &webhook{
+		Webhook: moov.Webhook{
+			WebhookID:   uuid.NewString(),
+			URL:         create.URL,
+			Description: create.Description,
+			Status:      create.Status,
+			EventTypes:  create.EventTypes,
+			CreatedOn:   s.now,
+			UpdatedOn:   s.now,
+		},
+		secret: "moovtest-" + uuid.NewString(),
+	}
+	if hook.Status == "" {
+		hook.Status = moov.WebhookStatusEnabled
+	}
+	s.webhooks.put(hook.WebhookID, hook)
+
+	writeJSON(w, http.StatusOK, hook.Webhook)
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []moov.Webhook{}
+	for _, hook := range s.webhooks.list(all) {
+		out = append(out, hook.Webhook)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hook, ok := s.lookupWebhook(w, r); ok {
+		writeJSON(w, http.StatusOK, hook.Webhook)
+	}
+}
+
+func (s *Server) updateWebhook(w http.ResponseWriter, r *http.Request) {
+	var update moov.UpdateWebhook
+	if !decode(w, r, &update) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hook, ok := s.lookupWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	hook.URL, hook.Description, hook.Status, hook.EventTypes = update.URL, update.Description, update.Status, update.EventTypes
+	hook.UpdatedOn = s.now
+
+	writeJSON(w, http.StatusOK, hook.Webhook)
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hook, ok := s.lookupWebhook(w, r)
+	if !ok {
+		return
+	}
+	s.webhooks.delete(hook.WebhookID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pingWebhook sends a test event to the webhook only, it isn't recorded in Events.
+func (s *Server) pingWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	hook, ok := s.lookupWebhook(w, r)
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	target := *hook
+	event := Event{
+		EventID:   uuid.NewString(),
+		EventType: moov.EventTypeTestPing,
+		CreatedOn: s.now,
+	}
+	s.mu.Unlock()
+
+	event.Data, _ = json.Marshal(mhooks.TestPing{Ping: true})
+	status := s.deliver(target, event)
+
+	var sent map[string]any
+	raw, _ := json.Marshal(event)
+	json.Unmarshal(raw, &sent)
+
+	writeJSON(w, http.StatusOK, moov.WebhookPing{
+		Webhook:            target.Webhook,
+		RequestBodySent:    sent,
+		ResponseStatusCode: int32(status),
+	})
+}
+
+func (s *Server) getWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hook, ok := s.lookupWebhook(w, r); ok {
+		writeJSON(w, http.StatusOK, moov.WebhookSecret{Secret: hook.secret})
+	}
+}
+
+func (s *Server) lookupWebhook(w http.ResponseWriter, r *http.Request) (*webhook, bool) {
+	hook, ok := s.webhooks.get(r.PathValue("webhookID"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "webhook not found")
+		return nil, false
+	}
+	return hook, true
+}
+
+// deliverOutbox sends queued events to the enabled webhooks subscribed to them. It's called
+// without the lock held so webhook handlers can call back into the server.
+func (s *Server) deliverOutbox() {
+	s.mu.Lock()
+	events := s.outbox
+	s.outbox = nil
+	hooks := []webhook{}
+	for _, hook := range s.webhooks.list(func(hook *webhook) bool { return hook.Status == moov.WebhookStatusEnabled }) {
+		hooks = append(hooks, *hook)
+	}
+	s.mu.Unlock()
+
+	for _, event := range events {
+		for _, hook := range hooks {
+			if len(hook.EventTypes) > 0 && !slices.Contains(hook.EventTypes, event.EventType) {
+				continue
+			}
+			s.deliver(hook, event)
+		}
+	}
+}
+
+// deliver posts the event signed the way mhooks.ParseEvent verifies it, returning the status
+// code the webhook responded with or 0 if it couldn't be reached.
+func (s *Server) deliver(hook webhook, event Event) int {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return 0
+	}
+
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0
+	}
+
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	nonce := uuid.NewString()
+	mac := hmac.New(sha512.New, []byte(hook.secret))
+	mac.Write([]byte(timestamp + "|" + nonce + "|" + hook.WebhookID))
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Timestamp", timestamp)
+	req.Header.Set("X-Nonce", nonce)
+	req.Header.Set("X-Webhook-ID", hook.WebhookID)
+	req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := s.webhookClient.Do(req)
+	if err != nil {
+		return 0
+	}
+	resp.Body.Close()
+
+	s.mu.Lock()
+	if stored, ok := s.webhooks.get(hook.WebhookID); ok {
+		now := s.now
+		stored.LastUsedOn = &now
+	}
+	s.mu.Unlock()
+
+	return resp.StatusCode
+}
@@ -0,0 +1,91 @@
+package motel
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Attributes encodes the fields of a Moov model tagged with `otel:"..."` as span attributes.
+// Only tagged fields are included, so untagged (and possibly sensitive) fields never leave
+// the process. Nested structs and maps are flattened with dots, e.g. wallet.availableBalance.value.
+//
+//	span.SetAttributes(motel.Attributes("wallet", wallet)...)
+func Attributes(prefix string, model any) []attribute.KeyValue {
+	var out []attribute.KeyValue
+	encode(&out, prefix, reflect.ValueOf(model), false)
+	return out
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func encode(out *[]attribute.KeyValue, key string, v reflect.Value, omitEmpty bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() || (omitEmpty && v.IsZero()) {
+		return
+	}
+
+	if v.Type() == timeType {
+		*out = append(*out, attribute.String(key, v.Interface().(time.Time).Format(time.RFC3339Nano)))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			tag, ok := field.Tag.Lookup("otel")
+			if !ok || !field.IsExported() {
+				continue
+			}
+
+			name, opts, _ := strings.Cut(tag, ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			encode(out, join(key, name), v.Field(i), opts == "omitempty")
+		}
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			encode(out, join(key, iter.Key().String()), iter.Value(), omitEmpty)
+		}
+
+	case reflect.String:
+		*out = append(*out, attribute.String(key, v.String()))
+	case reflect.Bool:
+		*out = append(*out, attribute.Bool(key, v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		*out = append(*out, attribute.Int64(key, v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		*out = append(*out, attribute.Int64(key, int64(v.Uint())))
+	case reflect.Float32, reflect.Float64:
+		*out = append(*out, attribute.Float64(key, v.Float()))
+
+	default:
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			*out = append(*out, attribute.String(key, s.String()))
+		}
+	}
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,149 @@
+// Package motel adds OpenTelemetry tracing and metrics to calls made by a moov.Client.
+//
+//	mc, err := moov.NewClient(
+//		motel.WithTracerProvider(otel.GetTracerProvider()),
+//		motel.WithMeterProvider(otel.GetMeterProvider()),
+//	)
+package motel
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	moovgo "github.com/moovfinancial/moov-go"
+	"github.com/moovfinancial/moov-go/pkg/moov"
+)
+
+const instrumentationName = "github.com/moovfinancial/moov-go/pkg/motel"
+
+const (
+	AttrMethod      = attribute.Key("http.request.method")
+	AttrURLTemplate = attribute.Key("url.template")
+	AttrStatusCode  = attribute.Key("http.response.status_code")
+	AttrMoovStatus  = attribute.Key("moov.status")
+	AttrRequestID   = attribute.Key("moov.request_id")
+	AttrMoovVersion = attribute.Key("moov.version")
+	AttrRetryCount  = attribute.Key("moov.retry_count")
+)
+
+// WithTracerProvider creates a client span for every call made to Moov.
+func WithTracerProvider(tp trace.TracerProvider) moov.ClientConfigurable {
+	return moov.WithMiddleware(Tracing(tp))
+}
+
+// WithMeterProvider records latency and error metrics for every call made to Moov.
+func WithMeterProvider(mp metric.MeterProvider) moov.ClientConfigurable {
+	return func(c *moov.Client) error {
+		mw, err := Metrics(mp)
+		if err != nil {
+			return err
+		}
+		return moov.WithMiddleware(mw)(c)
+	}
+}
+
+// Tracing returns a middleware that wraps each call in a client span named after the endpoint's
+// path template, e.g. /accounts/{id}/transfers, so span names stay low cardinality.
+func Tracing(tp trace.TracerProvider) moov.Middleware {
+	tracer := tp.Tracer(instrumentationName, trace.WithInstrumentationVersion(moovgo.Version()))
+
+	return func(next moov.CallHandler) moov.CallHandler {
+		return func(ctx context.Context, req *moov.CallRequest) (moov.HttpCallResponse, error) {
+			ctx, span := tracer.Start(ctx, req.PathTemplate,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					AttrMethod.String(req.Method),
+					AttrURLTemplate.String(req.PathTemplate),
+					AttrMoovVersion.String(requestVersion(req)),
+				))
+			defer span.End()
+
+			resp, err := next(ctx, req)
+
+			span.SetAttributes(AttrRetryCount.Int(max(req.Attempts-1, 0)))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return resp, err
+			}
+
+			span.SetAttributes(
+				AttrStatusCode.Int(resp.StatusCode()),
+				AttrMoovStatus.String(resp.Status().Name),
+				AttrRequestID.String(resp.RequestId()),
+			)
+			if failed(resp) {
+				span.SetStatus(codes.Error, resp.Status().Name)
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// Metrics returns a middleware that records the duration of each call in the
+// moov.client.call.duration histogram and counts failed calls in moov.client.call.errors.
+func Metrics(mp metric.MeterProvider) (moov.Middleware, error) {
+	meter := mp.Meter(instrumentationName, metric.WithInstrumentationVersion(moovgo.Version()))
+
+	duration, err := meter.Float64Histogram("moov.client.call.duration",
+		metric.WithDescription("Duration of calls made to the Moov API"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	errorCount, err := meter.Int64Counter("moov.client.call.errors",
+		metric.WithDescription("Number of calls to the Moov API that failed"),
+		metric.WithUnit("{call}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next moov.CallHandler) moov.CallHandler {
+		return func(ctx context.Context, req *moov.CallRequest) (moov.HttpCallResponse, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+			elapsed := time.Since(start)
+
+			status := "network_error"
+			if err == nil {
+				status = resp.Status().Name
+			}
+
+			attrs := metric.WithAttributes(
+				AttrMethod.String(req.Method),
+				AttrURLTemplate.String(req.PathTemplate),
+				AttrMoovStatus.String(status),
+			)
+
+			duration.Record(ctx, elapsed.Seconds(), attrs)
+			if err != nil || failed(resp) {
+				errorCount.Add(ctx, 1, attrs)
+			}
+
+			return resp, err
+		}
+	}, nil
+}
+
+func failed(resp moov.HttpCallResponse) bool {
+	switch resp.Status() {
+	case moov.StatusCompleted, moov.StatusStarted:
+		return false
+	default:
+		return true
+	}
+}
+
+func requestVersion(req *moov.CallRequest) string {
+	if v, ok := req.Headers[moov.VersionHeader]; ok {
+		return v
+	}
+	return moov.PreVersioning.String()
+}
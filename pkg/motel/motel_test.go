@@ -0,0 +1,174 @@
+package motel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/moovfinancial/moov-go/pkg/moov"
+	"github.com/moovfinancial/moov-go/pkg/motel"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, cfg ...moov.ClientConfigurable) *moov.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg = append([]moov.ClientConfigurable{
+		moov.WithCredentials(moov.Credentials{PublicKey: "pk", SecretKey: "sk", Host: strings.TrimPrefix(srv.URL, "http://")}),
+		moov.WithMoovURLScheme("http"),
+	}, cfg...)
+
+	mc, err := moov.NewClient(cfg...)
+	require.NoError(t, err)
+	return mc
+}
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	calls := 0
+	mc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Request-ID", "req-123")
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`[]`))
+	},
+		motel.WithTracerProvider(tp),
+		moov.WithRetryPolicy(moov.RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+	)
+
+	_, err := mc.ListTransfers(context.Background(), "account-123")
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	require.Equal(t, "/accounts/{id}/transfers", span.Name())
+	require.Equal(t, trace.SpanKindClient, span.SpanKind())
+	require.Equal(t, codes.Unset, span.Status().Code)
+
+	attrs := attribute.NewSet(span.Attributes()...)
+	requireAttr(t, attrs, motel.AttrMethod, attribute.StringValue(http.MethodGet))
+	requireAttr(t, attrs, motel.AttrStatusCode, attribute.IntValue(http.StatusOK))
+	requireAttr(t, attrs, motel.AttrMoovStatus, attribute.StringValue("completed"))
+	requireAttr(t, attrs, motel.AttrRequestID, attribute.StringValue("req-123"))
+	requireAttr(t, attrs, motel.AttrMoovVersion, attribute.StringValue(moov.PreVersioning.String()))
+	requireAttr(t, attrs, motel.AttrRetryCount, attribute.IntValue(1))
+}
+
+func TestTracing_Failure(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	mc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}, motel.WithTracerProvider(tp))
+
+	_, err := mc.GetTransfer(context.Background(), "account-123", "transfer-123")
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "/accounts/{id}/transfers/{id}", spans[0].Name())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, "not_found", spans[0].Status().Description)
+}
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	mc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}, motel.WithMeterProvider(mp))
+
+	require.NoError(t, mc.Ping(context.Background()))
+	_, err := mc.GetTransfer(context.Background(), "account-123", "missing")
+	require.Error(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	duration, ok := metrics["moov.client.call.duration"].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 2)
+
+	errs, ok := metrics["moov.client.call.errors"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, errs.DataPoints, 1)
+	require.Equal(t, int64(1), errs.DataPoints[0].Value)
+	requireAttr(t, errs.DataPoints[0].Attributes, motel.AttrURLTemplate, attribute.StringValue("/accounts/{id}/transfers/{id}"))
+	requireAttr(t, errs.DataPoints[0].Attributes, motel.AttrMoovStatus, attribute.StringValue("not_found"))
+}
+
+func TestAttributes(t *testing.T) {
+	closed := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	wallet := moov.Wallet{
+		WalletID: "wallet-123",
+		AvailableBalance: moov.AvailableBalance{
+			Currency:     "USD",
+			Value:        1204,
+			ValueDecimal: "12.04",
+		},
+		Status:   moov.WalletStatus_Active,
+		Metadata: map[string]string{"team": "payouts"},
+		ClosedOn: &closed,
+	}
+
+	attrs := attribute.NewSet(motel.Attributes("wallet", wallet)...)
+	requireAttr(t, attrs, "wallet.walletID", attribute.StringValue("wallet-123"))
+	requireAttr(t, attrs, "wallet.availableBalance.currency", attribute.StringValue("USD"))
+	requireAttr(t, attrs, "wallet.availableBalance.value", attribute.Int64Value(1204))
+	requireAttr(t, attrs, "wallet.status", attribute.StringValue("active"))
+	requireAttr(t, attrs, "wallet.metadata.team", attribute.StringValue("payouts"))
+	requireAttr(t, attrs, "wallet.closedOn", attribute.StringValue("2025-01-02T03:04:05Z"))
+
+	// untagged fields are never encoded
+	_, found := attrs.Value("wallet.availableBalance.valueDecimal")
+	require.False(t, found)
+
+	// omitempty fields are skipped when empty
+	attrs = attribute.NewSet(motel.Attributes("", moov.OccurrenceError{})...)
+	requireAttr(t, attrs, "message", attribute.StringValue(""))
+	require.Empty(t, motel.Attributes("refund", struct {
+		RefundedTransferID string `otel:"refunded_transfer_id,omitempty"`
+	}{}))
+}
+
+func requireAttr(t *testing.T, attrs attribute.Set, key attribute.Key, want attribute.Value) {
+	t.Helper()
+
+	got, ok := attrs.Value(key)
+	require.True(t, ok, "missing attribute %s", key)
+	require.Equal(t, want, got, "attribute %s", key)
+}